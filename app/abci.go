@@ -468,7 +468,7 @@ func handleQueryCustom(app *BaseApp, path []string, req abci.RequestQuery) (res
 	// []string{"proposal", "test"} as the path.
 	resBytes, queryErr := querier(ctx, path[2:], req)
 	if queryErr != nil {
-		space, code, log := sdkerrors.ABCIInfo(err, false)
+		space, code, log := sdkerrors.ABCIInfo(queryErr, false)
 		return abci.ResponseQuery{
 			Code:      code,
 			Codespace: space,
@@ -20,13 +20,21 @@ var (
 	NewADParam                        = types.NewADParam
 	NewIPALUserRequest                = types.NewCIPALUserRequest
 	NewMsgIPALClaim                   = types.NewMsgCIPALClaim
+	NewMsgCIPALUnclaim                = types.NewMsgCIPALUnclaim
+	NewUnclaimParam                   = types.NewUnclaimParam
 	NewGenesisState                   = types.NewGenesisState
+	DefaultGenesisState               = types.DefaultGenesisState
 	NewKeeper                         = keeper.NewKeeper
 	ErrEmptyInputs                    = types.ErrEmptyInputs
 	ErrStringTooLong                  = types.ErrStringTooLong
 	ErrInvalidSignature               = types.ErrInvalidSignature
 	ErrIPALClaimUserRequestExpired    = types.ErrIPALClaimUserRequestExpired
 	ErrCIPALClaimUserRequestSigVerify = types.ErrCIPALClaimUserRequestSigVerify
+	ErrInvalidNonce                   = types.ErrInvalidNonce
+	ErrServiceNotFound                = types.ErrServiceNotFound
+	ErrInvalidServiceAddress          = types.ErrInvalidServiceAddress
+	NewParams                         = types.NewParams
+	DefaultParams                     = types.DefaultParams
 	ModuleCdc                         = types.ModuleCdc
 	AttributeValueCategory            = types.AttributeValueCategory
 )
@@ -38,4 +46,10 @@ type (
 	IPALUserRequest = types.CIPALUserRequest
 	ADParam         = types.ADParam
 	CIPALObject     = types.CIPALObject
+	UserNonce       = types.UserNonce
+	MsgCIPALUnclaim = types.MsgCIPALUnclaim
+	UnclaimParam    = types.UnclaimParam
+	HistoryEntry    = types.HistoryEntry
+	Params          = types.Params
+	ServedUser      = types.ServedUser
 )
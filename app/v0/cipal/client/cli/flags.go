@@ -5,4 +5,8 @@ const (
 	flagProxy          = "proxy"
 	flagServiceAddress = "service_address"
 	flagServiceType    = "service_type"
+	flagNonce          = "nonce"
+	flagPage           = "page"
+	flagLimit          = "limit"
+	flagCursor         = "cursor"
 )
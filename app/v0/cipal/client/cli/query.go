@@ -5,11 +5,13 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 
 	"github.com/netcloth/netcloth-chain/app/v0/cipal/types"
 	"github.com/netcloth/netcloth-chain/client"
 	"github.com/netcloth/netcloth-chain/client/context"
 	"github.com/netcloth/netcloth-chain/codec"
+	"github.com/netcloth/netcloth-chain/types/rest"
 	"github.com/netcloth/netcloth-chain/version"
 )
 
@@ -25,6 +27,10 @@ func GetQueryCmd(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	cipalQueryCmd.AddCommand(client.GetCommands(
 		GetCmdQueryCIPAL(queryRoute, cdc),
 		GetCmdCountCIPAL(queryRoute, cdc),
+		GetCmdQueryNonce(queryRoute, cdc),
+		GetCmdQueryHistory(queryRoute, cdc),
+		GetCmdQueryParams(queryRoute, cdc),
+		GetCmdQueryCIPALList(queryRoute, cdc),
 	)...)
 
 	return cipalQueryCmd
@@ -44,17 +50,63 @@ func GetCmdCountCIPAL(queryRouter string, cdc *codec.Codec) *cobra.Command {
 		),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
-			resKVs, _, err := cliCtx.QuerySubspace(types.CIPALObjectKey, types.StoreKey)
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRouter, types.QueryCIPALCount), nil)
 			if err != nil {
 				return err
 			}
 
-			fmt.Println(len(resKVs))
+			var count struct {
+				Count int `json:"count"`
+			}
+			cdc.MustUnmarshalJSON(res, &count)
+			fmt.Println(count.Count)
 			return nil
 		},
 	}
 }
 
+func GetCmdQueryCIPALList(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "Querying a page of the cipal objects",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Query a page of the cipal objects, by user address.
+	The next page starts after the cursor returned with the previous one, or is given by page and limit.
+	Example:
+	$ %s query cipal list --page=1 --limit=30
+	$ %s query cipal list --limit=30 --cursor=<next_cursor of the previous page>
+	`,
+				version.ClientName, version.ClientName,
+			),
+		),
+		Args: cobra.NoArgs,
+
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			bz, err := cdc.MarshalJSON(types.NewQueryCIPALListParams(viper.GetInt(flagPage), viper.GetInt(flagLimit), viper.GetString(flagCursor)))
+			if err != nil {
+				return err
+			}
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryList), bz)
+			if err != nil {
+				return err
+			}
+
+			var result types.CIPALListResult
+			cdc.MustUnmarshalJSON(res, &result)
+			return cliCtx.PrintOutput(result)
+		},
+	}
+
+	cmd.Flags().Int(flagPage, rest.DefaultPage, "page of the cipal objects, ignored with a cursor")
+	cmd.Flags().Int(flagLimit, rest.DefaultLimit, fmt.Sprintf("max cipal objects of the page, at most %d", types.MaxListLimit))
+	cmd.Flags().String(flagCursor, "", "cursor returned with the previous page")
+
+	return cmd
+}
+
 func GetCmdQueryCIPAL(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	return &cobra.Command{
 		Use:   "query_cipal",
@@ -87,3 +139,109 @@ func GetCmdQueryCIPAL(queryRoute string, cdc *codec.Codec) *cobra.Command {
 		},
 	}
 }
+
+func GetCmdQueryNonce(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "nonce [user-address]",
+		Short: "Querying the nonce of a user",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Query the nonce the next user request of a user must be signed with.
+	Example:
+	$ %s query cipal nonce <user-address>
+	`,
+				version.ClientName,
+			),
+		),
+		Args: cobra.ExactArgs(1),
+
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			nonce, err := QueryNonce(cliCtx, args[0])
+			if err != nil {
+				return err
+			}
+
+			return cliCtx.PrintOutput(nonce)
+		},
+	}
+}
+
+func GetCmdQueryHistory(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "history [user-address]",
+		Short: "Querying the service history of a user",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Query the changes of the services of a user, oldest first.
+	Example:
+	$ %s query cipal history <user-address>
+	`,
+				version.ClientName,
+			),
+		),
+		Args: cobra.ExactArgs(1),
+
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			bz, err := cdc.MarshalJSON(types.NewQueryCIPALParams(args[0]))
+			if err != nil {
+				return err
+			}
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryHistory), bz)
+			if err != nil {
+				return err
+			}
+
+			var history types.HistoryEntries
+			cdc.MustUnmarshalJSON(res, &history)
+			return cliCtx.PrintOutput(history)
+		},
+	}
+}
+
+func GetCmdQueryParams(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "params",
+		Args:  cobra.NoArgs,
+		Short: "Query the current cipal parameters",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Query values set as cipal parameters.
+	Example:
+	$ %s query cipal params
+	`,
+				version.ClientName,
+			),
+		),
+
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryParameters), nil)
+			if err != nil {
+				return err
+			}
+
+			var params types.Params
+			cdc.MustUnmarshalJSON(res, &params)
+			return cliCtx.PrintOutput(params)
+		},
+	}
+}
+
+// QueryNonce queries the nonce the next user request of the user must be signed with
+func QueryNonce(cliCtx context.CLIContext, userAddress string) (nonce types.UserNonce, err error) {
+	bz, err := cliCtx.Codec.MarshalJSON(types.NewQueryCIPALParams(userAddress))
+	if err != nil {
+		return nonce, err
+	}
+
+	res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryNonce), bz)
+	if err != nil {
+		return nonce, err
+	}
+
+	err = cliCtx.Codec.UnmarshalJSON(res, &nonce)
+	return nonce, err
+}
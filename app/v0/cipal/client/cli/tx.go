@@ -23,6 +23,7 @@ func CIPALCmd(cdc *codec.Codec) *cobra.Command {
 	}
 	txCmd.AddCommand(
 		CIPALClaimCmd(cdc),
+		CIPALUnclaimCmd(cdc),
 	)
 	return txCmd
 }
@@ -31,7 +32,7 @@ func CIPALClaimCmd(cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "claim",
 		Short:   "Create and sign a CIPALClaim tx",
-		Example: "nchcli cipal claim --user=<user key name> --proxy=<proxy key name> --service_address=<service address> --service_type=<service type>",
+		Example: "nchcli cipal claim --user=<user key name> --proxy=<proxy key name> --service_address=<service address> --service_type=<service type> [--nonce=<nonce>]",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
 			cliCtxUser := context.NewCLIContextWithFrom(viper.GetString(flagUser)).WithCodec(cdc)
@@ -45,26 +46,20 @@ func CIPALClaimCmd(cdc *codec.Codec) *cobra.Command {
 			serviceAddress := viper.GetString(flagServiceAddress)
 			serviceType := viper.GetUint64(flagServiceType)
 			expiration := time.Now().UTC().AddDate(0, 0, 1)
-			adMsg := types.NewADParam(userAddress, serviceAddress, serviceType, expiration)
-
-			// build msg
-			passphrase, err := keys.GetPassphrase(cliCtxUser.GetFromName())
+			nonce, err := getNonce(cmd, cliCtxUser, userAddress)
 			if err != nil {
 				return err
 			}
-			// sign
-			sigBytes, pubkey, err := txBldr.Keybase().Sign(info.GetName(), passphrase, adMsg.GetSignBytes())
+			adMsg := types.NewADParam(userAddress, serviceAddress, serviceType, expiration, nonce)
+
+			stdSig, err := signUserRequest(txBldr, cliCtxUser, adMsg.GetSignBytes())
 			if err != nil {
 				return err
 			}
-			stdSig := auth.StdSignature{
-				PubKey:    pubkey,
-				Signature: sigBytes,
-			}
 
 			// build and sign the transaction, then broadcast to Tendermint
 			cliCtxProxy := context.NewCLIContextWithFrom(viper.GetString(flagProxy)).WithCodec(cdc)
-			msg := types.NewMsgCIPALClaim(cliCtxProxy.GetFromAddress(), userAddress, serviceAddress, serviceType, expiration, stdSig)
+			msg := types.NewMsgCIPALClaim(cliCtxProxy.GetFromAddress(), userAddress, serviceAddress, serviceType, expiration, nonce, stdSig)
 			if err := msg.ValidateBasic(); err != nil {
 				return err
 			}
@@ -76,6 +71,7 @@ func CIPALClaimCmd(cdc *codec.Codec) *cobra.Command {
 	cmd.Flags().String(flagProxy, "", "proxy account")
 	cmd.Flags().String(flagServiceAddress, "", "service address")
 	cmd.Flags().String(flagServiceType, "", "service type. 1:chatting, 2:storage...")
+	cmd.Flags().Uint64(flagNonce, 0, "nonce of the user request, the current nonce of the user if not set")
 
 	cmd.MarkFlagRequired(flagUser)
 	cmd.MarkFlagRequired(flagProxy)
@@ -86,3 +82,85 @@ func CIPALClaimCmd(cdc *codec.Codec) *cobra.Command {
 
 	return cmd
 }
+
+func CIPALUnclaimCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "unclaim",
+		Short:   "Create and sign a CIPALUnclaim tx, removing the service of a type of the user",
+		Example: "nchcli cipal unclaim --user=<user key name> --proxy=<proxy key name> --service_type=<service type> [--nonce=<nonce>]",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtxUser := context.NewCLIContextWithFrom(viper.GetString(flagUser)).WithCodec(cdc)
+
+			info, err := txBldr.Keybase().Get(cliCtxUser.GetFromName())
+			if err != nil {
+				return err
+			}
+			userAddress := info.GetAddress().String()
+
+			serviceType := viper.GetUint64(flagServiceType)
+			expiration := time.Now().UTC().AddDate(0, 0, 1)
+			nonce, err := getNonce(cmd, cliCtxUser, userAddress)
+			if err != nil {
+				return err
+			}
+			param := types.NewUnclaimParam(userAddress, serviceType, expiration, nonce)
+
+			stdSig, err := signUserRequest(txBldr, cliCtxUser, param.GetSignBytes())
+			if err != nil {
+				return err
+			}
+
+			cliCtxProxy := context.NewCLIContextWithFrom(viper.GetString(flagProxy)).WithCodec(cdc)
+			msg := types.NewMsgCIPALUnclaim(cliCtxProxy.GetFromAddress(), userAddress, serviceType, expiration, nonce, stdSig)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return utils.GenerateOrBroadcastMsgs(cliCtxProxy, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().String(flagUser, "", "user account")
+	cmd.Flags().String(flagProxy, "", "proxy account")
+	cmd.Flags().String(flagServiceType, "", "service type. 1:chatting, 2:storage...")
+	cmd.Flags().Uint64(flagNonce, 0, "nonce of the user request, the current nonce of the user if not set")
+
+	cmd.MarkFlagRequired(flagUser)
+	cmd.MarkFlagRequired(flagProxy)
+	cmd.MarkFlagRequired(flagServiceType)
+
+	cmd = client.PostCommands(cmd)[0]
+
+	return cmd
+}
+
+// getNonce returns the nonce flag, or the current nonce of the user if not set
+func getNonce(cmd *cobra.Command, cliCtx context.CLIContext, userAddress string) (uint64, error) {
+	if cmd.Flags().Changed(flagNonce) {
+		return viper.GetUint64(flagNonce), nil
+	}
+
+	userNonce, err := QueryNonce(cliCtx, userAddress)
+	if err != nil {
+		return 0, err
+	}
+	return userNonce.Nonce, nil
+}
+
+// signUserRequest signs the user request with the key of the user
+func signUserRequest(txBldr auth.TxBuilder, cliCtxUser context.CLIContext, signBytes []byte) (stdSig auth.StdSignature, err error) {
+	passphrase, err := keys.GetPassphrase(cliCtxUser.GetFromName())
+	if err != nil {
+		return stdSig, err
+	}
+
+	sigBytes, pubkey, err := txBldr.Keybase().Sign(cliCtxUser.GetFromName(), passphrase, signBytes)
+	if err != nil {
+		return stdSig, err
+	}
+
+	return auth.StdSignature{
+		PubKey:    pubkey,
+		Signature: sigBytes,
+	}, nil
+}
@@ -27,6 +27,26 @@ func registerQueryRoutes(cliCtx context.CLIContext, r *mux.Router) {
 		"/cipal/batch_query",
 		CIPALsFn(cliCtx),
 	).Methods("POST")
+
+	r.HandleFunc(
+		"/cipal/nonce/{accAddress}",
+		NonceFn(cliCtx),
+	).Methods("GET")
+
+	r.HandleFunc(
+		"/cipal/history/{accAddress}",
+		HistoryFn(cliCtx),
+	).Methods("GET")
+
+	r.HandleFunc(
+		"/cipal/parameters",
+		ParamsFn(cliCtx),
+	).Methods("GET")
+
+	r.HandleFunc(
+		"/cipal/list",
+		CIPALListFn(cliCtx),
+	).Methods("GET")
 }
 
 func queryCIPAL(cliCtx context.CLIContext, endpoint string) http.HandlerFunc {
@@ -72,6 +92,54 @@ func queryCIPALCount(cliCtx context.CLIContext, endpoint string) http.HandlerFun
 	}
 }
 
+func queryParams(cliCtx context.CLIContext, endpoint string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		res, height, err := cliCtx.QueryWithData(endpoint, nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func queryCIPALList(cliCtx context.CLIContext, endpoint string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		_, page, limit, err := rest.ParseHTTPArgs(r)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		bz, err := cliCtx.Codec.MarshalJSON(types.NewQueryCIPALListParams(page, limit, r.URL.Query().Get("cursor")))
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		res, height, err := cliCtx.QueryWithData(endpoint, bz)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
 func queryCIPALs(cliCtx context.CLIContext, endpoint string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		var params types.QueryCIPALsParams
@@ -101,6 +169,32 @@ func queryCIPALs(cliCtx context.CLIContext, endpoint string) http.HandlerFunc {
 	}
 }
 
+// queryByAddress queries the endpoint with the address of the path
+func queryByAddress(cliCtx context.CLIContext, endpoint string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params := types.NewQueryCIPALParams(mux.Vars(r)["accAddress"])
+		bz, err := cliCtx.Codec.MarshalJSON(params)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		res, height, err := cliCtx.QueryWithData(endpoint, bz)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
 func CIPALFn(cliCtx context.CLIContext) http.HandlerFunc {
 	return queryCIPAL(cliCtx, fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryCIPAL))
 }
@@ -112,3 +206,19 @@ func CIPALCountFn(cliCtx context.CLIContext) http.HandlerFunc {
 func CIPALsFn(cliCtx context.CLIContext) http.HandlerFunc {
 	return queryCIPALs(cliCtx, fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryCIPALs))
 }
+
+func NonceFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return queryByAddress(cliCtx, fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryNonce))
+}
+
+func HistoryFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return queryByAddress(cliCtx, fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryHistory))
+}
+
+func ParamsFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return queryParams(cliCtx, fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryParameters))
+}
+
+func CIPALListFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return queryCIPALList(cliCtx, fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryList))
+}
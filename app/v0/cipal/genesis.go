@@ -1,21 +1,103 @@
 package cipal
 
 import (
+	"io"
+
 	"github.com/netcloth/netcloth-chain/app/v0/cipal/keeper"
 	"github.com/netcloth/netcloth-chain/app/v0/cipal/types"
 	sdk "github.com/netcloth/netcloth-chain/types"
 )
 
 func InitGenesis(ctx sdk.Context, keeper keeper.Keeper, data types.GenesisState) {
+	keeper.SetParams(ctx, data.Params)
+
 	for _, obj := range data.CIPALObjs {
 		keeper.SetCIPALObject(ctx, obj)
 	}
+
+	for _, n := range data.Nonces {
+		keeper.SetNonce(ctx, n.UserAddress, n.Nonce)
+	}
+
+	for _, entry := range data.History {
+		keeper.AppendHistory(ctx, entry)
+	}
 }
 
 // ExportGenesis returns a GenesisState for a given context and keeper.
 func ExportGenesis(ctx sdk.Context, keeper Keeper) GenesisState {
 	cipals := keeper.GetAllCIPALObjects(ctx)
-	return types.GenesisState{
-		CIPALObjs: cipals,
+
+	var history types.HistoryEntries
+	keeper.IterateHistory(ctx, func(entry types.HistoryEntry) bool {
+		history = append(history, entry)
+		return false
+	})
+	return types.NewGenesisState(keeper.GetParams(ctx), cipals, keeper.GetAllNonces(ctx), history)
+}
+
+// WriteGenesis writes the genesis state as JSON, one entry at a time, so that the export does not decode
+// all the cipal objects, nonces and history into slices. The memory is bounded by the writer only.
+func WriteGenesis(ctx sdk.Context, keeper Keeper, w io.Writer) error {
+	gw := genesisWriter{w: w}
+
+	gw.write(`{"params":`)
+	gw.writeJSON(keeper.GetParams(ctx))
+
+	gw.write(`,"cipal_objects":[`)
+	first := true
+	keeper.IterateCIPALObjects(ctx, func(obj types.CIPALObject) bool {
+		return !gw.writeElem(&first, obj)
+	})
+
+	gw.write(`],"nonces":[`)
+	first = true
+	keeper.IterateNonces(ctx, func(nonce types.UserNonce) bool {
+		return !gw.writeElem(&first, nonce)
+	})
+
+	gw.write(`],"history":[`)
+	first = true
+	keeper.IterateHistory(ctx, func(entry types.HistoryEntry) bool {
+		return !gw.writeElem(&first, entry)
+	})
+
+	gw.write(`]}`)
+	return gw.err
+}
+
+// genesisWriter writes JSON until the first error
+type genesisWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (gw *genesisWriter) write(s string) bool {
+	if gw.err == nil {
+		_, gw.err = io.WriteString(gw.w, s)
+	}
+	return gw.err == nil
+}
+
+func (gw *genesisWriter) writeJSON(v interface{}) bool {
+	if gw.err != nil {
+		return false
+	}
+
+	bz, err := types.ModuleCdc.MarshalJSON(v)
+	if err != nil {
+		gw.err = err
+		return false
+	}
+	_, gw.err = gw.w.Write(bz)
+	return gw.err == nil
+}
+
+// writeElem writes an element of an array, separated from the previous one
+func (gw *genesisWriter) writeElem(first *bool, v interface{}) bool {
+	if !*first && !gw.write(",") {
+		return false
 	}
+	*first = false
+	return gw.writeJSON(v)
 }
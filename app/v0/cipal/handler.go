@@ -1,9 +1,12 @@
 package cipal
 
 import (
+	"time"
+
 	"github.com/netcloth/netcloth-chain/app/v0/cipal/types"
 	abci "github.com/tendermint/tendermint/abci/types"
 
+	"github.com/netcloth/netcloth-chain/app/v0/auth"
 	"github.com/netcloth/netcloth-chain/app/v0/cipal/keeper"
 	sdk "github.com/netcloth/netcloth-chain/types"
 	sdkerrors "github.com/netcloth/netcloth-chain/types/errors"
@@ -16,20 +19,50 @@ func NewHandler(k Keeper) sdk.Handler {
 		switch msg := msg.(type) {
 		case MsgIPALClaim:
 			return handleMsgIPALClaim(ctx, k, msg)
+		case MsgCIPALUnclaim:
+			return handleMsgCIPALUnclaim(ctx, k, msg)
 		default:
 			return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unrecognized %s message type: %T", ModuleName, msg)
 		}
 	}
 }
 
-func handleMsgIPALClaim(ctx sdk.Context, k Keeper, msg MsgIPALClaim) (*sdk.Result, error) {
-	if ctx.BlockHeader().Time.After(msg.UserRequest.Params.Expiration) {
-		return nil, sdkerrors.Wrap(ErrIPALClaimUserRequestExpired, "user request expired")
+// verifyUserRequest checks the user request is not expired, is signed by the key of the user and has
+// the current nonce of the user, the handlers increment the nonce once the request is applied
+func verifyUserRequest(ctx sdk.Context, k Keeper, userAddress string, expiration time.Time, nonce uint64, signBytes []byte, sig auth.StdSignature) error {
+	if ctx.BlockHeader().Time.After(expiration) {
+		return sdkerrors.Wrap(ErrIPALClaimUserRequestExpired, "user request expired")
+	}
+
+	user, err := sdk.AccAddressFromBech32(userAddress)
+	if err != nil {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, err.Error())
+	}
+	if sig.PubKey == nil || !user.Equals(sdk.AccAddress(sig.PubKey.Address())) {
+		return sdkerrors.Wrapf(ErrInvalidSignature, "user request not signed by the key of %s", userAddress)
 	}
 
-	sigVerifyPass := msg.UserRequest.Sig.VerifyBytes(msg.UserRequest.Params.GetSignBytes(), msg.UserRequest.Sig.Signature)
+	sigVerifyPass := sig.VerifyBytes(signBytes, sig.Signature)
 	if !sigVerifyPass {
-		return nil, sdkerrors.Wrap(ErrCIPALClaimUserRequestSigVerify, "user signature verify failed")
+		return sdkerrors.Wrap(ErrCIPALClaimUserRequestSigVerify, "user signature verify failed")
+	}
+
+	expected := k.GetNonce(ctx, userAddress)
+	if nonce != expected {
+		return sdkerrors.Wrapf(ErrInvalidNonce, "expected %d, got %d", expected, nonce)
+	}
+	return nil
+}
+
+func handleMsgIPALClaim(ctx sdk.Context, k Keeper, msg MsgIPALClaim) (*sdk.Result, error) {
+	params := msg.UserRequest.Params
+	err := verifyUserRequest(ctx, k, params.UserAddress, params.Expiration, params.Nonce, params.GetSignBytes(), msg.UserRequest.Sig)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := k.ValidateServiceInfo(ctx, params.ServiceInfo); err != nil {
+		return nil, err
 	}
 
 	obj, found := k.GetCIPALObject(ctx, msg.UserRequest.Params.UserAddress)
@@ -47,16 +80,20 @@ func handleMsgIPALClaim(ctx sdk.Context, k Keeper, msg MsgIPALClaim) (*sdk.Resul
 		if updateIndex != -1 {
 			if si.Address != msg.UserRequest.Params.ServiceInfo.Address {
 				obj.ServiceInfos[updateIndex].Address = msg.UserRequest.Params.ServiceInfo.Address
+				k.AppendHistory(ctx, types.NewHistoryEntry(params.UserAddress, ctx.BlockHeight(), si.Type, si.Address, params.ServiceInfo.Address))
 			}
 		} else {
 			obj.ServiceInfos = append(obj.ServiceInfos, msg.UserRequest.Params.ServiceInfo)
+			k.AppendHistory(ctx, types.NewHistoryEntry(params.UserAddress, ctx.BlockHeight(), params.ServiceInfo.Type, "", params.ServiceInfo.Address))
 		}
 
 		k.SetCIPALObject(ctx, obj)
 	} else {
 		obj = NewIPALObject(msg.UserRequest.Params.UserAddress, msg.UserRequest.Params.ServiceInfo.Address, msg.UserRequest.Params.ServiceInfo.Type)
 		k.SetCIPALObject(ctx, obj)
+		k.AppendHistory(ctx, types.NewHistoryEntry(params.UserAddress, ctx.BlockHeight(), params.ServiceInfo.Type, "", params.ServiceInfo.Address))
 	}
+	k.SetNonce(ctx, params.UserAddress, params.Nonce+1)
 
 	ctx.EventManager().EmitEvent(
 		sdk.NewEvent(
@@ -68,6 +105,54 @@ func handleMsgIPALClaim(ctx sdk.Context, k Keeper, msg MsgIPALClaim) (*sdk.Resul
 	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
 }
 
+func handleMsgCIPALUnclaim(ctx sdk.Context, k Keeper, msg MsgCIPALUnclaim) (*sdk.Result, error) {
+	params := msg.UserRequest.Params
+	err := verifyUserRequest(ctx, k, params.UserAddress, params.Expiration, params.Nonce, params.GetSignBytes(), msg.UserRequest.Sig)
+	if err != nil {
+		return nil, err
+	}
+
+	obj, found := k.GetCIPALObject(ctx, params.UserAddress)
+	if !found {
+		return nil, sdkerrors.Wrapf(ErrServiceNotFound, "user: %s", params.UserAddress)
+	}
+
+	index := -1
+	for i, v := range obj.ServiceInfos {
+		if v.Type == params.ServiceType {
+			index = i
+			break
+		}
+	}
+	if index == -1 {
+		return nil, sdkerrors.Wrapf(ErrServiceNotFound, "user: %s, service type: %d", params.UserAddress, params.ServiceType)
+	}
+
+	removed := obj.ServiceInfos[index]
+	obj.ServiceInfos = append(obj.ServiceInfos[:index], obj.ServiceInfos[index+1:]...)
+	if len(obj.ServiceInfos) == 0 {
+		k.DeleteCIPALObject(ctx, params.UserAddress)
+	} else {
+		k.SetCIPALObject(ctx, obj)
+	}
+	k.AppendHistory(ctx, types.NewHistoryEntry(params.UserAddress, ctx.BlockHeight(), removed.Type, removed.Address, ""))
+	k.SetNonce(ctx, params.UserAddress, params.Nonce+1)
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, AttributeValueCategory),
+		),
+	)
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+// BeginBlocker builds the count and the indexes missing on the chains started before them
+func BeginBlocker(ctx sdk.Context, k keeper.Keeper) {
+	k.BuildMissingIndexes(ctx)
+}
+
 func EndBlocker(ctx sdk.Context, k keeper.Keeper) []abci.ValidatorUpdate {
 	return []abci.ValidatorUpdate{}
 }
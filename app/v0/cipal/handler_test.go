@@ -1,12 +1,20 @@
 package cipal
 
 import (
+	"bytes"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
 
+	"github.com/netcloth/netcloth-chain/app/v0/auth"
+	"github.com/netcloth/netcloth-chain/app/v0/cipal/keeper"
+	"github.com/netcloth/netcloth-chain/app/v0/cipal/types"
+	ipaltypes "github.com/netcloth/netcloth-chain/app/v0/ipal/types"
 	sdk "github.com/netcloth/netcloth-chain/types"
 )
 
@@ -19,3 +27,186 @@ func TestInvalidMsg(t *testing.T) {
 	require.NotNil(t, err)
 	require.True(t, strings.Contains(err.Error(), "unrecognized cipal message type"))
 }
+
+// newClaimMsg returns a claim of the user signed by the key
+func newClaimMsg(t *testing.T, key crypto.PrivKey, serviceAddress string, expiration time.Time, nonce uint64) MsgIPALClaim {
+	userAddress := sdk.AccAddress(key.PubKey().Address()).String()
+	param := NewADParam(userAddress, serviceAddress, 1, expiration, nonce)
+	sig, err := key.Sign(param.GetSignBytes())
+	require.Nil(t, err)
+
+	return NewMsgIPALClaim(sdk.AccAddress([]byte("proxy")), userAddress, serviceAddress, 1, expiration, nonce, auth.StdSignature{PubKey: key.PubKey(), Signature: sig})
+}
+
+func TestMsgIPALClaimNonce(t *testing.T) {
+	ctx, k, _ := keeper.CreateTestInput(t)
+	h := NewHandler(k)
+
+	key := secp256k1.GenPrivKey()
+	userAddress := sdk.AccAddress(key.PubKey().Address()).String()
+	expiration := ctx.BlockHeader().Time.Add(time.Hour)
+	require.Equal(t, uint64(0), k.GetNonce(ctx, userAddress))
+
+	first := newClaimMsg(t, key, "nch1first", expiration, 0)
+	_, err := h(ctx, first)
+	require.Nil(t, err)
+	require.Equal(t, uint64(1), k.GetNonce(ctx, userAddress))
+
+	second := newClaimMsg(t, key, "nch1second", expiration, 1)
+	_, err = h(ctx, second)
+	require.Nil(t, err)
+	require.Equal(t, uint64(2), k.GetNonce(ctx, userAddress))
+
+	// replaying the first request does not roll the user back
+	_, err = h(ctx, first)
+	require.True(t, ErrInvalidNonce.Is(err))
+	obj, found := k.GetCIPALObject(ctx, userAddress)
+	require.True(t, found)
+	require.Equal(t, "nch1second", obj.ServiceInfos[0].Address)
+
+	// nonce ahead of the stored one
+	_, err = h(ctx, newClaimMsg(t, key, "nch1third", expiration, 3))
+	require.True(t, ErrInvalidNonce.Is(err))
+	require.Equal(t, uint64(2), k.GetNonce(ctx, userAddress))
+
+	// the nonce is part of the signature
+	forged := second
+	forged.UserRequest.Params.Nonce = 2
+	_, err = h(ctx, forged)
+	require.True(t, ErrCIPALClaimUserRequestSigVerify.Is(err))
+
+	// a request of the user signed with another key is rejected, the nonce of the user unchanged
+	other := secp256k1.GenPrivKey()
+	param := NewADParam(userAddress, "nch1other", 1, expiration, 2)
+	sig, err := other.Sign(param.GetSignBytes())
+	require.Nil(t, err)
+	_, err = h(ctx, NewMsgIPALClaim(sdk.AccAddress([]byte("proxy")), userAddress, "nch1other", 1, expiration, 2, auth.StdSignature{PubKey: other.PubKey(), Signature: sig}))
+	require.True(t, ErrInvalidSignature.Is(err))
+	require.Equal(t, uint64(2), k.GetNonce(ctx, userAddress))
+
+	gs := ExportGenesis(ctx, k)
+	require.Equal(t, types.UserNonces{types.NewUserNonce(userAddress, 2)}, gs.Nonces)
+
+	var buf bytes.Buffer
+	require.Nil(t, WriteGenesis(ctx, k, &buf))
+	var streamed GenesisState
+	require.Nil(t, ModuleCdc.UnmarshalJSON(buf.Bytes(), &streamed))
+	require.Equal(t, gs, streamed)
+}
+
+func TestMsgCIPALUnclaimAndHistory(t *testing.T) {
+	ctx, k, _ := keeper.CreateTestInput(t)
+	h := NewHandler(k)
+
+	key := secp256k1.GenPrivKey()
+	userAddress := sdk.AccAddress(key.PubKey().Address()).String()
+	expiration := ctx.BlockHeader().Time.Add(time.Hour)
+
+	newSignedUnclaimMsg := func(signer crypto.PrivKey, serviceType, nonce uint64) MsgCIPALUnclaim {
+		param := NewUnclaimParam(userAddress, serviceType, expiration, nonce)
+		sig, err := signer.Sign(param.GetSignBytes())
+		require.Nil(t, err)
+		return NewMsgCIPALUnclaim(sdk.AccAddress([]byte("proxy")), userAddress, serviceType, expiration, nonce, auth.StdSignature{PubKey: signer.PubKey(), Signature: sig})
+	}
+	newUnclaimMsg := func(serviceType, nonce uint64) MsgCIPALUnclaim {
+		return newSignedUnclaimMsg(key, serviceType, nonce)
+	}
+
+	// nothing to unclaim
+	_, err := h(ctx, newUnclaimMsg(1, 0))
+	require.True(t, ErrServiceNotFound.Is(err))
+	require.Equal(t, uint64(0), k.GetNonce(ctx, userAddress))
+
+	ctx = ctx.WithBlockHeight(1)
+	_, err = h(ctx, newClaimMsg(t, key, "nch1first", expiration, 0))
+	require.Nil(t, err)
+	ctx = ctx.WithBlockHeight(2)
+	_, err = h(ctx, newClaimMsg(t, key, "nch1second", expiration, 1))
+	require.Nil(t, err)
+
+	// another service type
+	_, err = h(ctx, newUnclaimMsg(2, 2))
+	require.True(t, ErrServiceNotFound.Is(err))
+
+	// signed with another key
+	_, err = h(ctx, newSignedUnclaimMsg(secp256k1.GenPrivKey(), 1, 2))
+	require.True(t, ErrInvalidSignature.Is(err))
+	_, found := k.GetCIPALObject(ctx, userAddress)
+	require.True(t, found)
+	require.Equal(t, uint64(2), k.GetNonce(ctx, userAddress))
+
+	ctx = ctx.WithBlockHeight(3)
+	msg := newUnclaimMsg(1, 2)
+	require.Nil(t, msg.ValidateBasic())
+	_, err = h(ctx, msg)
+	require.Nil(t, err)
+	_, found = k.GetCIPALObject(ctx, userAddress)
+	require.False(t, found)
+
+	// replay
+	_, err = h(ctx, msg)
+	require.True(t, ErrInvalidNonce.Is(err))
+
+	expected := types.HistoryEntries{
+		types.NewHistoryEntry(userAddress, 1, 1, "", "nch1first"),
+		types.NewHistoryEntry(userAddress, 2, 1, "nch1first", "nch1second"),
+		types.NewHistoryEntry(userAddress, 3, 1, "nch1second", ""),
+	}
+	require.Equal(t, expected, k.GetHistory(ctx, userAddress))
+	require.Nil(t, k.GetHistory(ctx, userAddress[:len(userAddress)-1]))
+
+	gs := ExportGenesis(ctx, k)
+	require.Equal(t, expected, gs.History)
+
+	ctx2, k2, _ := keeper.CreateTestInput(t)
+	InitGenesis(ctx2, k2, gs)
+	require.Equal(t, expected, k2.GetHistory(ctx2, userAddress))
+	require.Equal(t, uint64(3), k2.GetNonce(ctx2, userAddress))
+}
+
+func TestMsgIPALClaimStrictMode(t *testing.T) {
+	ctx, k, ipalKeeper := keeper.CreateTestInput(t)
+	h := NewHandler(k)
+
+	key := secp256k1.GenPrivKey()
+	userAddress := sdk.AccAddress(key.PubKey().Address()).String()
+	expiration := ctx.BlockHeader().Time.Add(time.Hour)
+
+	operator := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	ipalKeeper[operator.String()] = ipaltypes.IPALNode{
+		OperatorAddress: operator,
+		Endpoints:       ipaltypes.Endpoints{ipaltypes.NewEndpoint(2, "127.0.0.1:1234")},
+	}
+
+	// any service address off strict mode
+	_, err := h(ctx, newClaimMsg(t, key, "nch1any", expiration, 0))
+	require.Nil(t, err)
+
+	k.SetParams(ctx, NewParams(true))
+	_, err = h(ctx, newClaimMsg(t, key, "nch1any", expiration, 1))
+	require.True(t, ErrInvalidServiceAddress.Is(err))
+	_, err = h(ctx, newClaimMsg(t, key, sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address()).String(), expiration, 1))
+	require.True(t, ErrInvalidServiceAddress.Is(err))
+
+	// the node has no endpoint of the chatting type
+	_, err = h(ctx, newClaimMsg(t, key, operator.String(), expiration, 1))
+	require.True(t, ErrInvalidServiceAddress.Is(err))
+
+	ipalKeeper[operator.String()] = ipaltypes.IPALNode{
+		OperatorAddress: operator,
+		Endpoints:       ipaltypes.Endpoints{ipaltypes.NewEndpoint(1, "127.0.0.1:1234")},
+	}
+	_, err = h(ctx, newClaimMsg(t, key, operator.String(), expiration, 1))
+	require.Nil(t, err)
+
+	// the user moved from nch1any to the node
+	require.Nil(t, k.GetServedUsers(ctx, "nch1any"))
+	require.Equal(t, types.ServedUsers{types.NewServedUser(userAddress, 1)}, k.GetServedUsers(ctx, operator.String()))
+
+	param := NewUnclaimParam(userAddress, 1, expiration, 2)
+	sig, err := key.Sign(param.GetSignBytes())
+	require.Nil(t, err)
+	_, err = h(ctx, NewMsgCIPALUnclaim(sdk.AccAddress([]byte("proxy")), userAddress, 1, expiration, 2, auth.StdSignature{PubKey: key.PubKey(), Signature: sig}))
+	require.Nil(t, err)
+	require.Nil(t, k.GetServedUsers(ctx, operator.String()))
+}
@@ -0,0 +1,51 @@
+package keeper
+
+import (
+	"encoding/binary"
+
+	"github.com/netcloth/netcloth-chain/app/v0/cipal/types"
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+// AppendHistory appends the entry to the history of its user
+func (k Keeper) AppendHistory(ctx sdk.Context, entry types.HistoryEntry) {
+	store := ctx.KVStore(k.storeKey)
+	prefix := types.GetHistoryPrefix(entry.UserAddress)
+
+	seq := uint64(0)
+	iterator := sdk.KVStoreReversePrefixIterator(store, prefix)
+	if iterator.Valid() {
+		seq = binary.BigEndian.Uint64(iterator.Key()[len(prefix):]) + 1
+	}
+	iterator.Close()
+
+	store.Set(types.GetHistoryKey(entry.UserAddress, seq), k.cdc.MustMarshalBinaryLengthPrefixed(entry))
+}
+
+// GetHistory returns the history of a user, oldest first
+func (k Keeper) GetHistory(ctx sdk.Context, userAddress string) (entries types.HistoryEntries) {
+	k.iterateHistory(ctx, types.GetHistoryPrefix(userAddress), func(entry types.HistoryEntry) bool {
+		entries = append(entries, entry)
+		return false
+	})
+	return entries
+}
+
+// IterateHistory iterates over the history of all the users, oldest first per user
+func (k Keeper) IterateHistory(ctx sdk.Context, cb func(entry types.HistoryEntry) (stop bool)) {
+	k.iterateHistory(ctx, types.HistoryKey, cb)
+}
+
+func (k Keeper) iterateHistory(ctx sdk.Context, prefix []byte, cb func(entry types.HistoryEntry) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, prefix)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var entry types.HistoryEntry
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &entry)
+		if cb(entry) {
+			break
+		}
+	}
+}
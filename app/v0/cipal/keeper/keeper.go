@@ -1,6 +1,7 @@
 package keeper
 
 import (
+	"encoding/binary"
 	"fmt"
 
 	"github.com/tendermint/tendermint/libs/log"
@@ -15,13 +16,15 @@ type Keeper struct {
 	storeKey   sdk.StoreKey
 	cdc        *codec.Codec
 	paramstore params.Subspace
+	ipalKeeper types.IPALKeeper
 }
 
-func NewKeeper(storeKey sdk.StoreKey, cdc *codec.Codec, paramstore params.Subspace) Keeper {
+func NewKeeper(storeKey sdk.StoreKey, cdc *codec.Codec, paramstore params.Subspace, ipalKeeper types.IPALKeeper) Keeper {
 	return Keeper{
 		storeKey:   storeKey,
 		cdc:        cdc,
 		paramstore: paramstore.WithKeyTable(ParamKeyTable()),
+		ipalKeeper: ipalKeeper,
 	}
 }
 
@@ -42,33 +45,128 @@ func (k Keeper) GetCIPALObject(ctx sdk.Context, userAddress string) (obj types.C
 }
 
 func (k Keeper) GetAllCIPALObjects(ctx sdk.Context) (CIPALObjs []types.CIPALObject) {
+	k.IterateCIPALObjects(ctx, func(obj types.CIPALObject) bool {
+		CIPALObjs = append(CIPALObjs, obj)
+		return false
+	})
+	return CIPALObjs
+}
+
+// IterateCIPALObjects iterates over the cipal objects by user address, without loading them all
+func (k Keeper) IterateCIPALObjects(ctx sdk.Context, cb func(obj types.CIPALObject) (stop bool)) {
 	store := ctx.KVStore(k.storeKey)
 	iterator := sdk.KVStorePrefixIterator(store, types.CIPALObjectKey)
 	defer iterator.Close()
 
 	for ; iterator.Valid(); iterator.Next() {
-		obj := types.MustUnmarshalCIPALObject(k.cdc, iterator.Value())
-		CIPALObjs = append(CIPALObjs, obj)
+		if cb(types.MustUnmarshalCIPALObject(k.cdc, iterator.Value())) {
+			break
+		}
 	}
-	return CIPALObjs
 }
 
-// get the set of all cipal object with no limits
-func (k Keeper) GetCIPALObjectCount(ctx sdk.Context) (count int) {
+// GetCIPALObjectsPage returns a page of the cipal objects by user address. The page starts after
+// the cursor, the key of the last object of the previous page, if any, and then skips offset objects.
+// The returned cursor is nil if there are no more objects.
+func (k Keeper) GetCIPALObjectsPage(ctx sdk.Context, cursor []byte, offset, limit int) (objs types.CIPALObjects, next []byte) {
 	store := ctx.KVStore(k.storeKey)
-	iterator := sdk.KVStorePrefixIterator(store, types.CIPALObjectKey)
+	start := types.CIPALObjectKey
+	if len(cursor) > 0 {
+		start = sdk.InclusiveEndBytes(cursor)
+	}
+	iterator := store.Iterator(start, sdk.PrefixEndBytes(types.CIPALObjectKey))
 	defer iterator.Close()
 
-	count = 0
+	objs = types.CIPALObjects{}
+	for ; iterator.Valid() && offset > 0; iterator.Next() {
+		offset--
+	}
+
+	var last []byte
 	for ; iterator.Valid(); iterator.Next() {
-		count++
+		if len(objs) == limit {
+			next = last
+			break
+		}
+
+		objs = append(objs, types.MustUnmarshalCIPALObject(k.cdc, iterator.Value()))
+		last = sdk.CopyBytes(iterator.Key())
+	}
+	return objs, next
+}
+
+// GetCIPALObjectCount returns the stored count of the cipal objects, counted once by BuildMissingIndexes
+// on the chains started before the count
+func (k Keeper) GetCIPALObjectCount(ctx sdk.Context) (count int) {
+	store := ctx.KVStore(k.storeKey)
+	if bz := store.Get(types.CountKey); bz != nil {
+		return int(binary.BigEndian.Uint64(bz))
 	}
-	return count
+	return 0
 }
 
+func (k Keeper) setCIPALObjectCount(ctx sdk.Context, count int) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.CountKey, sdk.Uint64ToBigEndian(uint64(count)))
+}
+
+// SetCIPALObject stores the object and indexes the users by service address
 func (k Keeper) SetCIPALObject(ctx sdk.Context, obj types.CIPALObject) {
+	if old, found := k.GetCIPALObject(ctx, obj.UserAddress); found {
+		k.delServedUsers(ctx, old)
+	} else {
+		k.setCIPALObjectCount(ctx, k.GetCIPALObjectCount(ctx)+1)
+	}
+	k.setServedUsers(ctx, obj)
+
 	store := ctx.KVStore(k.storeKey)
 	bz := types.MustMarshalCIPALObject(k.cdc, obj)
 	store.Set(types.GetCIPALObjectKey(obj.UserAddress), bz)
 	ctx.Logger().Info(string(types.GetCIPALObjectKey(obj.UserAddress)))
 }
+
+// GetNonce returns the nonce the next user request of the user must be signed with
+func (k Keeper) GetNonce(ctx sdk.Context, userAddress string) uint64 {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetNonceKey(userAddress))
+	if bz == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint64(bz)
+}
+
+func (k Keeper) SetNonce(ctx sdk.Context, userAddress string, nonce uint64) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetNonceKey(userAddress), sdk.Uint64ToBigEndian(nonce))
+}
+
+func (k Keeper) GetAllNonces(ctx sdk.Context) (nonces types.UserNonces) {
+	k.IterateNonces(ctx, func(nonce types.UserNonce) bool {
+		nonces = append(nonces, nonce)
+		return false
+	})
+	return nonces
+}
+
+func (k Keeper) IterateNonces(ctx sdk.Context, cb func(nonce types.UserNonce) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.NonceKey)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		userAddress := string(iterator.Key()[len(types.NonceKey):])
+		if cb(types.NewUserNonce(userAddress, binary.BigEndian.Uint64(iterator.Value()))) {
+			break
+		}
+	}
+}
+
+func (k Keeper) DeleteCIPALObject(ctx sdk.Context, userAddress string) {
+	if old, found := k.GetCIPALObject(ctx, userAddress); found {
+		k.delServedUsers(ctx, old)
+		k.setCIPALObjectCount(ctx, k.GetCIPALObjectCount(ctx)-1)
+	}
+
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetCIPALObjectKey(userAddress))
+}
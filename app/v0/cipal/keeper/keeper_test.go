@@ -0,0 +1,102 @@
+package keeper
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/netcloth/netcloth-chain/app/v0/cipal/types"
+)
+
+func TestCIPALObjectCountAndPages(t *testing.T) {
+	ctx, k, _ := CreateTestInput(t)
+	require.Equal(t, 0, k.GetCIPALObjectCount(ctx))
+
+	// stored before the count
+	store := ctx.KVStore(k.storeKey)
+	legacy := types.NewCIPALObject("user00", "nch1service", 1)
+	store.Set(types.GetCIPALObjectKey(legacy.UserAddress), types.MustMarshalCIPALObject(k.cdc, legacy))
+	require.Equal(t, 0, k.GetCIPALObjectCount(ctx))
+	k.BuildMissingIndexes(ctx)
+	require.Equal(t, 1, k.GetCIPALObjectCount(ctx))
+
+	for i := 1; i < 5; i++ {
+		k.SetCIPALObject(ctx, types.NewCIPALObject(fmt.Sprintf("user%02d", i), "nch1service", 1))
+	}
+	require.Equal(t, 5, k.GetCIPALObjectCount(ctx))
+
+	// updates are not counted
+	k.SetCIPALObject(ctx, types.NewCIPALObject("user01", "nch1other", 1))
+	require.Equal(t, 5, k.GetCIPALObjectCount(ctx))
+
+	k.DeleteCIPALObject(ctx, "user02")
+	k.DeleteCIPALObject(ctx, "user02")
+	require.Equal(t, 4, k.GetCIPALObjectCount(ctx))
+
+	page, next := k.GetCIPALObjectsPage(ctx, nil, 0, 3)
+	require.Len(t, page, 3)
+	require.Equal(t, "user00", page[0].UserAddress)
+	require.Equal(t, "user03", page[2].UserAddress)
+	require.Equal(t, types.GetCIPALObjectKey("user03"), next)
+
+	// the next page starts after the cursor, or after the offset
+	page, next = k.GetCIPALObjectsPage(ctx, next, 0, 3)
+	require.Len(t, page, 1)
+	require.Equal(t, "user04", page[0].UserAddress)
+	require.Nil(t, next)
+
+	page, next = k.GetCIPALObjectsPage(ctx, nil, 3, 3)
+	require.Len(t, page, 1)
+	require.Equal(t, "user04", page[0].UserAddress)
+	require.Nil(t, next)
+
+	page, _ = k.GetCIPALObjectsPage(ctx, nil, 6, 3)
+	require.Empty(t, page)
+
+	// query
+	querier := NewQuerier(k)
+	query := func(params types.QueryCIPALListParams) (res types.CIPALListResult, err error) {
+		bz, err := querier(ctx, []string{types.QueryList}, abci.RequestQuery{Data: types.ModuleCdc.MustMarshalJSON(params)})
+		if err == nil {
+			types.ModuleCdc.MustUnmarshalJSON(bz, &res)
+		}
+		return res, err
+	}
+
+	res, err := query(types.NewQueryCIPALListParams(1, 2, ""))
+	require.Nil(t, err)
+	require.Len(t, res.Objects, 2)
+	res, err = query(types.NewQueryCIPALListParams(0, 2, res.NextCursor))
+	require.Nil(t, err)
+	require.Len(t, res.Objects, 2)
+	require.Equal(t, "user03", res.Objects[0].UserAddress)
+	require.Empty(t, res.NextCursor)
+
+	_, err = query(types.NewQueryCIPALListParams(0, 2, ""))
+	require.NotNil(t, err)
+	_, err = query(types.NewQueryCIPALListParams(1, 2, "zz"))
+	require.NotNil(t, err)
+}
+
+func TestBuildMissingIndexes(t *testing.T) {
+	ctx, k, _ := CreateTestInput(t)
+
+	// stored before the served users index
+	store := ctx.KVStore(k.storeKey)
+	legacy := types.NewCIPALObject("user00", "nch1service", 1)
+	store.Set(types.GetCIPALObjectKey(legacy.UserAddress), types.MustMarshalCIPALObject(k.cdc, legacy))
+	k.SetCIPALObject(ctx, types.NewCIPALObject("user01", "nch1service", 2))
+	require.Equal(t, types.ServedUsers{types.NewServedUser("user01", 2)}, k.GetServedUsers(ctx, "nch1service"))
+
+	k.BuildMissingIndexes(ctx)
+	expected := types.ServedUsers{types.NewServedUser("user00", 1), types.NewServedUser("user01", 2)}
+	require.Equal(t, expected, k.GetServedUsers(ctx, "nch1service"))
+
+	// built once
+	k.DeleteCIPALObject(ctx, "user01")
+	store.Set(types.GetCIPALObjectKey("user02"), types.MustMarshalCIPALObject(k.cdc, types.NewCIPALObject("user02", "nch1service", 1)))
+	k.BuildMissingIndexes(ctx)
+	require.Equal(t, types.ServedUsers{types.NewServedUser("user00", 1)}, k.GetServedUsers(ctx, "nch1service"))
+}
@@ -0,0 +1,33 @@
+package keeper
+
+import (
+	"github.com/netcloth/netcloth-chain/app/v0/cipal/types"
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+// BuildMissingIndexes counts, once, the cipal objects stored before the count, and indexes, once, the
+// served users of the cipal objects stored before the index. It iterates over all the objects, so it
+// runs in the begin blocker with an infinite gas meter rather than in a transaction.
+func (k Keeper) BuildMissingIndexes(ctx sdk.Context) {
+	ctx = ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+	store := ctx.KVStore(k.storeKey)
+	if !store.Has(types.CountKey) {
+		iterator := sdk.KVStorePrefixIterator(store, types.CIPALObjectKey)
+		count := 0
+		for ; iterator.Valid(); iterator.Next() {
+			count++
+		}
+		iterator.Close()
+		k.setCIPALObjectCount(ctx, count)
+	}
+
+	if store.Has(types.IndexedKey) {
+		return
+	}
+
+	k.IterateCIPALObjects(ctx, func(obj types.CIPALObject) bool {
+		k.setServedUsers(ctx, obj)
+		return false
+	})
+	store.Set(types.IndexedKey, []byte{})
+}
@@ -3,12 +3,27 @@ package keeper
 import (
 	"github.com/netcloth/netcloth-chain/app/v0/cipal/types"
 	"github.com/netcloth/netcloth-chain/app/v0/params"
+	sdk "github.com/netcloth/netcloth-chain/types"
 )
 
 const (
 	DefaultParamspace = types.ModuleName
 )
 
-func ParamKeyTable() params.KeyTable { //useless if module has no params
-	return params.NewKeyTable()
+func ParamKeyTable() params.KeyTable {
+	return params.NewKeyTable().RegisterParamSet(&types.Params{})
+}
+
+// GetStrictMode returns the strict mode param, off for the chains started before the param
+func (k Keeper) GetStrictMode(ctx sdk.Context) (res bool) {
+	k.paramstore.GetIfExists(ctx, types.KeyStrictMode, &res)
+	return
+}
+
+func (k Keeper) GetParams(ctx sdk.Context) types.Params {
+	return types.NewParams(k.GetStrictMode(ctx))
+}
+
+func (k Keeper) SetParams(ctx sdk.Context, params types.Params) {
+	k.paramstore.SetParamSet(ctx, &params)
 }
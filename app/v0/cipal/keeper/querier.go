@@ -1,6 +1,9 @@
 package keeper
 
 import (
+	"bytes"
+	"encoding/hex"
+
 	abci "github.com/tendermint/tendermint/abci/types"
 
 	"github.com/netcloth/netcloth-chain/app/v0/cipal/types"
@@ -18,6 +21,16 @@ func NewQuerier(k Keeper) sdk.Querier {
 			return queryCIPALs(ctx, req, k)
 		case types.QueryCIPALCount:
 			return queryCIPALCount(ctx, req, k)
+		case types.QueryNonce:
+			return queryNonce(ctx, req, k)
+		case types.QueryHistory:
+			return queryHistory(ctx, req, k)
+		case types.QueryParameters:
+			return queryParameters(ctx, k)
+		case types.QueryUsers:
+			return queryUsers(ctx, req, k)
+		case types.QueryList:
+			return queryCIPALList(ctx, req, k)
 		default:
 			return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unknown query path: %s", path[0])
 		}
@@ -88,3 +101,104 @@ func queryCIPALs(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, erro
 	}
 	return bz, nil
 }
+
+func queryNonce(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QueryCIPALParams
+
+	err := types.ModuleCdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	nonce := types.NewUserNonce(params.AccAddr, k.GetNonce(ctx, params.AccAddr))
+	bz, err := codec.MarshalJSONIndent(types.ModuleCdc, nonce)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return bz, nil
+}
+
+func queryHistory(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QueryCIPALParams
+
+	err := types.ModuleCdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	history := k.GetHistory(ctx, params.AccAddr)
+	if history == nil {
+		history = types.HistoryEntries{}
+	}
+
+	bz, err := codec.MarshalJSONIndent(types.ModuleCdc, history)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return bz, nil
+}
+
+func queryParameters(ctx sdk.Context, k Keeper) ([]byte, error) {
+	params := k.GetParams(ctx)
+
+	bz, err := codec.MarshalJSONIndent(types.ModuleCdc, params)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return bz, nil
+}
+
+func queryUsers(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QueryUsersParams
+
+	err := types.ModuleCdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	users := k.GetServedUsers(ctx, params.ServiceAddress)
+	if users == nil {
+		users = types.ServedUsers{}
+	}
+
+	bz, err := codec.MarshalJSONIndent(types.ModuleCdc, users)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return bz, nil
+}
+
+func queryCIPALList(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QueryCIPALListParams
+
+	err := types.ModuleCdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	if params.Limit < 1 || params.Limit > types.MaxListLimit {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "limit must be between 1 and %d", types.MaxListLimit)
+	}
+
+	var cursor []byte
+	if params.Cursor != "" {
+		cursor, err = hex.DecodeString(params.Cursor)
+		if err != nil || !bytes.HasPrefix(cursor, types.CIPALObjectKey) {
+			return nil, sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "invalid cursor: %s", params.Cursor)
+		}
+	} else if params.Page < 1 {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "page must be positive")
+	}
+
+	offset := 0
+	if cursor == nil {
+		offset = (params.Page - 1) * params.Limit
+	}
+
+	cipals, next := k.GetCIPALObjectsPage(ctx, cursor, offset, params.Limit)
+	bz, err := codec.MarshalJSONIndent(types.ModuleCdc, types.CIPALListResult{Objects: cipals, NextCursor: hex.EncodeToString(next)})
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return bz, nil
+}
@@ -0,0 +1,62 @@
+package keeper
+
+import (
+	"encoding/binary"
+
+	"github.com/netcloth/netcloth-chain/app/v0/cipal/types"
+	sdk "github.com/netcloth/netcloth-chain/types"
+	sdkerrors "github.com/netcloth/netcloth-chain/types/errors"
+)
+
+func (k Keeper) setServedUsers(ctx sdk.Context, obj types.CIPALObject) {
+	store := ctx.KVStore(k.storeKey)
+	for _, si := range obj.ServiceInfos {
+		store.Set(types.GetServedUserKey(si.Address, si.Type, obj.UserAddress), []byte{})
+	}
+}
+
+func (k Keeper) delServedUsers(ctx sdk.Context, obj types.CIPALObject) {
+	store := ctx.KVStore(k.storeKey)
+	for _, si := range obj.ServiceInfos {
+		store.Delete(types.GetServedUserKey(si.Address, si.Type, obj.UserAddress))
+	}
+}
+
+// GetServedUsers returns the users bound to a service address, by service type
+func (k Keeper) GetServedUsers(ctx sdk.Context, serviceAddress string) (users types.ServedUsers) {
+	store := ctx.KVStore(k.storeKey)
+	prefix := types.GetServedUsersPrefix(serviceAddress)
+	iterator := sdk.KVStorePrefixIterator(store, prefix)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		key := iterator.Key()[len(prefix):]
+		users = append(users, types.NewServedUser(string(key[8:]), binary.BigEndian.Uint64(key[:8])))
+	}
+	return users
+}
+
+// ValidateServiceInfo checks, in strict mode, the service address is an ipal node with an endpoint of
+// the service type
+func (k Keeper) ValidateServiceInfo(ctx sdk.Context, si types.ServiceInfo) error {
+	if !k.GetStrictMode(ctx) {
+		return nil
+	}
+
+	operator, err := sdk.AccAddressFromBech32(si.Address)
+	if err != nil {
+		return sdkerrors.Wrapf(types.ErrInvalidServiceAddress, "%s: %s", si.Address, err)
+	}
+
+	node, found := k.ipalKeeper.GetIPALNode(ctx, operator)
+	if !found {
+		return sdkerrors.Wrapf(types.ErrInvalidServiceAddress, "ipal node %s not found", si.Address)
+	}
+
+	for _, e := range node.Endpoints {
+		if e.Type == si.Type {
+			return nil
+		}
+	}
+	return sdkerrors.Wrapf(types.ErrInvalidServiceAddress, "ipal node %s has no endpoint of service type %d", si.Address, si.Type)
+}
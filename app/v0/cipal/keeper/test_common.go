@@ -0,0 +1,65 @@
+package keeper
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/netcloth/netcloth-chain/app/v0/auth"
+	"github.com/netcloth/netcloth-chain/app/v0/cipal/types"
+	ipaltypes "github.com/netcloth/netcloth-chain/app/v0/ipal/types"
+	"github.com/netcloth/netcloth-chain/app/v0/params"
+	"github.com/netcloth/netcloth-chain/codec"
+	"github.com/netcloth/netcloth-chain/store"
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+// create a codec used only for testing
+func MakeTestCodec() *codec.Codec {
+	cdc := codec.New()
+	sdk.RegisterCodec(cdc)
+	auth.RegisterCodec(cdc)
+	types.RegisterCodec(cdc)
+	codec.RegisterCrypto(cdc)
+	return cdc
+}
+
+// MockIPALKeeper - the ipal nodes by operator address
+type MockIPALKeeper map[string]ipaltypes.IPALNode
+
+func (m MockIPALKeeper) GetIPALNode(ctx sdk.Context, operator sdk.AccAddress) (obj ipaltypes.IPALNode, found bool) {
+	obj, found = m[operator.String()]
+	return obj, found
+}
+
+// CreateTestInput returns a context, a cipal keeper with the default params and the ipal nodes it
+// looks up
+func CreateTestInput(t *testing.T) (sdk.Context, Keeper, MockIPALKeeper) {
+	keys := sdk.NewKVStoreKeys(params.StoreKey, types.StoreKey)
+	tkeys := sdk.NewTransientStoreKeys(params.TStoreKey)
+
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	for _, key := range keys {
+		ms.MountStoreWithDB(key, sdk.StoreTypeIAVL, db)
+	}
+	for _, key := range tkeys {
+		ms.MountStoreWithDB(key, sdk.StoreTypeTransient, db)
+	}
+	require.Nil(t, ms.LoadLatestVersion())
+
+	ctx := sdk.NewContext(ms, abci.Header{Time: time.Unix(0, 0), ChainID: "foochainid"}, false, log.NewTMLogger(os.Stdout))
+	cdc := MakeTestCodec()
+
+	paramsKeeper := params.NewKeeper(cdc, keys[params.StoreKey], tkeys[params.TStoreKey])
+	ipalKeeper := MockIPALKeeper{}
+	keeper := NewKeeper(keys[types.StoreKey], cdc, paramsKeeper.Subspace(DefaultParamspace), ipalKeeper)
+	keeper.SetParams(ctx, types.DefaultParams())
+
+	return ctx, keeper, ipalKeeper
+}
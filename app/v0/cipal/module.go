@@ -1,6 +1,7 @@
 package cipal
 
 import (
+	"bytes"
 	"encoding/json"
 
 	"github.com/gorilla/mux"
@@ -33,7 +34,7 @@ func (am AppModuleBasic) RegisterCodec(cdc *codec.Codec) {
 }
 
 func (am AppModuleBasic) DefaultGenesis() json.RawMessage {
-	return ModuleCdc.MustMarshalJSON("")
+	return ModuleCdc.MustMarshalJSON(DefaultGenesisState())
 }
 
 func (am AppModuleBasic) ValidateGenesis(value json.RawMessage) (err error) {
@@ -72,9 +73,14 @@ func (am AppModule) InitGenesis(ctx sdk.Context, data json.RawMessage) []abci.Va
 	return []abci.ValidatorUpdate{}
 }
 
+// ExportGenesis avoids the decoded slices of ExportGenesis, but the module manager takes the genesis
+// of the modules as json.RawMessage, so the whole JSON is still buffered
 func (am AppModule) ExportGenesis(ctx sdk.Context) json.RawMessage {
-	gs := ExportGenesis(ctx, am.keeper)
-	return ModuleCdc.MustMarshalJSON(gs)
+	var buf bytes.Buffer
+	if err := WriteGenesis(ctx, am.keeper, &buf); err != nil {
+		panic(err)
+	}
+	return buf.Bytes()
 }
 
 func (am AppModule) RegisterInvariants(sdk.InvariantRegistry) {
@@ -97,7 +103,8 @@ func (am AppModule) NewQuerierHandler() sdk.Querier {
 	return NewQuerier(am.keeper)
 }
 
-func (am AppModule) BeginBlock(sdk.Context, abci.RequestBeginBlock) {
+func (am AppModule) BeginBlock(ctx sdk.Context, _ abci.RequestBeginBlock) {
+	BeginBlocker(ctx, am.keeper)
 }
 
 func (am AppModule) EndBlock(ctx sdk.Context, end abci.RequestEndBlock) []abci.ValidatorUpdate {
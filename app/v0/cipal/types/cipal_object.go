@@ -2,6 +2,7 @@ package types
 
 import (
 	"fmt"
+	"strings"
 
 	"gopkg.in/yaml.v2"
 
@@ -71,3 +72,10 @@ func (obj CIPALObject) String() string {
 User Address:			%s
 Service Infos:		    %s`, obj.UserAddress, getServiceInfosString(obj.ServiceInfos))
 }
+
+func (objs CIPALObjects) String() (out string) {
+	for _, obj := range objs {
+		out += obj.String() + "\n"
+	}
+	return strings.TrimSpace(out)
+}
@@ -6,6 +6,7 @@ import (
 
 func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(MsgCIPALClaim{}, "nch/CIPALClaim", nil)
+	cdc.RegisterConcrete(MsgCIPALUnclaim{}, "nch/CIPALUnclaim", nil)
 }
 
 var ModuleCdc *codec.Codec
@@ -10,4 +10,7 @@ var (
 	ErrInvalidSignature               = sdkerrors.New(ModuleName, 3, "CIPAL invalid user_request signature")
 	ErrIPALClaimUserRequestExpired    = sdkerrors.New(ModuleName, 4, "CIPAL user_request time expired")
 	ErrCIPALClaimUserRequestSigVerify = sdkerrors.New(ModuleName, 5, "CIPAL user_request signature verify failed")
+	ErrInvalidNonce                   = sdkerrors.New(ModuleName, 6, "CIPAL user_request invalid nonce")
+	ErrServiceNotFound                = sdkerrors.New(ModuleName, 7, "CIPAL service not found")
+	ErrInvalidServiceAddress          = sdkerrors.New(ModuleName, 8, "CIPAL service address is not an ipal node serving the service type")
 )
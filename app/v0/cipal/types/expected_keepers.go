@@ -0,0 +1,10 @@
+package types
+
+import (
+	ipaltypes "github.com/netcloth/netcloth-chain/app/v0/ipal/types"
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+type IPALKeeper interface {
+	GetIPALNode(ctx sdk.Context, operator sdk.AccAddress) (obj ipaltypes.IPALNode, found bool)
+}
@@ -2,17 +2,25 @@ package types
 
 // GenesisState is the supply state that must be provided at genesis.
 type GenesisState struct {
-	CIPALObjs CIPALObjects `json:"cipal_objects" yaml:"cipal_objects"`
+	Params    Params         `json:"params" yaml:"params"`
+	CIPALObjs CIPALObjects   `json:"cipal_objects" yaml:"cipal_objects"`
+	Nonces    UserNonces     `json:"nonces" yaml:"nonces"`
+	History   HistoryEntries `json:"history" yaml:"history"`
 }
 
 // NewGenesisState creates a new genesis state.
-func NewGenesisState(CIPALObjs CIPALObjects) GenesisState {
+func NewGenesisState(params Params, CIPALObjs CIPALObjects, nonces UserNonces, history HistoryEntries) GenesisState {
 	return GenesisState{
+		Params:    params,
 		CIPALObjs: CIPALObjs,
+		Nonces:    nonces,
+		History:   history,
 	}
 }
 
 // DefaultGenesisState returns a default genesis state
 func DefaultGenesisState() GenesisState {
-	return GenesisState{}
+	return GenesisState{
+		Params: DefaultParams(),
+	}
 }
@@ -0,0 +1,41 @@
+package types
+
+import (
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// HistoryEntry - a change of the service of a type of a user, the old address is empty for a new
+// service and the new address for a removed one
+type HistoryEntry struct {
+	UserAddress string `json:"user_address" yaml:"user_address"`
+	Height      int64  `json:"height" yaml:"height"`
+	ServiceType uint64 `json:"service_type" yaml:"service_type"`
+	OldAddress  string `json:"old_address" yaml:"old_address"`
+	NewAddress  string `json:"new_address" yaml:"new_address"`
+}
+
+func NewHistoryEntry(userAddress string, height int64, serviceType uint64, oldAddress, newAddress string) HistoryEntry {
+	return HistoryEntry{
+		UserAddress: userAddress,
+		Height:      height,
+		ServiceType: serviceType,
+		OldAddress:  oldAddress,
+		NewAddress:  newAddress,
+	}
+}
+
+func (e HistoryEntry) String() string {
+	out, _ := yaml.Marshal(e)
+	return string(out)
+}
+
+type HistoryEntries []HistoryEntry
+
+func (e HistoryEntries) String() (out string) {
+	for _, val := range e {
+		out += val.String() + "\n"
+	}
+	return strings.TrimSpace(out)
+}
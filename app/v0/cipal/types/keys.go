@@ -1,7 +1,10 @@
 package types
 
 import (
+	"encoding/binary"
+
 	"github.com/netcloth/netcloth-chain/app/protocol"
+	sdk "github.com/netcloth/netcloth-chain/types"
 )
 
 const (
@@ -13,8 +16,42 @@ const (
 
 var (
 	CIPALObjectKey = []byte{0x11}
+	NonceKey       = []byte{0x12}
+	HistoryKey     = []byte{0x13}
+	ServedUserKey  = []byte{0x14}
+	CountKey       = []byte{0x15} // count of the cipal objects
+	IndexedKey     = []byte{0x16} // set once the served users of the cipal objects stored before the index are indexed
 )
 
 func GetCIPALObjectKey(addr string) []byte {
 	return append(CIPALObjectKey, []byte(addr)...)
 }
+
+func GetNonceKey(addr string) []byte {
+	return append(NonceKey, []byte(addr)...)
+}
+
+// lengthPrefixed prefixes the address with its length, so that the keys of an address are not a prefix of
+// the keys of another address
+func lengthPrefixed(addr string) []byte {
+	bz := make([]byte, 2)
+	binary.BigEndian.PutUint16(bz, uint16(len(addr)))
+	return append(bz, []byte(addr)...)
+}
+
+func GetHistoryPrefix(addr string) []byte {
+	return append(HistoryKey, lengthPrefixed(addr)...)
+}
+
+func GetHistoryKey(addr string, seq uint64) []byte {
+	return append(GetHistoryPrefix(addr), sdk.Uint64ToBigEndian(seq)...)
+}
+
+// GetServedUsersPrefix returns the prefix of the users served by a service address
+func GetServedUsersPrefix(serviceAddr string) []byte {
+	return append(ServedUserKey, lengthPrefixed(serviceAddr)...)
+}
+
+func GetServedUserKey(serviceAddr string, serviceType uint64, userAddr string) []byte {
+	return append(append(GetServedUsersPrefix(serviceAddr), sdk.Uint64ToBigEndian(serviceType)...), []byte(userAddr)...)
+}
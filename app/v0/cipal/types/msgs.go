@@ -16,6 +16,7 @@ const (
 
 var (
 	_ sdk.Msg = MsgCIPALClaim{}
+	_ sdk.Msg = MsgCIPALUnclaim{}
 )
 
 type ServiceInfo struct {
@@ -27,6 +28,7 @@ type ADParam struct {
 	UserAddress string      `json:"user_address" yaml:"user_address"`
 	ServiceInfo ServiceInfo `json:"service_info" yaml:"service_info"`
 	Expiration  time.Time   `json:"expiration"`
+	Nonce       uint64      `json:"nonce" yaml:"nonce"` // must equal the stored nonce of the user, against replays
 }
 
 type CIPALUserRequest struct {
@@ -39,6 +41,24 @@ type MsgCIPALClaim struct {
 	UserRequest CIPALUserRequest `json:"user_request" yaml:"user_request"`
 }
 
+// UnclaimParam - the request of a user to remove the service of a type
+type UnclaimParam struct {
+	UserAddress string    `json:"user_address" yaml:"user_address"`
+	ServiceType uint64    `json:"service_type" yaml:"service_type"`
+	Expiration  time.Time `json:"expiration"`
+	Nonce       uint64    `json:"nonce" yaml:"nonce"`
+}
+
+type CIPALUnclaimRequest struct {
+	Params UnclaimParam      `json:"params" yaml:"params"`
+	Sig    auth.StdSignature `json:"signature" yaml:"signature"`
+}
+
+type MsgCIPALUnclaim struct {
+	From        sdk.AccAddress      `json:"from" yaml:"from"`
+	UserRequest CIPALUnclaimRequest `json:"user_request" yaml:"user_request"`
+}
+
 func (i ServiceInfo) String() string {
 	return fmt.Sprintf(`ServiceInfo{Type:%d, Address:%s`, i.Type, i.Address)
 }
@@ -63,25 +83,26 @@ func (p ADParam) Validate() error {
 	return nil
 }
 
-func NewADParam(userAddress string, serviceAddress string, serviceType uint64, expiration time.Time) ADParam {
+func NewADParam(userAddress string, serviceAddress string, serviceType uint64, expiration time.Time, nonce uint64) ADParam {
 	return ADParam{
 		UserAddress: userAddress,
 		ServiceInfo: ServiceInfo{Type: serviceType, Address: serviceAddress},
 		Expiration:  expiration,
+		Nonce:       nonce,
 	}
 }
 
-func NewCIPALUserRequest(userAddress string, serviceAddress string, serviceType uint64, expiration time.Time, sig auth.StdSignature) CIPALUserRequest {
+func NewCIPALUserRequest(userAddress string, serviceAddress string, serviceType uint64, expiration time.Time, nonce uint64, sig auth.StdSignature) CIPALUserRequest {
 	return CIPALUserRequest{
-		Params: NewADParam(userAddress, serviceAddress, serviceType, expiration),
+		Params: NewADParam(userAddress, serviceAddress, serviceType, expiration, nonce),
 		Sig:    sig,
 	}
 }
 
-func NewMsgCIPALClaim(from sdk.AccAddress, userAddress string, serviceAddress string, serviceType uint64, expiration time.Time, sig auth.StdSignature) MsgCIPALClaim {
+func NewMsgCIPALClaim(from sdk.AccAddress, userAddress string, serviceAddress string, serviceType uint64, expiration time.Time, nonce uint64, sig auth.StdSignature) MsgCIPALClaim {
 	return MsgCIPALClaim{
 		from,
-		NewCIPALUserRequest(userAddress, serviceAddress, serviceType, expiration, sig),
+		NewCIPALUserRequest(userAddress, serviceAddress, serviceType, expiration, nonce, sig),
 	}
 }
 
@@ -119,3 +140,77 @@ func (msg MsgCIPALClaim) GetSignBytes() []byte {
 func (msg MsgCIPALClaim) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{msg.From}
 }
+
+func (p UnclaimParam) GetSignBytes() []byte {
+	b, err := json.Marshal(p)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+func (p UnclaimParam) Validate() error {
+	if p.UserAddress == "" {
+		return sdkerrors.Wrap(ErrEmptyInputs, "user address empty")
+	}
+
+	if len(p.UserAddress) > maxUserAddressLength {
+		return sdkerrors.Wrap(ErrStringTooLong, "user address too long")
+	}
+
+	return nil
+}
+
+func NewUnclaimParam(userAddress string, serviceType uint64, expiration time.Time, nonce uint64) UnclaimParam {
+	return UnclaimParam{
+		UserAddress: userAddress,
+		ServiceType: serviceType,
+		Expiration:  expiration,
+		Nonce:       nonce,
+	}
+}
+
+func NewMsgCIPALUnclaim(from sdk.AccAddress, userAddress string, serviceType uint64, expiration time.Time, nonce uint64, sig auth.StdSignature) MsgCIPALUnclaim {
+	return MsgCIPALUnclaim{
+		From: from,
+		UserRequest: CIPALUnclaimRequest{
+			Params: NewUnclaimParam(userAddress, serviceType, expiration, nonce),
+			Sig:    sig,
+		},
+	}
+}
+
+func (msg MsgCIPALUnclaim) Route() string { return RouterKey }
+
+func (msg MsgCIPALUnclaim) Type() string { return "cipal_unclaim" }
+
+func (msg MsgCIPALUnclaim) ValidateBasic() error {
+	if msg.From.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing sender address")
+	}
+
+	err := msg.UserRequest.Params.Validate()
+	if err != nil {
+		return err
+	}
+
+	pubKey := msg.UserRequest.Sig.PubKey
+	signBytes := msg.UserRequest.Params.GetSignBytes()
+	if !pubKey.VerifyBytes(signBytes, msg.UserRequest.Sig.Signature) {
+		return sdkerrors.Wrap(ErrInvalidSignature, "user request signature invalid")
+	}
+
+	return nil
+}
+
+func (msg MsgCIPALUnclaim) GetSignBytes() []byte {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+func (msg MsgCIPALUnclaim) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.From}
+}
@@ -22,7 +22,7 @@ func TestIPALUserRequestSig(t *testing.T) {
 	fmt.Println(expiration)
 	require.Nil(t, err)
 
-	adParam := NewADParam(userAddress, serviceAddress, serviceType, expiration)
+	adParam := NewADParam(userAddress, serviceAddress, serviceType, expiration, 0)
 	//fmt.Println(fmt.Sprintf("param: %x", adParam.GetSignBytes()))
 
 	// parse private key
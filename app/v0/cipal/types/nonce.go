@@ -0,0 +1,34 @@
+package types
+
+import (
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// UserNonce - the nonce the next user request of the user must be signed with
+type UserNonce struct {
+	UserAddress string `json:"user_address" yaml:"user_address"`
+	Nonce       uint64 `json:"nonce" yaml:"nonce"`
+}
+
+func NewUserNonce(userAddress string, nonce uint64) UserNonce {
+	return UserNonce{
+		UserAddress: userAddress,
+		Nonce:       nonce,
+	}
+}
+
+func (n UserNonce) String() string {
+	out, _ := yaml.Marshal(n)
+	return string(out)
+}
+
+type UserNonces []UserNonce
+
+func (n UserNonces) String() (out string) {
+	for _, val := range n {
+		out += val.String() + "\n"
+	}
+	return strings.TrimSpace(out)
+}
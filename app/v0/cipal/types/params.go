@@ -0,0 +1,46 @@
+package types
+
+import (
+	"fmt"
+
+	"github.com/netcloth/netcloth-chain/app/v0/params"
+)
+
+var (
+	KeyStrictMode = []byte("StrictMode")
+)
+
+type Params struct {
+	StrictMode bool `json:"strict_mode" yaml:"strict_mode"` // the service addresses must be ipal nodes with an endpoint of the service type
+}
+
+var _ params.ParamSet = (*Params)(nil)
+
+func NewParams(strictMode bool) Params {
+	return Params{
+		StrictMode: strictMode,
+	}
+}
+
+func (p *Params) ParamSetPairs() params.ParamSetPairs {
+	return params.ParamSetPairs{
+		params.NewParamSetPair(KeyStrictMode, &p.StrictMode, validateStrictMode),
+	}
+}
+
+func DefaultParams() Params {
+	return NewParams(false)
+}
+
+func (p Params) String() string {
+	return fmt.Sprintf(`Params:
+  Strict Mode: %t`, p.StrictMode)
+}
+
+func validateStrictMode(i interface{}) error {
+	_, ok := i.(bool)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	return nil
+}
@@ -1,9 +1,20 @@
 package types
 
+import "fmt"
+
 const (
 	QueryCIPAL      = "query"
 	QueryCIPALCount = "count"
 	QueryCIPALs     = "batch_query"
+	QueryNonce      = "nonce"
+	QueryHistory    = "history"
+	QueryParameters = "parameters"
+	QueryUsers      = "users"
+	QueryList       = "list"
+)
+
+const (
+	MaxListLimit = 1000 // max cipal objects of a list page
 )
 
 type QueryCIPALParams struct {
@@ -19,3 +30,39 @@ func NewQueryCIPALParams(AccAddr string) QueryCIPALParams {
 		AccAddr: AccAddr,
 	}
 }
+
+type QueryUsersParams struct {
+	ServiceAddress string `json:"service_address"`
+}
+
+func NewQueryUsersParams(serviceAddress string) QueryUsersParams {
+	return QueryUsersParams{
+		ServiceAddress: serviceAddress,
+	}
+}
+
+// QueryCIPALListParams - pagination of the cipal objects. The page starts after the cursor
+// if given, otherwise it's the page-th of limit objects
+type QueryCIPALListParams struct {
+	Page   int    `json:"page"`
+	Limit  int    `json:"limit"`
+	Cursor string `json:"cursor"` // hex encoded
+}
+
+func NewQueryCIPALListParams(page, limit int, cursor string) QueryCIPALListParams {
+	return QueryCIPALListParams{
+		Page:   page,
+		Limit:  limit,
+		Cursor: cursor,
+	}
+}
+
+// CIPALListResult - a page of the cipal objects, NextCursor is empty on the last page
+type CIPALListResult struct {
+	Objects    CIPALObjects `json:"objects" yaml:"objects"`
+	NextCursor string       `json:"next_cursor" yaml:"next_cursor"`
+}
+
+func (r CIPALListResult) String() string {
+	return fmt.Sprintf("%s\nNext Cursor: %s", r.Objects, r.NextCursor)
+}
@@ -0,0 +1,34 @@
+package types
+
+import (
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// ServedUser - a user bound to a service address for a service type
+type ServedUser struct {
+	UserAddress string `json:"user_address" yaml:"user_address"`
+	ServiceType uint64 `json:"service_type" yaml:"service_type"`
+}
+
+func NewServedUser(userAddress string, serviceType uint64) ServedUser {
+	return ServedUser{
+		UserAddress: userAddress,
+		ServiceType: serviceType,
+	}
+}
+
+func (u ServedUser) String() string {
+	out, _ := yaml.Marshal(u)
+	return string(out)
+}
+
+type ServedUsers []ServedUser
+
+func (u ServedUsers) String() (out string) {
+	for _, val := range u {
+		out += val.String() + "\n"
+	}
+	return strings.TrimSpace(out)
+}
@@ -168,6 +168,10 @@ Where proposal.json contains:
     "switch_height":100000,
     "threshold":"90.000000000000000000"
 }
+
+Instead of switch_height, the upgrade may switch at the first block after a UTC time given by switch_time:
+
+    "switch_time":"2020-06-01T08:00:00Z",
 `,
 				version.ClientName,
 			),
@@ -194,6 +198,7 @@ Where proposal.json contains:
 			proposal.Version = proposalJson.Version
 			proposal.Software = proposalJson.Software
 			proposal.SwitchHeight = proposalJson.SwitchHeight
+			proposal.SwitchTime = proposalJson.SwitchTime
 			proposal.Threshold = proposalJson.Threshold
 
 			if err = proposal.ValidateBasic(); err != nil {
@@ -35,8 +35,12 @@ func handleSoftwareUpgradeProposal(ctx sdk.Context, keeper Keeper, proposalConte
 		return types.ErrSoftwareUpgradeInvalidVersion
 	}
 
-	if uint64(ctx.BlockHeight()) > proposalContent.SwitchHeight {
-		return types.ErrSoftwareUpgradeInvalidSwitchHeight
+	if proposalContent.SwitchTime.IsZero() {
+		if uint64(ctx.BlockHeight()) > proposalContent.SwitchHeight {
+			return types.ErrSoftwareUpgradeInvalidSwitchHeight
+		}
+	} else if !proposalContent.SwitchTime.After(ctx.BlockHeader().Time) {
+		return sdkerrors.Wrapf(types.ErrSoftwareUpgradeInvalidSwitchTime, "switch time %s not after block time %s", proposalContent.SwitchTime, ctx.BlockHeader().Time)
 	}
 
 	_, found := keeper.gk.GetProfiler(ctx, proposer)
@@ -49,6 +53,9 @@ func handleSoftwareUpgradeProposal(ctx sdk.Context, keeper Keeper, proposalConte
 	}
 
 	pd := sdk.NewProtocolDefinition(proposalContent.Version, proposalContent.Software, proposalContent.SwitchHeight, proposalContent.Threshold)
+	if !proposalContent.SwitchTime.IsZero() {
+		pd = sdk.NewTimedProtocolDefinition(proposalContent.Version, proposalContent.Software, proposalContent.SwitchTime, proposalContent.Threshold)
+	}
 	uc := sdk.NewUpgradeConfig(pid, pd)
 	keeper.pk.SetUpgradeConfig(ctx, uc)
 
@@ -20,4 +20,5 @@ var (
 	ErrSoftwareUpgradeInvalidProfiler       = sdkerrors.New(ModuleName, 12, "invalid software upgrade profiler")
 	ErrSoftwareUpgradeSwitchPeriodInProcess = sdkerrors.New(ModuleName, 13, "software upgrade already in switch period")
 	ErrSoftwareUpgradeInvalidThreshold      = sdkerrors.New(ModuleName, 14, "software upgrade Threshold should be in range [0.8, 1.0]")
+	ErrSoftwareUpgradeInvalidSwitchTime     = sdkerrors.New(ModuleName, 15, "invalid software upgrade switch time")
 )
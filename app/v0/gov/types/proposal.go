@@ -7,6 +7,7 @@ import (
 	"time"
 
 	sdk "github.com/netcloth/netcloth-chain/types"
+	sdkerrors "github.com/netcloth/netcloth-chain/types/errors"
 )
 
 // Proposal defines a struct used by the governance module to allow for voting
@@ -281,12 +282,13 @@ func (tp TextProposal) String() string {
 }
 
 type SoftwareUpgradeProposal struct {
-	Title        string  `json:"title" yaml:"title"`
-	Description  string  `json:"description" yaml:"description"`
-	Version      uint64  `json:"version"`
-	Software     string  `json:"software"`
-	SwitchHeight uint64  `json:"switch_height"`
-	Threshold    sdk.Dec `json:"threshold"`
+	Title        string    `json:"title" yaml:"title"`
+	Description  string    `json:"description" yaml:"description"`
+	Version      uint64    `json:"version"`
+	Software     string    `json:"software"`
+	SwitchHeight uint64    `json:"switch_height"`
+	Threshold    sdk.Dec   `json:"threshold"`
+	SwitchTime   time.Time `json:"switch_time"` // alternative to SwitchHeight, the upgrade switches at the first block after the time
 }
 
 func NewSoftwareUpgradeProposal(title, description string, version uint64, software string, switchHeight uint64, threshold sdk.Dec) Content {
@@ -300,6 +302,18 @@ func NewSoftwareUpgradeProposal(title, description string, version uint64, softw
 	}
 }
 
+// NewTimedSoftwareUpgradeProposal creates a software upgrade proposal switching at the switch time instead of a height
+func NewTimedSoftwareUpgradeProposal(title, description string, version uint64, software string, switchTime time.Time, threshold sdk.Dec) Content {
+	return SoftwareUpgradeProposal{
+		Title:       title,
+		Description: description,
+		Version:     version,
+		Software:    software,
+		Threshold:   threshold,
+		SwitchTime:  switchTime,
+	}
+}
+
 var _ Content = SoftwareUpgradeProposal{}
 
 // nolint
@@ -311,6 +325,9 @@ func (sup SoftwareUpgradeProposal) ValidateBasic() error {
 	if sup.Threshold.LT(sdk.NewDecWithPrec(80, 2)) || sup.Threshold.GT(sdk.NewDecWithPrec(100, 2)) {
 		return ErrSoftwareUpgradeInvalidThreshold
 	}
+	if !sup.SwitchTime.IsZero() && sup.SwitchHeight != 0 {
+		return sdkerrors.Wrap(ErrSoftwareUpgradeInvalidSwitchTime, "only one of switch height and switch time can be set")
+	}
 	return ValidateAbstract(sup)
 }
 
@@ -3,8 +3,11 @@ package types
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
+
+	sdk "github.com/netcloth/netcloth-chain/types"
 )
 
 func TestProposalStatus_Format(t *testing.T) {
@@ -22,3 +25,16 @@ func TestProposalStatus_Format(t *testing.T) {
 		require.Equal(t, tt.expectedStringOutput, got)
 	}
 }
+
+func TestSoftwareUpgradeProposalSwitchTime(t *testing.T) {
+	threshold := sdk.NewDecWithPrec(9, 1)
+	switchTime := time.Unix(1000, 0).UTC()
+
+	require.NoError(t, NewSoftwareUpgradeProposal("title", "desc", 1, "software", 100, threshold).ValidateBasic())
+	require.NoError(t, NewTimedSoftwareUpgradeProposal("title", "desc", 1, "software", switchTime, threshold).ValidateBasic())
+
+	// only one of switch height and switch time
+	sup := NewSoftwareUpgradeProposal("title", "desc", 1, "software", 100, threshold).(SoftwareUpgradeProposal)
+	sup.SwitchTime = switchTime
+	require.True(t, ErrSoftwareUpgradeInvalidSwitchTime.Is(sup.ValidateBasic()))
+}
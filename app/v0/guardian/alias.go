@@ -12,22 +12,32 @@ const (
 	RouterKey      = types.RouterKey
 	QuerierRoute   = types.QuerierRoute
 	QueryProfilers = types.QueryProfilers
-	StoreKey       = types.StoreKey
+
+	QueryPendingActions = types.QueryPendingActions
+	QueryPendingAction  = types.QueryPendingAction
+	StoreKey            = types.StoreKey
 )
 
 type (
 	MsgAddProfiler    = types.MsgAddProfiler
 	MsgDeleteProfiler = types.MsgDeleteProfiler
-	Guardian          = types.Guardian
-	Profilers         = types.Profilers
+
+	MsgAddGenesisProfiler = types.MsgAddGenesisProfiler
+	MsgApproveAction      = types.MsgApproveAction
+	PendingAction         = types.PendingAction
+	PendingActions        = types.PendingActions
+	Guardian              = types.Guardian
+	Profilers             = types.Profilers
 )
 
 var (
-	NewMsgAddProfiler       = types.NewMsgAddProfiler
-	NewMsgDeleteProfiler    = types.NewMsgDeleteProfiler
-	NewGuardian             = types.NewGuardian
-	GetProfilerKey          = types.GetProfilerKey
-	GetProfilersSubspaceKey = types.GetProfilersSubspaceKey
+	NewMsgAddProfiler        = types.NewMsgAddProfiler
+	NewMsgDeleteProfiler     = types.NewMsgDeleteProfiler
+	NewMsgAddGenesisProfiler = types.NewMsgAddGenesisProfiler
+	NewMsgApproveAction      = types.NewMsgApproveAction
+	NewGuardian              = types.NewGuardian
+	GetProfilerKey           = types.GetProfilerKey
+	GetProfilersSubspaceKey  = types.GetProfilersSubspaceKey
 
 	ErrInvalidOperator       = types.ErrInvalidOperator
 	ErrProfilerNotExists     = types.ErrProfilerNotExists
@@ -37,4 +47,8 @@ var (
 	ErrAddressEmpty          = types.ErrAddressEmpty
 	ErrAddedByEmpty          = types.ErrAddedByEmpty
 	ErrDeletedByEmpty        = types.ErrDeletedByEmpty
+	ErrActionNotExists       = types.ErrActionNotExists
+	ErrActionApproved        = types.ErrActionApproved
+	ErrActionExpired         = types.ErrActionExpired
+	ErrLastGenesisProfiler   = types.ErrLastGenesisProfiler
 )
@@ -2,6 +2,7 @@ package cli
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/spf13/cobra"
 
@@ -22,6 +23,8 @@ func GetQueryCmd(cdc *codec.Codec) *cobra.Command {
 
 	guardianQueryCmd.AddCommand(client.GetCommands(
 		GetCmdQueryProfilers(cdc),
+		GetCmdQueryPendingActions(cdc),
+		GetCmdQueryPendingAction(cdc),
 	)...)
 
 	return guardianQueryCmd
@@ -53,3 +56,64 @@ func GetCmdQueryProfilers(cdc *codec.Codec) *cobra.Command {
 	}
 	return cmd
 }
+
+func GetCmdQueryPendingActions(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "pending-actions",
+		Short:   "Query for the profiler actions pending approvals",
+		Example: "nchcli query guardian pending-actions",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryPendingActions), nil)
+			if err != nil {
+				return err
+			}
+
+			var actions types.PendingActions
+			err = cdc.UnmarshalJSON(res, &actions)
+			if err != nil {
+				return err
+			}
+
+			return cliCtx.PrintOutput(actions)
+		},
+	}
+	return cmd
+}
+
+func GetCmdQueryPendingAction(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "pending-action [action-id]",
+		Short:   "Query for a profiler action pending approvals",
+		Example: "nchcli query guardian pending-action 1",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			actionID, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("action id %s not a valid uint", args[0])
+			}
+
+			bz, err := cdc.MarshalJSON(types.NewQueryPendingActionParams(actionID))
+			if err != nil {
+				return err
+			}
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryPendingAction), bz)
+			if err != nil {
+				return err
+			}
+
+			var action types.PendingAction
+			err = cdc.UnmarshalJSON(res, &action)
+			if err != nil {
+				return err
+			}
+
+			return cliCtx.PrintOutput(action)
+		},
+	}
+	return cmd
+}
@@ -2,6 +2,8 @@ package cli
 
 import (
 	"fmt"
+	"strconv"
+
 	"github.com/netcloth/netcloth-chain/client"
 
 	"github.com/spf13/cobra"
@@ -27,6 +29,8 @@ func GuardianCmd(cdc *codec.Codec) *cobra.Command {
 	txCmd.AddCommand(client.PostCommands(
 		GetCmdCreateProfiler(cdc),
 		GetCmdDeleteProfiler(cdc),
+		GetCmdAddGenesisProfiler(cdc),
+		GetCmdApproveAction(cdc),
 	)...)
 
 	return txCmd
@@ -35,7 +39,7 @@ func GuardianCmd(cdc *codec.Codec) *cobra.Command {
 func GetCmdCreateProfiler(cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "add-profiler",
-		Short:   "Add a new profiler",
+		Short:   "Propose a new profiler, added once approved by enough genesis guardians",
 		Example: "nchcli guardian add-profiler --from=<key-name> --address=<added address> --description=<name>",
 
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -80,7 +84,7 @@ func GetCmdCreateProfiler(cdc *codec.Codec) *cobra.Command {
 func GetCmdDeleteProfiler(cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "delete-profiler",
-		Short:   "Delete a profiler",
+		Short:   "Propose to delete a profiler, deleted once approved by enough genesis guardians",
 		Example: "nchcli guardian delete-profiler --from=<key-name> --address=<deleted address>",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
@@ -112,3 +116,63 @@ func GetCmdDeleteProfiler(cdc *codec.Codec) *cobra.Command {
 
 	return cmd
 }
+
+func GetCmdAddGenesisProfiler(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "add-genesis-profiler",
+		Short:   "Propose a new genesis profiler, added once approved by enough genesis guardians",
+		Example: "nchcli guardian add-genesis-profiler --from=<key-name> --address=<added address> --description=<name>",
+
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			profilerAddr, err := sdk.AccAddressFromBech32(viper.GetString(FlagAddress))
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgAddGenesisProfiler(viper.GetString(FlagDescription), profilerAddr, cliCtx.GetFromAddress())
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().String(FlagAddress, "", "bech32 encoded account address")
+	cmd.Flags().String(FlagDescription, "", "description of account")
+
+	cmd.MarkFlagRequired(FlagAddress)
+	cmd.MarkFlagRequired(FlagDescription)
+
+	return cmd
+}
+
+func GetCmdApproveAction(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "approve [action-id]",
+		Short:   "Approve a pending profiler action as a genesis guardian",
+		Example: "nchcli guardian approve 1 --from=<key-name>",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			actionID, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("action id %s not a valid uint", args[0])
+			}
+
+			msg := types.NewMsgApproveAction(actionID, cliCtx.GetFromAddress())
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	return cmd
+}
@@ -1,17 +1,25 @@
 package guardian
 
 import (
+	"time"
+
 	"github.com/netcloth/netcloth-chain/app/v0/guardian/types"
 	sdk "github.com/netcloth/netcloth-chain/types"
 )
 
 type GenesisState struct {
-	Profilers []types.Guardian `json:"profilers"`
+	Profilers      []types.Guardian      `json:"profilers"`
+	Threshold      uint64                `json:"threshold"`     // approvals of genesis guardians a pending action needs, 0 for the majority
+	ActionPeriod   time.Duration         `json:"action_period"` // time to approve a pending action
+	PendingActions []types.PendingAction `json:"pending_actions"`
 }
 
-func NewGenesisState(profilers []types.Guardian) GenesisState {
+func NewGenesisState(profilers []types.Guardian, threshold uint64, actionPeriod time.Duration, pendingActions []types.PendingAction) GenesisState {
 	return GenesisState{
-		Profilers: profilers,
+		Profilers:      profilers,
+		Threshold:      threshold,
+		ActionPeriod:   actionPeriod,
+		PendingActions: pendingActions,
 	}
 }
 
@@ -19,6 +27,20 @@ func InitGenesis(ctx sdk.Context, keeper Keeper, data GenesisState) {
 	for _, profiler := range data.Profilers {
 		keeper.AddProfiler(ctx, profiler)
 	}
+
+	keeper.SetThreshold(ctx, data.Threshold)
+	if data.ActionPeriod > 0 { // the genesis before the pending actions
+		keeper.SetActionPeriod(ctx, data.ActionPeriod)
+	}
+
+	nextID := uint64(1)
+	for _, action := range data.PendingActions {
+		keeper.SetPendingAction(ctx, action)
+		if action.ID >= nextID {
+			nextID = action.ID + 1
+		}
+	}
+	keeper.SetNextActionID(ctx, nextID)
 }
 
 func ExportGenesis(ctx sdk.Context, k Keeper) GenesisState {
@@ -31,11 +53,11 @@ func ExportGenesis(ctx sdk.Context, k Keeper) GenesisState {
 		profilers = append(profilers, profiler)
 	}
 
-	return NewGenesisState(profilers)
+	return NewGenesisState(profilers, k.GetThreshold(ctx), k.GetActionPeriod(ctx), k.GetPendingActions(ctx))
 }
 
 func DefaultGenesisState() GenesisState {
-	return NewGenesisState([]types.Guardian{})
+	return NewGenesisState([]types.Guardian{}, 0, types.DefaultActionPeriod, []types.PendingAction{})
 }
 
 func (gs GenesisState) Contains(addr sdk.AccAddress) bool {
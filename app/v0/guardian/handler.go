@@ -1,17 +1,28 @@
 package guardian
 
 import (
+	"fmt"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/netcloth/netcloth-chain/app/v0/guardian/types"
 	sdk "github.com/netcloth/netcloth-chain/types"
 	sdkerrors "github.com/netcloth/netcloth-chain/types/errors"
 )
 
 func NewHandler(k Keeper) sdk.Handler {
 	return func(ctx sdk.Context, msg sdk.Msg) (*sdk.Result, error) {
+		ctx = ctx.WithEventManager(sdk.NewEventManager())
+
 		switch msg := msg.(type) {
 		case MsgAddProfiler:
 			return handleMsgAddProfiler(ctx, k, msg)
+		case MsgAddGenesisProfiler:
+			return handleMsgAddGenesisProfiler(ctx, k, msg)
 		case MsgDeleteProfiler:
 			return handleMsgDeleteProfiler(ctx, k, msg)
+		case MsgApproveAction:
+			return handleMsgApproveAction(ctx, k, msg)
 		default:
 			return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unrecognized %s message type: %T", ModuleName, msg)
 		}
@@ -19,40 +30,169 @@ func NewHandler(k Keeper) sdk.Handler {
 }
 
 func handleMsgAddProfiler(ctx sdk.Context, k Keeper, msg MsgAddProfiler) (*sdk.Result, error) {
-	if profiler, found := k.GetProfiler(ctx, msg.AddedBy); !found || profiler.AccountType != Genesis {
-		return nil, ErrInvalidOperator(msg.AddedBy)
+	profiler := NewGuardian(msg.Description, Ordinary, msg.Address, msg.AddedBy)
+	return proposeAction(ctx, k, types.ActionAddProfiler, profiler, msg.AddedBy)
+}
+
+func handleMsgAddGenesisProfiler(ctx sdk.Context, k Keeper, msg MsgAddGenesisProfiler) (*sdk.Result, error) {
+	profiler := NewGuardian(msg.Description, Genesis, msg.Address, msg.AddedBy)
+	return proposeAction(ctx, k, types.ActionAddProfiler, profiler, msg.AddedBy)
+}
+
+func handleMsgDeleteProfiler(ctx sdk.Context, k Keeper, msg MsgDeleteProfiler) (*sdk.Result, error) {
+	profiler, found := k.GetProfiler(ctx, msg.Address)
+	if !found {
+		return nil, ErrProfilerNotExists(msg.Address)
 	}
 
-	if _, found := k.GetProfiler(ctx, msg.Address); found {
-		return nil, ErrProfilerExists(msg.Address)
+	return proposeAction(ctx, k, types.ActionDeleteProfiler, profiler, msg.DeletedBy)
+}
+
+// proposeAction opens a pending action approved by its genesis guardian proposer, executed at once
+// if that is enough approvals
+func proposeAction(ctx sdk.Context, k Keeper, actionType types.ActionType, profiler Guardian, proposer sdk.AccAddress) (*sdk.Result, error) {
+	if p, found := k.GetProfiler(ctx, proposer); !found || p.AccountType != Genesis {
+		return nil, ErrInvalidOperator(proposer)
 	}
 
-	profiler := NewGuardian(msg.Description, Ordinary, msg.Address, msg.AddedBy)
-	err := k.AddProfiler(ctx, profiler)
-	if err != nil {
+	if err := validateAction(ctx, k, actionType, profiler); err != nil {
 		return nil, err
 	}
 
-	return &sdk.Result{}, nil
+	id := k.GetNextActionID(ctx)
+	k.SetNextActionID(ctx, id+1)
+	action := types.NewPendingAction(id, actionType, profiler, proposer, ctx.BlockHeader().Time.Add(k.GetActionPeriod(ctx)))
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeProposeAction,
+			sdk.NewAttribute(types.AttributeKeyActionID, fmt.Sprintf("%d", id)),
+			sdk.NewAttribute(types.AttributeKeyActionType, actionType.String()),
+			sdk.NewAttribute(types.AttributeKeyAddress, profiler.Address.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, proposer.String()),
+		),
+	})
+
+	return tryExecuteAction(ctx, k, action)
 }
 
-func handleMsgDeleteProfiler(ctx sdk.Context, k Keeper, msg MsgDeleteProfiler) (*sdk.Result, error) {
-	if profiler, found := k.GetProfiler(ctx, msg.DeletedBy); !found || profiler.AccountType != Genesis {
-		return nil, ErrInvalidOperator(msg.DeletedBy)
+func handleMsgApproveAction(ctx sdk.Context, k Keeper, msg MsgApproveAction) (*sdk.Result, error) {
+	if p, found := k.GetProfiler(ctx, msg.Approver); !found || p.AccountType != Genesis {
+		return nil, ErrInvalidOperator(msg.Approver)
 	}
 
-	profiler, found := k.GetProfiler(ctx, msg.Address)
+	action, found := k.GetPendingAction(ctx, msg.ActionID)
 	if !found {
-		return nil, ErrProfilerNotExists(msg.Address)
+		return nil, types.ErrActionNotExists(msg.ActionID)
+	}
+
+	if ctx.BlockHeader().Time.After(action.Deadline) {
+		return nil, types.ErrActionExpired(msg.ActionID)
+	}
+
+	if action.HasApproved(msg.Approver) {
+		return nil, types.ErrActionApproved(msg.ActionID, msg.Approver)
+	}
+	action.Approvals = append(action.Approvals, msg.Approver)
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeApproveAction,
+			sdk.NewAttribute(types.AttributeKeyActionID, fmt.Sprintf("%d", action.ID)),
+			sdk.NewAttribute(types.AttributeKeyApprover, msg.Approver.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Approver.String()),
+		),
+	})
+
+	return tryExecuteAction(ctx, k, action)
+}
+
+// tryExecuteAction executes the action if approved by enough of the current genesis guardians, or
+// keeps it pending
+func tryExecuteAction(ctx sdk.Context, k Keeper, action types.PendingAction) (*sdk.Result, error) {
+	genesisProfilers := k.GetGenesisProfilers(ctx)
+	approvals := 0
+	for _, p := range genesisProfilers {
+		if action.HasApproved(p.Address) {
+			approvals++
+		}
+	}
+
+	if approvals < types.RequiredApprovals(k.GetThreshold(ctx), len(genesisProfilers)) {
+		k.SetPendingAction(ctx, action)
+		return &sdk.Result{Events: ctx.EventManager().Events()}, nil
 	}
 
-	if profiler.AccountType == Genesis {
-		return nil, ErrDeleteGenesisProfiler(msg.Address)
+	if err := validateAction(ctx, k, action.Type, action.Guardian); err != nil {
+		return nil, err
 	}
 
-	err := k.DeleteProfiler(ctx, msg.Address)
+	var err error
+	switch action.Type {
+	case types.ActionAddProfiler:
+		err = k.AddProfiler(ctx, action.Guardian)
+	case types.ActionDeleteProfiler:
+		err = k.DeleteProfiler(ctx, action.Guardian.Address)
+	}
 	if err != nil {
 		return nil, err
 	}
-	return &sdk.Result{}, nil
+	k.DeletePendingAction(ctx, action.ID)
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeExecuteAction,
+			sdk.NewAttribute(types.AttributeKeyActionID, fmt.Sprintf("%d", action.ID)),
+			sdk.NewAttribute(types.AttributeKeyActionType, action.Type.String()),
+			sdk.NewAttribute(types.AttributeKeyAddress, action.Guardian.Address.String()),
+		),
+	)
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+// validateAction checks the action applies to the current profilers, the last genesis profiler can't
+// be deleted
+func validateAction(ctx sdk.Context, k Keeper, actionType types.ActionType, profiler Guardian) error {
+	switch actionType {
+	case types.ActionAddProfiler:
+		if _, found := k.GetProfiler(ctx, profiler.Address); found {
+			return ErrProfilerExists(profiler.Address)
+		}
+	case types.ActionDeleteProfiler:
+		p, found := k.GetProfiler(ctx, profiler.Address)
+		if !found {
+			return ErrProfilerNotExists(profiler.Address)
+		}
+		if p.AccountType == Genesis && len(k.GetGenesisProfilers(ctx)) == 1 {
+			return types.ErrLastGenesisProfiler(profiler.Address)
+		}
+	}
+	return nil
+}
+
+// EndBlocker removes the pending actions past their deadline
+func EndBlocker(ctx sdk.Context, k Keeper) []abci.ValidatorUpdate {
+	for _, action := range k.GetPendingActions(ctx) {
+		if !ctx.BlockHeader().Time.After(action.Deadline) {
+			continue
+		}
+
+		k.DeletePendingAction(ctx, action.ID)
+		ctx.EventManager().EmitEvent(
+			sdk.NewEvent(
+				types.EventTypeExpireAction,
+				sdk.NewAttribute(types.AttributeKeyActionID, fmt.Sprintf("%d", action.ID)),
+			),
+		)
+	}
+	return []abci.ValidatorUpdate{}
 }
@@ -0,0 +1,125 @@
+package guardian
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+	"github.com/tendermint/tendermint/libs/log"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/netcloth/netcloth-chain/app/v0/guardian/types"
+	"github.com/netcloth/netcloth-chain/codec"
+	"github.com/netcloth/netcloth-chain/store"
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+func createTestInput(t *testing.T) (sdk.Context, Keeper) {
+	key := sdk.NewKVStoreKey(StoreKey)
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(key, sdk.StoreTypeIAVL, db)
+	require.Nil(t, ms.LoadLatestVersion())
+
+	cdc := codec.New()
+	types.RegisterCodec(cdc)
+	codec.RegisterCrypto(cdc)
+
+	ctx := sdk.NewContext(ms, abci.Header{Time: time.Unix(0, 0)}, false, log.NewNopLogger())
+	return ctx, NewKeeper(cdc, key)
+}
+
+func newAddr() sdk.AccAddress {
+	return sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+}
+
+func TestProfilerActionsApprovals(t *testing.T) {
+	ctx, k := createTestInput(t)
+	h := NewHandler(k)
+
+	g1, g2, g3 := newAddr(), newAddr(), newAddr()
+	InitGenesis(ctx, k, NewGenesisState([]Guardian{
+		NewGuardian("g1", Genesis, g1, g1),
+		NewGuardian("g2", Genesis, g2, g2),
+		NewGuardian("g3", Genesis, g3, g3),
+	}, 0, time.Hour, nil))
+
+	// only the genesis guardians propose
+	ordinary := newAddr()
+	_, err := h(ctx, NewMsgAddProfiler("ordinary", ordinary, ordinary))
+	require.NotNil(t, err)
+
+	// 2 of 3 approvals
+	_, err = h(ctx, NewMsgAddProfiler("ordinary", ordinary, g1))
+	require.Nil(t, err)
+	_, found := k.GetProfiler(ctx, ordinary)
+	require.False(t, found)
+	action, found := k.GetPendingAction(ctx, 1)
+	require.True(t, found)
+	require.Equal(t, types.ActionAddProfiler, action.Type)
+
+	_, err = h(ctx, NewMsgApproveAction(1, g1))
+	require.NotNil(t, err)
+	_, err = h(ctx, NewMsgApproveAction(1, ordinary))
+	require.NotNil(t, err)
+
+	_, err = h(ctx, NewMsgApproveAction(1, g2))
+	require.Nil(t, err)
+	p, found := k.GetProfiler(ctx, ordinary)
+	require.True(t, found)
+	require.Equal(t, Ordinary, p.AccountType)
+	require.Empty(t, k.GetPendingActions(ctx))
+
+	// an ordinary profiler can't approve, a genesis guardian is rotated out with approvals
+	_, err = h(ctx, NewMsgDeleteProfiler(g3, g1))
+	require.Nil(t, err)
+	_, err = h(ctx, NewMsgApproveAction(2, ordinary))
+	require.NotNil(t, err)
+	_, err = h(ctx, NewMsgApproveAction(2, g2))
+	require.Nil(t, err)
+	_, found = k.GetProfiler(ctx, g3)
+	require.False(t, found)
+	require.Len(t, k.GetGenesisProfilers(ctx), 2)
+
+	// the pending actions expire
+	g4 := newAddr()
+	_, err = h(ctx, NewMsgAddGenesisProfiler("g4", g4, g2))
+	require.Nil(t, err)
+	ctx = ctx.WithBlockHeader(abci.Header{Time: time.Unix(0, 0).Add(2 * time.Hour)})
+	_, err = h(ctx, NewMsgApproveAction(3, g1))
+	require.NotNil(t, err)
+	EndBlocker(ctx, k)
+	_, found = k.GetPendingAction(ctx, 3)
+	require.False(t, found)
+
+	gs := ExportGenesis(ctx, k)
+	require.Equal(t, time.Hour, gs.ActionPeriod)
+	require.Len(t, gs.Profilers, 3)
+}
+
+func TestLastGenesisProfiler(t *testing.T) {
+	ctx, k := createTestInput(t)
+	h := NewHandler(k)
+
+	g1 := newAddr()
+	InitGenesis(ctx, k, NewGenesisState([]Guardian{NewGuardian("g1", Genesis, g1, g1)}, 0, 0, nil))
+	require.Equal(t, types.DefaultActionPeriod, k.GetActionPeriod(ctx))
+
+	// a single genesis guardian approves alone
+	g2 := newAddr()
+	_, err := h(ctx, NewMsgAddGenesisProfiler("g2", g2, g1))
+	require.Nil(t, err)
+	p, found := k.GetProfiler(ctx, g2)
+	require.True(t, found)
+	require.Equal(t, Genesis, p.AccountType)
+
+	k.SetThreshold(ctx, 1)
+	_, err = h(ctx, NewMsgDeleteProfiler(g1, g2))
+	require.Nil(t, err)
+	_, err = h(ctx, NewMsgDeleteProfiler(g2, g2))
+	require.NotNil(t, err)
+	_, found = k.GetProfiler(ctx, g2)
+	require.True(t, found)
+}
@@ -1,6 +1,9 @@
 package guardian
 
 import (
+	"time"
+
+	"github.com/netcloth/netcloth-chain/app/v0/guardian/types"
 	"github.com/netcloth/netcloth-chain/codec"
 	sdk "github.com/netcloth/netcloth-chain/types"
 )
@@ -45,3 +48,98 @@ func (k Keeper) ProfilersIterator(ctx sdk.Context) sdk.Iterator {
 	store := ctx.KVStore(k.storeKey)
 	return sdk.KVStorePrefixIterator(store, GetProfilersSubspaceKey())
 }
+
+// GetGenesisProfilers returns the genesis profilers, approving the pending actions
+func (k Keeper) GetGenesisProfilers(ctx sdk.Context) (profilers []Guardian) {
+	iterator := k.ProfilersIterator(ctx)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var profiler Guardian
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &profiler)
+		if profiler.AccountType == Genesis {
+			profilers = append(profilers, profiler)
+		}
+	}
+	return profilers
+}
+
+// GetThreshold returns the approvals of genesis guardians a pending action needs, 0 for the majority
+func (k Keeper) GetThreshold(ctx sdk.Context) (threshold uint64) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetThresholdKey())
+	if bz != nil {
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &threshold)
+	}
+	return threshold
+}
+
+func (k Keeper) SetThreshold(ctx sdk.Context, threshold uint64) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetThresholdKey(), k.cdc.MustMarshalBinaryLengthPrefixed(threshold))
+}
+
+// GetActionPeriod returns the time the genesis guardians have to approve a pending action
+func (k Keeper) GetActionPeriod(ctx sdk.Context) (period time.Duration) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetActionPeriodKey())
+	if bz == nil {
+		return types.DefaultActionPeriod
+	}
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &period)
+	return period
+}
+
+func (k Keeper) SetActionPeriod(ctx sdk.Context, period time.Duration) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetActionPeriodKey(), k.cdc.MustMarshalBinaryLengthPrefixed(period))
+}
+
+func (k Keeper) GetNextActionID(ctx sdk.Context) (id uint64) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetNextActionIDKey())
+	if bz == nil {
+		return 1
+	}
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &id)
+	return id
+}
+
+func (k Keeper) SetNextActionID(ctx sdk.Context, id uint64) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetNextActionIDKey(), k.cdc.MustMarshalBinaryLengthPrefixed(id))
+}
+
+func (k Keeper) GetPendingAction(ctx sdk.Context, id uint64) (action types.PendingAction, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetPendingActionKey(id))
+	if bz == nil {
+		return action, false
+	}
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &action)
+	return action, true
+}
+
+func (k Keeper) SetPendingAction(ctx sdk.Context, action types.PendingAction) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetPendingActionKey(action.ID), k.cdc.MustMarshalBinaryLengthPrefixed(action))
+}
+
+func (k Keeper) DeletePendingAction(ctx sdk.Context, id uint64) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetPendingActionKey(id))
+}
+
+// GetPendingActions returns the pending actions by id
+func (k Keeper) GetPendingActions(ctx sdk.Context) (actions types.PendingActions) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.GetPendingActionsSubspaceKey())
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var action types.PendingAction
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &action)
+		actions = append(actions, action)
+	}
+	return actions
+}
@@ -104,5 +104,5 @@ func (a AppModule) BeginBlock(sdk.Context, types.RequestBeginBlock) {
 }
 
 func (a AppModule) EndBlock(ctx sdk.Context, b types.RequestEndBlock) []types.ValidatorUpdate {
-	return nil
+	return EndBlocker(ctx, a.keeper)
 }
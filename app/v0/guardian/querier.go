@@ -4,8 +4,10 @@ import (
 	"errors"
 	abci "github.com/tendermint/tendermint/abci/types"
 
+	"github.com/netcloth/netcloth-chain/app/v0/guardian/types"
 	"github.com/netcloth/netcloth-chain/codec"
 	sdk "github.com/netcloth/netcloth-chain/types"
+	sdkerrors "github.com/netcloth/netcloth-chain/types/errors"
 )
 
 func NewQuerier(k Keeper) sdk.Querier {
@@ -13,6 +15,10 @@ func NewQuerier(k Keeper) sdk.Querier {
 		switch path[0] {
 		case QueryProfilers:
 			return queryProfilers(ctx, k)
+		case QueryPendingActions:
+			return queryPendingActions(ctx, k)
+		case QueryPendingAction:
+			return queryPendingAction(ctx, req, k)
 		default:
 			return nil, errors.New("unknown guardian query endpoint")
 		}
@@ -36,3 +42,34 @@ func queryProfilers(ctx sdk.Context, k Keeper) ([]byte, error) {
 	}
 	return bz, nil
 }
+
+func queryPendingActions(ctx sdk.Context, k Keeper) ([]byte, error) {
+	actions := k.GetPendingActions(ctx)
+	if actions == nil {
+		actions = PendingActions{}
+	}
+
+	bz, err := codec.MarshalJSONIndent(k.cdc, actions)
+	if err != nil {
+		return nil, err
+	}
+	return bz, nil
+}
+
+func queryPendingAction(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QueryPendingActionParams
+	if err := k.cdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	action, found := k.GetPendingAction(ctx, params.ID)
+	if !found {
+		return nil, ErrActionNotExists(params.ID)
+	}
+
+	bz, err := codec.MarshalJSONIndent(k.cdc, action)
+	if err != nil {
+		return nil, err
+	}
+	return bz, nil
+}
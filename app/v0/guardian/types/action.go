@@ -0,0 +1,134 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+const (
+	// DefaultActionPeriod - time the genesis guardians have to approve a pending action
+	DefaultActionPeriod = time.Hour * 24 * 3
+)
+
+type ActionType byte
+
+const (
+	ActionAddProfiler    ActionType = 0x01
+	ActionDeleteProfiler ActionType = 0x02
+)
+
+// String to ActionType byte, Returns ff if invalid.
+func ActionTypeFromString(str string) (ActionType, error) {
+	switch str {
+	case "AddProfiler":
+		return ActionAddProfiler, nil
+	case "DeleteProfiler":
+		return ActionDeleteProfiler, nil
+	default:
+		return ActionType(0xff), errors.Errorf("'%s' is not a valid action type", str)
+	}
+}
+
+func (at ActionType) String() string {
+	switch at {
+	case ActionAddProfiler:
+		return "AddProfiler"
+	case ActionDeleteProfiler:
+		return "DeleteProfiler"
+	default:
+		return ""
+	}
+}
+
+func (at ActionType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(at.String())
+}
+
+func (at *ActionType) UnmarshalJSON(data []byte) error {
+	var s string
+	err := json.Unmarshal(data, &s)
+	if err != nil {
+		return err
+	}
+
+	bz2, err := ActionTypeFromString(s)
+	if err != nil {
+		return err
+	}
+	*at = bz2
+	return nil
+}
+
+// PendingAction - a change of the profilers, executed once approved by enough genesis guardians
+// before its deadline
+type PendingAction struct {
+	ID        uint64           `json:"id"`
+	Type      ActionType       `json:"type"`
+	Guardian  Guardian         `json:"guardian"` // profiler added, or deleted
+	Proposer  sdk.AccAddress   `json:"proposer"`
+	Approvals []sdk.AccAddress `json:"approvals"` // genesis guardians approving the action, the proposer first
+	Deadline  time.Time        `json:"deadline"`
+}
+
+func NewPendingAction(id uint64, actionType ActionType, guardian Guardian, proposer sdk.AccAddress, deadline time.Time) PendingAction {
+	return PendingAction{
+		ID:        id,
+		Type:      actionType,
+		Guardian:  guardian,
+		Proposer:  proposer,
+		Approvals: []sdk.AccAddress{proposer},
+		Deadline:  deadline,
+	}
+}
+
+// HasApproved returns whether the address approved the action
+func (a PendingAction) HasApproved(addr sdk.AccAddress) bool {
+	for _, approval := range a.Approvals {
+		if approval.Equals(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+func (a PendingAction) String() string {
+	return fmt.Sprintf(`PendingAction %d
+  Type:          %s
+  Address:       %s
+  Account Type:  %s
+  Description:   %s
+  Proposer:      %s
+  Approvals:     %v
+  Deadline:      %s`, a.ID, a.Type, a.Guardian.Address, a.Guardian.AccountType, a.Guardian.Description, a.Proposer, a.Approvals, a.Deadline)
+}
+
+type PendingActions []PendingAction
+
+func (as PendingActions) String() (out string) {
+	if len(as) == 0 {
+		return "[]"
+	}
+	for _, val := range as {
+		out += val.String() + "\n"
+	}
+	return strings.TrimSpace(out)
+}
+
+// RequiredApprovals returns the approvals an action needs out of the genesis guardians, the threshold
+// or the majority if the threshold is 0, at most all the genesis guardians
+func RequiredApprovals(threshold uint64, genesisGuardians int) int {
+	n := uint64(genesisGuardians)
+	if threshold == 0 {
+		return int(n/2 + 1)
+	}
+	if threshold > n {
+		return int(n)
+	}
+	return int(threshold)
+}
@@ -7,6 +7,8 @@ import (
 func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(MsgAddProfiler{}, "nch/guardian/MsgAddProfiler", nil)
 	cdc.RegisterConcrete(MsgDeleteProfiler{}, "nch/guardian/MsgDeleteProfiler", nil)
+	cdc.RegisterConcrete(MsgAddGenesisProfiler{}, "nch/guardian/MsgAddGenesisProfiler", nil)
+	cdc.RegisterConcrete(MsgApproveAction{}, "nch/guardian/MsgApproveAction", nil)
 	cdc.RegisterConcrete(Guardian{}, "nch/guardian/Guardian", nil)
 }
 
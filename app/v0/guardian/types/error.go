@@ -13,6 +13,10 @@ const (
 	CodeInvalidDescription    = 105
 	CodeDeleteGenesisProfiler = 106
 	CodeInvalidGuardian       = 108
+	CodeActionNotExists       = 109
+	CodeActionApproved        = 110
+	CodeActionExpired         = 111
+	CodeLastGenesisProfiler   = 112
 	CodeAddressEmpty          = 120
 	CodeAddedByEmpty          = 121
 	CodeDeletedByEmpty        = 122
@@ -49,3 +53,19 @@ func ErrAddedByEmpty() error {
 func ErrDeletedByEmpty() error {
 	return sdkerrors.New(ModuleName, CodeDeletedByEmpty, "deleted_by is empty")
 }
+
+func ErrActionNotExists(id uint64) error {
+	return sdkerrors.New(ModuleName, CodeActionNotExists, fmt.Sprintf("pending action %d is not existed", id))
+}
+
+func ErrActionApproved(id uint64, approver sdk.AccAddress) error {
+	return sdkerrors.New(ModuleName, CodeActionApproved, fmt.Sprintf("pending action %d already approved by %s", id, approver))
+}
+
+func ErrActionExpired(id uint64) error {
+	return sdkerrors.New(ModuleName, CodeActionExpired, fmt.Sprintf("pending action %d expired", id))
+}
+
+func ErrLastGenesisProfiler(profiler sdk.AccAddress) error {
+	return sdkerrors.New(ModuleName, CodeLastGenesisProfiler, fmt.Sprintf("can't delete profiler %s, the last genesis profiler", profiler))
+}
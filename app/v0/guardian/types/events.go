@@ -0,0 +1,15 @@
+package types
+
+const (
+	EventTypeProposeAction = "propose_guardian_action"
+	EventTypeApproveAction = "approve_guardian_action"
+	EventTypeExecuteAction = "execute_guardian_action"
+	EventTypeExpireAction  = "expire_guardian_action"
+
+	AttributeKeyActionID   = "action_id"
+	AttributeKeyActionType = "action_type"
+	AttributeKeyAddress    = "address"
+	AttributeKeyApprover   = "approver"
+
+	AttributeValueCategory = ModuleName
+)
@@ -13,7 +13,11 @@ const (
 )
 
 var (
-	profilerKey = []byte{0x00}
+	profilerKey      = []byte{0x00}
+	pendingActionKey = []byte{0x01}
+	nextActionIDKey  = []byte{0x02}
+	thresholdKey     = []byte{0x03}
+	actionPeriodKey  = []byte{0x04}
 )
 
 func GetProfilerKey(addr sdk.AccAddress) []byte {
@@ -23,3 +27,23 @@ func GetProfilerKey(addr sdk.AccAddress) []byte {
 func GetProfilersSubspaceKey() []byte {
 	return profilerKey
 }
+
+func GetPendingActionKey(id uint64) []byte {
+	return append(pendingActionKey, sdk.Uint64ToBigEndian(id)...)
+}
+
+func GetPendingActionsSubspaceKey() []byte {
+	return pendingActionKey
+}
+
+func GetNextActionIDKey() []byte {
+	return nextActionIDKey
+}
+
+func GetThresholdKey() []byte {
+	return thresholdKey
+}
+
+func GetActionPeriodKey() []byte {
+	return actionPeriodKey
+}
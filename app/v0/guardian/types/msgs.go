@@ -4,7 +4,7 @@ import (
 	sdk "github.com/netcloth/netcloth-chain/types"
 )
 
-var _, _ sdk.Msg = MsgAddProfiler{}, MsgDeleteProfiler{}
+var _, _, _, _ sdk.Msg = MsgAddProfiler{}, MsgDeleteProfiler{}, MsgAddGenesisProfiler{}, MsgApproveAction{}
 
 type MsgAddProfiler struct {
 	AddGuardian
@@ -75,6 +75,79 @@ func (m MsgDeleteProfiler) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{m.DeletedBy}
 }
 
+// MsgAddGenesisProfiler - proposes a genesis profiler, approving the pending actions
+type MsgAddGenesisProfiler struct {
+	AddGuardian
+}
+
+func NewMsgAddGenesisProfiler(description string, address, addedBy sdk.AccAddress) MsgAddGenesisProfiler {
+	return MsgAddGenesisProfiler{
+		AddGuardian: AddGuardian{
+			Description: description,
+			Address:     address,
+			AddedBy:     addedBy,
+		},
+	}
+}
+
+func (m MsgAddGenesisProfiler) Route() string {
+	return RouterKey
+}
+
+func (m MsgAddGenesisProfiler) Type() string {
+	return "MsgAddGenesisProfiler"
+}
+
+func (m MsgAddGenesisProfiler) ValidateBasic() error {
+	return m.AddGuardian.ValidateBasic()
+}
+
+func (m MsgAddGenesisProfiler) GetSignBytes() []byte {
+	bz := msgCdc.MustMarshalJSON(m)
+	return sdk.MustSortJSON(bz)
+}
+
+func (m MsgAddGenesisProfiler) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{m.AddedBy}
+}
+
+// MsgApproveAction - approves a pending action by a genesis guardian
+type MsgApproveAction struct {
+	ActionID uint64         `json:"action_id"`
+	Approver sdk.AccAddress `json:"approver"`
+}
+
+func NewMsgApproveAction(actionID uint64, approver sdk.AccAddress) MsgApproveAction {
+	return MsgApproveAction{
+		ActionID: actionID,
+		Approver: approver,
+	}
+}
+
+func (m MsgApproveAction) Route() string {
+	return RouterKey
+}
+
+func (m MsgApproveAction) Type() string {
+	return "MsgApproveAction"
+}
+
+func (m MsgApproveAction) ValidateBasic() error {
+	if len(m.Approver) == 0 {
+		return ErrAddressEmpty()
+	}
+	return nil
+}
+
+func (m MsgApproveAction) GetSignBytes() []byte {
+	bz := msgCdc.MustMarshalJSON(m)
+	return sdk.MustSortJSON(bz)
+}
+
+func (m MsgApproveAction) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{m.Approver}
+}
+
 type AddGuardian struct {
 	Description string         `json:"description"`
 	Address     sdk.AccAddress `json:"address"`
@@ -1,5 +1,17 @@
 package types
 
 const (
-	QueryProfilers = "profilers"
+	QueryProfilers      = "profilers"
+	QueryPendingActions = "pending_actions"
+	QueryPendingAction  = "pending_action"
 )
+
+type QueryPendingActionParams struct {
+	ID uint64 `json:"id"`
+}
+
+func NewQueryPendingActionParams(id uint64) QueryPendingActionParams {
+	return QueryPendingActionParams{
+		ID: id,
+	}
+}
@@ -11,29 +11,59 @@ const (
 	RouterKey         = types.RouterKey
 	QuerierRoute      = types.QuerierRoute
 	DefaultParamspace = keeper.DefaultParamspace
+	DoNotModifyDesc   = types.DoNotModifyDesc
 )
 
 var (
-	NewKeeper              = keeper.NewKeeper
-	NewQuerier             = keeper.NewQuerier
-	RegisterCodec          = types.RegisterCodec
-	NewIPALNodeObject      = types.NewIPALNode
-	NewMsgIPALNodeClaim    = types.NewMsgIPALNodeClaim
-	ModuleCdc              = types.ModuleCdc
-	AttributeValueCategory = types.AttributeValueCategory
-	NewEndpoint            = types.NewEndpoint
-	ErrEmptyInputs         = types.ErrEmptyInputs
-	ErrBadDenom            = types.ErrBadDenom
-	ErrBondInsufficient    = types.ErrBondInsufficient
-	ErrMonikerExist        = types.ErrMonikerExist
-	ErrEndpointsFormat     = types.ErrEndpointsFormat
-	ErrEndpointsEmpty      = types.ErrEndpointsEmpty
-	ErrEndpointsDuplicate  = types.ErrEndpointsDuplicate
+	NewKeeper                 = keeper.NewKeeper
+	NewQuerier                = keeper.NewQuerier
+	RegisterCodec             = types.RegisterCodec
+	NewIPALNodeObject         = types.NewIPALNode
+	NewMsgIPALNodeClaim       = types.NewMsgIPALNodeClaim
+	ModuleCdc                 = types.ModuleCdc
+	AttributeValueCategory    = types.AttributeValueCategory
+	NewEndpoint               = types.NewEndpoint
+	ErrEmptyInputs            = types.ErrEmptyInputs
+	ErrBadDenom               = types.ErrBadDenom
+	ErrBondInsufficient       = types.ErrBondInsufficient
+	ErrMonikerExist           = types.ErrMonikerExist
+	ErrEndpointsFormat        = types.ErrEndpointsFormat
+	ErrEndpointsEmpty         = types.ErrEndpointsEmpty
+	ErrEndpointsDuplicate     = types.ErrEndpointsDuplicate
+	NewQueryUnBondingsParams  = types.NewQueryUnBondingsParams
+	NewMsgIPALReport          = types.NewMsgIPALReport
+	NewMsgIPALAdjudicate      = types.NewMsgIPALAdjudicate
+	NewIPALSlashProposal      = types.NewIPALSlashProposal
+	ErrIPALNodeNotFound       = types.ErrIPALNodeNotFound
+	ErrInvalidMisbehaviour    = types.ErrInvalidMisbehaviour
+	ErrReportNotFound         = types.ErrReportNotFound
+	ErrReportAdjudicated      = types.ErrReportAdjudicated
+	ErrNotAdjudicator         = types.ErrNotAdjudicator
+	ErrUnknownServiceType     = types.ErrUnknownServiceType
+	NewMsgIPALNodeUpdate      = types.NewMsgIPALNodeUpdate
+	NewMsgIPALNodeUnclaim     = types.NewMsgIPALNodeUnclaim
+	NewMsgIPALDelegate        = types.NewMsgIPALDelegate
+	NewMsgIPALUndelegate      = types.NewMsgIPALUndelegate
+	ErrDelegationNotFound     = types.ErrDelegationNotFound
+	ErrInsufficientDelegation = types.ErrInsufficientDelegation
+	ErrPendingReport          = types.ErrPendingReport
 )
 
 type (
-	Keeper           = keeper.Keeper
-	MsgIPALNodeClaim = types.MsgIPALNodeClaim
-	Endpoint         = types.Endpoint
-	Endpoints        = types.Endpoints
+	Keeper             = keeper.Keeper
+	MsgIPALNodeClaim   = types.MsgIPALNodeClaim
+	Endpoint           = types.Endpoint
+	Endpoints          = types.Endpoints
+	UnBonding          = types.UnBonding
+	UnBondings         = types.UnBondings
+	MsgIPALReport      = types.MsgIPALReport
+	MsgIPALAdjudicate  = types.MsgIPALAdjudicate
+	IPALSlashProposal  = types.IPALSlashProposal
+	MisbehaviourReport = types.MisbehaviourReport
+	MsgIPALNodeUpdate  = types.MsgIPALNodeUpdate
+	MsgIPALNodeUnclaim = types.MsgIPALNodeUnclaim
+	MsgIPALDelegate    = types.MsgIPALDelegate
+	MsgIPALUndelegate  = types.MsgIPALUndelegate
+	MsgIPALHeartbeat   = types.MsgIPALHeartbeat
+	Delegation         = types.Delegation
 )
@@ -9,4 +9,16 @@ const (
 	flagDetails               = "details"
 	flagExtension             = "extension"
 	flagBond                  = "bond"
+	flagOperator              = "operator"
+	flagMisbehaviourType      = "type"
+	flagEndpoint              = "endpoint"
+	flagEvidence              = "evidence"
+	flagStatus                = "status"
+	flagServiceType           = "service-type"
+	flagMinBond               = "min-bond"
+	flagPage                  = "page"
+	flagLimit                 = "limit"
+	flagCursor                = "cursor"
+	flagDelegator             = "delegator"
+	flagIncludeInactive       = "include-inactive"
 )
@@ -2,10 +2,13 @@ package cli
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 
+	cipaltypes "github.com/netcloth/netcloth-chain/app/v0/cipal/types"
 	"github.com/netcloth/netcloth-chain/app/v0/ipal/types"
 	"github.com/netcloth/netcloth-chain/client"
 	"github.com/netcloth/netcloth-chain/client/context"
@@ -27,6 +30,11 @@ func GetQueryCmd(queryRoute string, cdc *codec.Codec) *cobra.Command {
 		GetCmdQueryParams(queryRoute, cdc),
 		GetCmdQueryIPALNodeList(cdc),
 		GetCmdQueryIPALNode(cdc),
+		GetCmdQueryUnBondings(cdc),
+		GetCmdQueryReport(cdc),
+		GetCmdQueryReports(cdc),
+		GetCmdQueryDelegations(cdc),
+		GetCmdQueryUsers(cdc),
 	)...)
 
 	return ipalQueryCmd
@@ -59,30 +67,50 @@ $ %s query ipal params`, version.ClientName)),
 }
 
 func GetCmdQueryIPALNodeList(cdc *codec.Codec) *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "Querying commands for IPALNodes",
-		Long: strings.TrimSpace(fmt.Sprintf(`List all IPALNodes.
+		Long: strings.TrimSpace(fmt.Sprintf(`List the IPALNodes not jailed, by descending bond, optionally filtered by service type and min bond.
+The nodes flagged inactive for missing heartbeats are listed with --include-inactive only.
+The next page starts after the cursor returned with the previous one, or is given by page and limit.
 Example:
-$ %s query ipal list`, version.ClientName)),
-
+$ %s query ipal list
+$ %s query ipal list --service-type=1 --min-bond=1000000pnch --page=2 --limit=10
+$ %s query ipal list --limit=10 --cursor=<next_cursor of the previous page>`, version.ClientName, version.ClientName, version.ClientName)),
+		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
-			resKVs, _, err := cliCtx.QuerySubspace(types.IPALNodeByBondKey, types.StoreKey)
+
+			minBond, err := types.ParseMinBond(viper.GetString(flagMinBond))
 			if err != nil {
 				return err
 			}
 
-			var ipalNodes types.IPALNodes
-			if len(resKVs) > 0 {
-				for i := len(resKVs) - 1; i >= 0; i-- {
-					ipalNodes = append(ipalNodes, types.MustUnmarshalIPALNode(cdc, resKVs[i].Value))
-				}
+			filter := types.NewIPALNodeFilter(viper.GetUint64(flagServiceType), minBond, viper.GetBool(flagIncludeInactive))
+			bz, err := cdc.MarshalJSON(types.NewQueryIPALNodeListParams(filter, viper.GetInt(flagPage), viper.GetInt(flagLimit), viper.GetString(flagCursor)))
+			if err != nil {
+				return err
+			}
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryIPALNodeList), bz)
+			if err != nil {
+				return err
 			}
 
-			return cliCtx.PrintOutput(ipalNodes)
+			var result types.IPALNodeListResult
+			cdc.MustUnmarshalJSON(res, &result)
+			return cliCtx.PrintOutput(result)
 		},
 	}
+
+	cmd.Flags().Uint64(flagServiceType, 0, "service type of one of the endpoints, any if 0")
+	cmd.Flags().String(flagMinBond, "", "min bond (e.g. 1000000pnch)")
+	cmd.Flags().Int(flagPage, 1, "page of the node list, ignored with a cursor")
+	cmd.Flags().Int(flagLimit, 0, "max nodes of the page, all if 0")
+	cmd.Flags().String(flagCursor, "", "cursor returned with the previous page")
+	cmd.Flags().Bool(flagIncludeInactive, false, "list the nodes missing heartbeats too")
+
+	return cmd
 }
 
 func GetCmdQueryIPALNode(cdc *codec.Codec) *cobra.Command {
@@ -115,3 +143,193 @@ $ %s query ipal node [address]`, version.ClientName)),
 		},
 	}
 }
+
+func GetCmdQueryUnBondings(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "unbondings [address]",
+		Short: "Querying the pending unbondings of an account",
+		Long: strings.TrimSpace(fmt.Sprintf(`Query the pending unbondings of an account, with their amounts and completion times.
+Example:
+$ %s query ipal unbondings [address]`, version.ClientName)),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			addr, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			bz, err := cdc.MarshalJSON(types.NewQueryUnBondingsParams(addr))
+			if err != nil {
+				return err
+			}
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryUnBondings), bz)
+			if err != nil {
+				return err
+			}
+
+			var unBondings types.UnBondings
+			cdc.MustUnmarshalJSON(res, &unBondings)
+			return cliCtx.PrintOutput(unBondings)
+		},
+	}
+}
+
+func GetCmdQueryReport(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "report [report-id]",
+		Short: "Querying a misbehaviour report",
+		Long: strings.TrimSpace(fmt.Sprintf(`Query a misbehaviour report of an ipal node by id.
+Example:
+$ %s query ipal report 1`, version.ClientName)),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			reportID, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("report-id %s not a valid uint", args[0])
+			}
+
+			bz, err := cdc.MarshalJSON(types.NewQueryReportParams(reportID))
+			if err != nil {
+				return err
+			}
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryReport), bz)
+			if err != nil {
+				return err
+			}
+
+			var report types.MisbehaviourReport
+			cdc.MustUnmarshalJSON(res, &report)
+			return cliCtx.PrintOutput(report)
+		},
+	}
+}
+
+func GetCmdQueryReports(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "reports",
+		Short: "Querying misbehaviour reports",
+		Long: strings.TrimSpace(fmt.Sprintf(`Query the misbehaviour reports of the ipal nodes, optionally filtered by operator and status.
+Example:
+$ %s query ipal reports --operator=<operator address> --status=pending`, version.ClientName)),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			var operator sdk.AccAddress
+			if operatorStr := viper.GetString(flagOperator); operatorStr != "" {
+				addr, err := sdk.AccAddressFromBech32(operatorStr)
+				if err != nil {
+					return err
+				}
+				operator = addr
+			}
+
+			bz, err := cdc.MarshalJSON(types.NewQueryReportsParams(operator, viper.GetString(flagStatus)))
+			if err != nil {
+				return err
+			}
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryReports), bz)
+			if err != nil {
+				return err
+			}
+
+			var reports types.MisbehaviourReports
+			cdc.MustUnmarshalJSON(res, &reports)
+			return cliCtx.PrintOutput(reports)
+		},
+	}
+
+	cmd.Flags().String(flagOperator, "", "operator address of the reported ipal node")
+	cmd.Flags().String(flagStatus, "", fmt.Sprintf("report status, %s, %s or %s", types.ReportStatusPending, types.ReportStatusAccepted, types.ReportStatusRejected))
+
+	return cmd
+}
+
+func GetCmdQueryDelegations(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "delegations",
+		Short: "Querying delegations to ipal nodes",
+		Long: strings.TrimSpace(fmt.Sprintf(`Query the delegations to the ipal nodes, optionally filtered by operator and delegator.
+Example:
+$ %s query ipal delegations --operator=<operator address> --delegator=<delegator address>`, version.ClientName)),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			var operator, delegator sdk.AccAddress
+			if operatorStr := viper.GetString(flagOperator); operatorStr != "" {
+				addr, err := sdk.AccAddressFromBech32(operatorStr)
+				if err != nil {
+					return err
+				}
+				operator = addr
+			}
+			if delegatorStr := viper.GetString(flagDelegator); delegatorStr != "" {
+				addr, err := sdk.AccAddressFromBech32(delegatorStr)
+				if err != nil {
+					return err
+				}
+				delegator = addr
+			}
+
+			bz, err := cdc.MarshalJSON(types.NewQueryDelegationsParams(operator, delegator))
+			if err != nil {
+				return err
+			}
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryDelegations), bz)
+			if err != nil {
+				return err
+			}
+
+			var delegations types.Delegations
+			cdc.MustUnmarshalJSON(res, &delegations)
+			return cliCtx.PrintOutput(delegations)
+		},
+	}
+
+	cmd.Flags().String(flagOperator, "", "operator address of the delegated ipal node")
+	cmd.Flags().String(flagDelegator, "", "delegator address")
+
+	return cmd
+}
+
+func GetCmdQueryUsers(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "users [operator-address]",
+		Short: "Querying the cipal users served by an ipal node",
+		Long: strings.TrimSpace(fmt.Sprintf(`Query the cipal users bound to an ipal node, with their service types.
+Example:
+$ %s query ipal users <operator address>`, version.ClientName)),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			operator, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			bz, err := cdc.MarshalJSON(cipaltypes.NewQueryUsersParams(operator.String()))
+			if err != nil {
+				return err
+			}
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", cipaltypes.QuerierRoute, cipaltypes.QueryUsers), bz)
+			if err != nil {
+				return err
+			}
+
+			var users cipaltypes.ServedUsers
+			cdc.MustUnmarshalJSON(res, &users)
+			return cliCtx.PrintOutput(users)
+		},
+	}
+}
@@ -2,17 +2,21 @@ package cli
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
 	"github.com/netcloth/netcloth-chain/app/v0/auth"
 	"github.com/netcloth/netcloth-chain/app/v0/auth/client/utils"
+	"github.com/netcloth/netcloth-chain/app/v0/gov"
 	"github.com/netcloth/netcloth-chain/app/v0/ipal/types"
 	"github.com/netcloth/netcloth-chain/client"
 	"github.com/netcloth/netcloth-chain/client/context"
 	"github.com/netcloth/netcloth-chain/codec"
 	sdk "github.com/netcloth/netcloth-chain/types"
+	"github.com/netcloth/netcloth-chain/version"
 )
 
 func IPALCmd(cdc *codec.Codec) *cobra.Command {
@@ -22,6 +26,13 @@ func IPALCmd(cdc *codec.Codec) *cobra.Command {
 	}
 	txCmd.AddCommand(
 		IPALNodeClaimCmd(cdc),
+		IPALReportCmd(cdc),
+		IPALAdjudicateCmd(cdc),
+		IPALNodeUpdateCmd(cdc),
+		IPALNodeUnclaimCmd(cdc),
+		IPALDelegateCmd(cdc),
+		IPALUndelegateCmd(cdc),
+		IPALHeartbeatCmd(cdc),
 	)
 	return txCmd
 }
@@ -84,3 +95,288 @@ func IPALNodeClaimCmd(cdc *codec.Codec) *cobra.Command {
 
 	return cmd
 }
+
+func IPALReportCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "report",
+		Short:   "Create and sign a IPALReport tx, reporting a misbehaving endpoint of an ipal node",
+		Example: "nchcli ipal report --from=<user key name> --operator=<operator address> --type=<unavailable|tampering> --endpoint=<endpoint> --evidence=<evidence>",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			operator, err := sdk.AccAddressFromBech32(viper.GetString(flagOperator))
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgIPALReport(cliCtx.GetFromAddress(), operator, viper.GetString(flagMisbehaviourType), viper.GetString(flagEndpoint), viper.GetString(flagEvidence))
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().String(flagOperator, "", "operator address of the reported ipal node")
+	cmd.Flags().String(flagMisbehaviourType, "", fmt.Sprintf("misbehaviour type, %s or %s", types.MisbehaviourUnavailable, types.MisbehaviourTampering))
+	cmd.Flags().String(flagEndpoint, "", "the misbehaving endpoint")
+	cmd.Flags().String(flagEvidence, "", "evidence of the misbehaviour")
+
+	cmd.MarkFlagRequired(flagOperator)
+	cmd.MarkFlagRequired(flagMisbehaviourType)
+	cmd.MarkFlagRequired(flagEndpoint)
+	cmd.MarkFlagRequired(flagEvidence)
+
+	cmd = client.PostCommands(cmd)[0]
+
+	return cmd
+}
+
+func IPALAdjudicateCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "adjudicate [report-id] [accept|reject]",
+		Short:   "Create and sign a IPALAdjudicate tx, accepting or rejecting a misbehaviour report as an adjudicator",
+		Example: "nchcli ipal adjudicate 1 accept --from=<adjudicator key name>",
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			reportID, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("report-id %s not a valid uint", args[0])
+			}
+
+			var accept bool
+			switch args[1] {
+			case "accept":
+				accept = true
+			case "reject":
+			default:
+				return fmt.Errorf("decision must be accept or reject: %s", args[1])
+			}
+
+			msg := types.NewMsgIPALAdjudicate(cliCtx.GetFromAddress(), reportID, accept)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd = client.PostCommands(cmd)[0]
+
+	return cmd
+}
+
+func IPALNodeUpdateCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "update",
+		Short:   "Create and sign a IPALNodeUpdate tx, updating the description and the endpoints of an ipal node, its bond unchanged",
+		Example: "nchcli ipal update --from=<user key name> --moniker=<name> --website=<website> --endpoints=<endpoints> --details=<details>",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			var endpoints types.Endpoints
+			if endpointsStr := viper.GetString(flagEndpoints); endpointsStr != "" {
+				var err error
+				endpoints, err = types.EndpointsFromString(endpointsStr, viper.GetString(flagEndpointDelimiter), viper.GetString(flagEndpointTypeDelimiter))
+				if err != nil {
+					return err
+				}
+			}
+
+			msg := types.NewMsgIPALNodeUpdate(cliCtx.GetFromAddress(), viper.GetString(flagMoniker), viper.GetString(flagWebsite), viper.GetString(flagDetails), endpoints)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().String(flagMoniker, types.DoNotModifyDesc, "ipal node moniker")
+	cmd.Flags().String(flagWebsite, types.DoNotModifyDesc, "ipal node website")
+	cmd.Flags().String(flagEndpoints, "", "ipal node endpoints replacing the current ones, in format: serviceType|endpoint,serviceType|endpoint (e.g. 1|192.168.1.100:10000,2|192.168.1.101:20000)")
+	cmd.Flags().String(flagEndpointDelimiter, ",", "endpoints delimiter, e.g. '#' as delimiter: 1|192.168.1.100:10000#2|192.168.1.101:20000")
+	cmd.Flags().String(flagEndpointTypeDelimiter, "|", "endpoint delimiter, e.g. '-' as delimiter: 1-192.168.1.100:10000,2-192.168.1.101:20000")
+	cmd.Flags().String(flagDetails, types.DoNotModifyDesc, "ipal node details")
+
+	cmd = client.PostCommands(cmd)[0]
+
+	return cmd
+}
+
+func IPALNodeUnclaimCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "unclaim",
+		Short:   "Create and sign a IPALNodeUnclaim tx, removing the ipal node from the directory and unbonding its whole bond",
+		Example: "nchcli ipal unclaim --from=<user key name>",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			msg := types.NewMsgIPALNodeUnclaim(cliCtx.GetFromAddress())
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd = client.PostCommands(cmd)[0]
+
+	return cmd
+}
+
+func IPALDelegateCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "delegate [operator-address] [amount]",
+		Short:   "Create and sign a IPALDelegate tx, adding bond to an ipal node",
+		Example: "nchcli ipal delegate <operator address> 1000000pnch --from=<user key name>",
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			operator, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			amount, err := sdk.ParseCoin(args[1])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgIPALDelegate(cliCtx.GetFromAddress(), operator, amount)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd = client.PostCommands(cmd)[0]
+
+	return cmd
+}
+
+func IPALUndelegateCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "undelegate [operator-address] [amount]",
+		Short:   "Create and sign a IPALUndelegate tx, removing bond from an ipal node, the amount unbonding",
+		Example: "nchcli ipal undelegate <operator address> 1000000pnch --from=<user key name>",
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			operator, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			amount, err := sdk.ParseCoin(args[1])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgIPALUndelegate(cliCtx.GetFromAddress(), operator, amount)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd = client.PostCommands(cmd)[0]
+
+	return cmd
+}
+
+func IPALHeartbeatCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "heartbeat",
+		Short:   "Create and sign a IPALHeartbeat tx, signaling the ipal node is alive",
+		Example: "nchcli ipal heartbeat --from=<user key name>",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			msg := types.NewMsgIPALHeartbeat(cliCtx.GetFromAddress())
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd = client.PostCommands(cmd)[0]
+
+	return cmd
+}
+
+// GetCmdSubmitProposal implements the command to submit an ipal-slash proposal
+func GetCmdSubmitProposal(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ipal-slash [proposal-file]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Submit an ipal slash proposal",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Submit a proposal accepting a pending misbehaviour report, slashing and jailing the reported ipal node, along with an initial deposit.
+The proposal details must be supplied via a JSON file.
+
+Example:
+$ %s tx gov submit-proposal ipal-slash <path/to/proposal.json> --from=<key_or_address>
+
+Where proposal.json contains:
+
+{
+  "title": "Slash the ipal node of report 1",
+  "description": "The endpoint tampered with the messages",
+  "report_id": "1",
+  "deposit": [
+    {
+      "denom": "pnch",
+      "amount": "1000000000000"
+    }
+  ]
+}
+`,
+				version.ClientName,
+			),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			proposal, err := ParseIPALSlashProposalJSON(cdc, args[0])
+			if err != nil {
+				return err
+			}
+
+			from := cliCtx.GetFromAddress()
+			content := types.NewIPALSlashProposal(proposal.Title, proposal.Description, proposal.ReportID)
+
+			msg := gov.NewMsgSubmitProposal(content, proposal.Deposit, from)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,34 @@
+package cli
+
+import (
+	"io/ioutil"
+
+	"github.com/netcloth/netcloth-chain/codec"
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+type (
+	// IPALSlashProposalJSON defines an IPALSlashProposal with a deposit
+	IPALSlashProposalJSON struct {
+		Title       string    `json:"title" yaml:"title"`
+		Description string    `json:"description" yaml:"description"`
+		ReportID    uint64    `json:"report_id" yaml:"report_id"`
+		Deposit     sdk.Coins `json:"deposit" yaml:"deposit"`
+	}
+)
+
+// ParseIPALSlashProposalJSON reads and parses an IPALSlashProposalJSON from a file.
+func ParseIPALSlashProposalJSON(cdc *codec.Codec, proposalFile string) (IPALSlashProposalJSON, error) {
+	proposal := IPALSlashProposalJSON{}
+
+	contents, err := ioutil.ReadFile(proposalFile)
+	if err != nil {
+		return proposal, err
+	}
+
+	if err := cdc.UnmarshalJSON(contents, &proposal); err != nil {
+		return proposal, err
+	}
+
+	return proposal, nil
+}
@@ -0,0 +1,11 @@
+package client
+
+import (
+	govclient "github.com/netcloth/netcloth-chain/app/v0/gov/client"
+	"github.com/netcloth/netcloth-chain/app/v0/ipal/client/cli"
+	"github.com/netcloth/netcloth-chain/app/v0/ipal/client/rest"
+)
+
+var (
+	ProposalHandler = govclient.NewProposalHandler(cli.GetCmdSubmitProposal, rest.ProposalRESTHandler)
+)
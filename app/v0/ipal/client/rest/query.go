@@ -4,10 +4,12 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/gorilla/mux"
 
+	cipaltypes "github.com/netcloth/netcloth-chain/app/v0/cipal/types"
 	"github.com/netcloth/netcloth-chain/app/v0/ipal/types"
 	"github.com/netcloth/netcloth-chain/client/context"
 	sdk "github.com/netcloth/netcloth-chain/types"
@@ -25,6 +27,31 @@ func registerQueryRoutes(cliCtx context.CLIContext, r *mux.Router) {
 		nodeHandlerFn(cliCtx),
 	).Methods("GET")
 
+	r.HandleFunc(
+		"/ipal/unbondings/{accAddr}",
+		unBondingsHandlerFn(cliCtx),
+	).Methods("GET")
+
+	r.HandleFunc(
+		"/ipal/report/{reportID}",
+		reportHandlerFn(cliCtx),
+	).Methods("GET")
+
+	r.HandleFunc(
+		"/ipal/reports",
+		reportsHandlerFn(cliCtx),
+	).Methods("GET")
+
+	r.HandleFunc(
+		"/ipal/delegations",
+		delegationsHandlerFn(cliCtx),
+	).Methods("GET")
+
+	r.HandleFunc(
+		"/ipal/users/{accAddr}",
+		usersHandlerFn(cliCtx),
+	).Methods("GET")
+
 	r.HandleFunc(
 		"/ipal/nodes",
 		nodesHandlerFn(cliCtx),
@@ -33,25 +60,58 @@ func registerQueryRoutes(cliCtx context.CLIContext, r *mux.Router) {
 
 func listHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
-		if !ok {
+		query := r.URL.Query()
+
+		var serviceType uint64
+		if serviceTypeStr := query.Get("service_type"); serviceTypeStr != "" {
+			t, err := strconv.ParseUint(serviceTypeStr, 10, 64)
+			if err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			serviceType = t
+		}
+
+		minBond, err := types.ParseMinBond(query.Get("min_bond"))
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
 			return
 		}
 
-		resKVs, height, err := cliCtx.QuerySubspace(types.IPALNodeByBondKey, types.StoreKey)
+		page, err := parseIntQuery(r, "page", rest.DefaultPage)
 		if err != nil {
-			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		limit, err := parseIntQuery(r, "limit", 0)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
 			return
 		}
 
-		var ipalNodes types.IPALNodes
-		if len(resKVs) > 0 {
-			for i := len(resKVs) - 1; i >= 0; i-- {
-				ipalNodes = append(ipalNodes, types.MustUnmarshalIPALNode(cliCtx.Codec, resKVs[i].Value))
+		var includeInactive bool
+		if includeInactiveStr := query.Get("include_inactive"); includeInactiveStr != "" {
+			includeInactive, err = strconv.ParseBool(includeInactiveStr)
+			if err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
 			}
 		}
 
-		res, err := cliCtx.Codec.MarshalJSONIndent(ipalNodes, "", "  ")
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		params := types.NewQueryIPALNodeListParams(types.NewIPALNodeFilter(serviceType, minBond, includeInactive), page, limit, query.Get("cursor"))
+		bz, err := cliCtx.Codec.MarshalJSON(params)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		res, height, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryIPALNodeList), bz)
 		if err != nil {
 			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
 			return
@@ -62,6 +122,20 @@ func listHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
 	}
 }
 
+// parseIntQuery parses a non negative integer query parameter, defaultValue if missing
+func parseIntQuery(r *http.Request, name string, defaultValue int) (int, error) {
+	s := r.URL.Query().Get(name)
+	if s == "" {
+		return defaultValue, nil
+	}
+
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid %s: %s", name, s)
+	}
+	return n, nil
+}
+
 func queryNode(cliCtx context.CLIContext, endpoint string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		vars := mux.Vars(r)
@@ -129,6 +203,185 @@ func queryNodes(cliCtx context.CLIContext, endpoint string) http.HandlerFunc {
 	}
 }
 
+func queryUnBondings(cliCtx context.CLIContext, endpoint string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		accAddr, err := sdk.AccAddressFromBech32(vars["accAddr"])
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		bz, err := cliCtx.Codec.MarshalJSON(types.NewQueryUnBondingsParams(accAddr))
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		res, height, err := cliCtx.QueryWithData(endpoint, bz)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func queryReport(cliCtx context.CLIContext, endpoint string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		reportID, err := strconv.ParseUint(vars["reportID"], 10, 64)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		bz, err := cliCtx.Codec.MarshalJSON(types.NewQueryReportParams(reportID))
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		res, height, err := cliCtx.QueryWithData(endpoint, bz)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+// queryReports - GET /ipal/reports?operator=&status=
+func queryReports(cliCtx context.CLIContext, endpoint string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var operator sdk.AccAddress
+		if operatorStr := r.URL.Query().Get("operator"); operatorStr != "" {
+			addr, err := sdk.AccAddressFromBech32(operatorStr)
+			if err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			operator = addr
+		}
+
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		bz, err := cliCtx.Codec.MarshalJSON(types.NewQueryReportsParams(operator, r.URL.Query().Get("status")))
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		res, height, err := cliCtx.QueryWithData(endpoint, bz)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func queryDelegations(cliCtx context.CLIContext, endpoint string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var operator, delegator sdk.AccAddress
+		if operatorStr := r.URL.Query().Get("operator"); operatorStr != "" {
+			addr, err := sdk.AccAddressFromBech32(operatorStr)
+			if err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			operator = addr
+		}
+		if delegatorStr := r.URL.Query().Get("delegator"); delegatorStr != "" {
+			addr, err := sdk.AccAddressFromBech32(delegatorStr)
+			if err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			delegator = addr
+		}
+
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		bz, err := cliCtx.Codec.MarshalJSON(types.NewQueryDelegationsParams(operator, delegator))
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		res, height, err := cliCtx.QueryWithData(endpoint, bz)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func queryUsers(cliCtx context.CLIContext, endpoint string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		operator, err := sdk.AccAddressFromBech32(mux.Vars(r)["accAddr"])
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		bz, err := cliCtx.Codec.MarshalJSON(cipaltypes.NewQueryUsersParams(operator.String()))
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		res, height, err := cliCtx.QueryWithData(endpoint, bz)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func usersHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return queryUsers(cliCtx, fmt.Sprintf("custom/%s/%s", cipaltypes.QuerierRoute, cipaltypes.QueryUsers))
+}
+
+func delegationsHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return queryDelegations(cliCtx, fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryDelegations))
+}
+
 func nodeHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
 	return queryNode(cliCtx, fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryIPALNode))
 }
@@ -136,3 +389,15 @@ func nodeHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
 func nodesHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
 	return queryNodes(cliCtx, fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryIPALNodes))
 }
+
+func unBondingsHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return queryUnBondings(cliCtx, fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryUnBondings))
+}
+
+func reportHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return queryReport(cliCtx, fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryReport))
+}
+
+func reportsHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return queryReports(cliCtx, fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryReports))
+}
@@ -1,12 +1,66 @@
 package rest
 
 import (
+	"net/http"
+
 	"github.com/gorilla/mux"
 
+	"github.com/netcloth/netcloth-chain/app/v0/auth/client/utils"
+	"github.com/netcloth/netcloth-chain/app/v0/gov"
+	govrest "github.com/netcloth/netcloth-chain/app/v0/gov/client/rest"
+	"github.com/netcloth/netcloth-chain/app/v0/ipal/types"
 	"github.com/netcloth/netcloth-chain/client/context"
+	sdk "github.com/netcloth/netcloth-chain/types"
+	"github.com/netcloth/netcloth-chain/types/rest"
 )
 
 // RegisterRoutes registers the routes from the different modules for the LCD.
 func RegisterRoutes(cliCtx context.CLIContext, r *mux.Router) {
 	registerQueryRoutes(cliCtx, r)
+	registerTxRoutes(cliCtx, r)
+}
+
+type (
+	// IPALSlashProposalReq defines an ipal slash proposal request body.
+	IPALSlashProposalReq struct {
+		BaseReq rest.BaseReq `json:"base_req" yaml:"base_req"`
+
+		Title       string         `json:"title" yaml:"title"`
+		Description string         `json:"description" yaml:"description"`
+		ReportID    uint64         `json:"report_id" yaml:"report_id"`
+		Proposer    sdk.AccAddress `json:"proposer" yaml:"proposer"`
+		Deposit     sdk.Coins      `json:"deposit" yaml:"deposit"`
+	}
+)
+
+// ProposalRESTHandler returns a ProposalRESTHandler that exposes the ipal slash REST handler with a given sub-route.
+func ProposalRESTHandler(cliCtx context.CLIContext) govrest.ProposalRESTHandler {
+	return govrest.ProposalRESTHandler{
+		SubRoute: "ipal_slash",
+		Handler:  postProposalHandlerFn(cliCtx),
+	}
+}
+
+func postProposalHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req IPALSlashProposalReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		content := types.NewIPALSlashProposal(req.Title, req.Description, req.ReportID)
+
+		msg := gov.NewMsgSubmitProposal(content, req.Deposit, req.Proposer)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
 }
@@ -0,0 +1,204 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/netcloth/netcloth-chain/app/v0/auth/client/utils"
+	"github.com/netcloth/netcloth-chain/app/v0/ipal/types"
+	"github.com/netcloth/netcloth-chain/client/context"
+	sdk "github.com/netcloth/netcloth-chain/types"
+	"github.com/netcloth/netcloth-chain/types/rest"
+)
+
+func registerTxRoutes(cliCtx context.CLIContext, r *mux.Router) {
+	r.HandleFunc(
+		"/ipal/update",
+		updateHandlerFn(cliCtx),
+	).Methods("POST")
+
+	r.HandleFunc(
+		"/ipal/unclaim",
+		unclaimHandlerFn(cliCtx),
+	).Methods("POST")
+
+	r.HandleFunc(
+		"/ipal/delegate",
+		delegateHandlerFn(cliCtx),
+	).Methods("POST")
+
+	r.HandleFunc(
+		"/ipal/undelegate",
+		undelegateHandlerFn(cliCtx),
+	).Methods("POST")
+
+	r.HandleFunc(
+		"/ipal/heartbeat",
+		heartbeatHandlerFn(cliCtx),
+	).Methods("POST")
+}
+
+type (
+	// UpdateReq defines the properties of an ipal node update request's body, the fields
+	// set to [do-not-modify] and empty endpoints are not modified.
+	UpdateReq struct {
+		BaseReq   rest.BaseReq    `json:"base_req" yaml:"base_req"`
+		Moniker   string          `json:"moniker" yaml:"moniker"`
+		Website   string          `json:"website" yaml:"website"`
+		Details   string          `json:"details" yaml:"details"`
+		Endpoints types.Endpoints `json:"endpoints" yaml:"endpoints"`
+	}
+
+	// UnclaimReq defines the properties of an ipal node unclaim or heartbeat request's body.
+	UnclaimReq struct {
+		BaseReq rest.BaseReq `json:"base_req" yaml:"base_req"`
+	}
+
+	// DelegateReq defines the properties of an ipal delegate or undelegate request's body.
+	DelegateReq struct {
+		BaseReq         rest.BaseReq   `json:"base_req" yaml:"base_req"`
+		OperatorAddress sdk.AccAddress `json:"operator_address" yaml:"operator_address"`
+		Amount          sdk.Coin       `json:"amount" yaml:"amount"`
+	}
+)
+
+func updateHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req UpdateReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		operator, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgIPALNodeUpdate(operator, req.Moniker, req.Website, req.Details, req.Endpoints)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+func unclaimHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req UnclaimReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		operator, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgIPALNodeUnclaim(operator)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+func delegateHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req DelegateReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		delegator, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgIPALDelegate(delegator, req.OperatorAddress, req.Amount)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+func undelegateHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req DelegateReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		delegator, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgIPALUndelegate(delegator, req.OperatorAddress, req.Amount)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+func heartbeatHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req UnclaimReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		operator, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgIPALHeartbeat(operator)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
@@ -14,15 +14,47 @@ func InitGenesis(ctx sdk.Context, keeper keeper.Keeper, data types.GenesisState)
 		node.Bond = sdk.NewCoin(sdk.NativeTokenName, sdk.NewInt(0))
 		keeper.CreateIPALNode(ctx, node)
 	}
+
+	nextReportID := uint64(1)
+	for _, report := range data.Reports {
+		keeper.SetReport(ctx, report)
+		if report.ID >= nextReportID {
+			nextReportID = report.ID + 1
+		}
+	}
+	keeper.SetNextReportID(ctx, nextReportID)
+
+	for _, d := range data.Delegations {
+		keeper.SetDelegation(ctx, d)
+	}
 	return []abci.ValidatorUpdate{}
 }
 
 func ExportGenesis(ctx sdk.Context, keeper Keeper) types.GenesisState {
 	params := keeper.GetParams(ctx)
-	ipalNodes := keeper.GetAllIPALNodes(ctx)
+
+	var ipalNodes types.IPALNodes
+	keeper.IterateIPALNodes(ctx, func(n types.IPALNode) bool {
+		ipalNodes = append(ipalNodes, n)
+		return false
+	})
+
+	var reports types.MisbehaviourReports
+	keeper.IterateReports(ctx, func(report types.MisbehaviourReport) bool {
+		reports = append(reports, report)
+		return false
+	})
+
+	var delegations types.Delegations
+	keeper.IterateDelegations(ctx, func(d types.Delegation) bool {
+		delegations = append(delegations, d)
+		return false
+	})
 
 	return types.GenesisState{
-		Params:    params,
-		IPALNodes: ipalNodes,
+		Params:      params,
+		IPALNodes:   ipalNodes,
+		Reports:     reports,
+		Delegations: delegations,
 	}
 }
@@ -1,9 +1,14 @@
 package ipal
 
 import (
+	"fmt"
+	"strings"
+
 	abci "github.com/tendermint/tendermint/abci/types"
 
+	govtypes "github.com/netcloth/netcloth-chain/app/v0/gov/types"
 	"github.com/netcloth/netcloth-chain/app/v0/ipal/keeper"
+	"github.com/netcloth/netcloth-chain/app/v0/ipal/types"
 	sdk "github.com/netcloth/netcloth-chain/types"
 	sdkerrors "github.com/netcloth/netcloth-chain/types/errors"
 )
@@ -15,6 +20,20 @@ func NewHandler(k Keeper) sdk.Handler {
 		switch msg := msg.(type) {
 		case MsgIPALNodeClaim:
 			return handleMsgIPALNodeClaim(ctx, k, msg)
+		case MsgIPALReport:
+			return handleMsgIPALReport(ctx, k, msg)
+		case MsgIPALAdjudicate:
+			return handleMsgIPALAdjudicate(ctx, k, msg)
+		case MsgIPALNodeUpdate:
+			return handleMsgIPALNodeUpdate(ctx, k, msg)
+		case MsgIPALNodeUnclaim:
+			return handleMsgIPALNodeUnclaim(ctx, k, msg)
+		case MsgIPALDelegate:
+			return handleMsgIPALDelegate(ctx, k, msg)
+		case MsgIPALUndelegate:
+			return handleMsgIPALUndelegate(ctx, k, msg)
+		case MsgIPALHeartbeat:
+			return handleMsgIPALHeartbeat(ctx, k, msg)
 		default:
 			return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unrecognized %s message type: %T", ModuleName, msg)
 		}
@@ -49,10 +68,234 @@ func handleMsgIPALNodeClaim(ctx sdk.Context, k Keeper, m MsgIPALNodeClaim) (*sdk
 	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
 }
 
+func handleMsgIPALReport(ctx sdk.Context, k Keeper, m MsgIPALReport) (*sdk.Result, error) {
+	err := m.ValidateBasic()
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := k.SubmitReport(ctx, m)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeReportMisbehaviour,
+			sdk.NewAttribute(types.AttributeKeyReportID, fmt.Sprintf("%d", report.ID)),
+			sdk.NewAttribute(types.AttributeKeyReporter, report.Reporter.String()),
+			sdk.NewAttribute(types.AttributeKeyOperator, report.Operator.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, AttributeValueCategory),
+		),
+	})
+
+	return &sdk.Result{Data: sdk.Uint64ToBigEndian(report.ID), Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgIPALAdjudicate(ctx sdk.Context, k Keeper, m MsgIPALAdjudicate) (*sdk.Result, error) {
+	err := m.ValidateBasic()
+	if err != nil {
+		return nil, err
+	}
+
+	if !k.IsAdjudicator(ctx, m.Adjudicator) {
+		return nil, sdkerrors.Wrapf(ErrNotAdjudicator, "%s", m.Adjudicator)
+	}
+
+	err = k.AdjudicateReport(ctx, m.ReportID, m.Accept)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, AttributeValueCategory),
+		),
+	)
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgIPALNodeUpdate(ctx sdk.Context, k Keeper, m MsgIPALNodeUpdate) (*sdk.Result, error) {
+	m.TrimSpace()
+
+	err := m.ValidateBasic()
+	if err != nil {
+		return nil, err
+	}
+
+	if m.Moniker != types.DoNotModifyDesc {
+		acc, monikerExist := k.GetIPALNodeAddByMoniker(ctx, m.Moniker)
+		if monikerExist && !acc.Equals(m.OperatorAddress) {
+			return nil, sdkerrors.Wrapf(ErrMonikerExist, "moniker: [%s] already exist", m.Moniker)
+		}
+	}
+
+	node, err := k.UpdateIPALNode(ctx, m)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeUpdateIPALNode,
+			sdk.NewAttribute(types.AttributeKeyOperator, node.OperatorAddress.String()),
+			sdk.NewAttribute(types.AttributeKeyMoniker, node.Moniker),
+			sdk.NewAttribute(types.AttributeKeyEndpoints, joinEndpoints(node.Endpoints)),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, AttributeValueCategory),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+// joinEndpoints formats the endpoints as the claim command takes them: serviceType|endpoint,serviceType|endpoint
+func joinEndpoints(endpoints Endpoints) string {
+	eps := make([]string, len(endpoints))
+	for i, e := range endpoints {
+		eps[i] = fmt.Sprintf("%d|%s", e.Type, e.Endpoint)
+	}
+	return strings.Join(eps, ",")
+}
+
+func handleMsgIPALNodeUnclaim(ctx sdk.Context, k Keeper, m MsgIPALNodeUnclaim) (*sdk.Result, error) {
+	err := m.ValidateBasic()
+	if err != nil {
+		return nil, err
+	}
+
+	node, err := k.UnclaimIPALNode(ctx, m.OperatorAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeUnclaimIPALNode,
+			sdk.NewAttribute(types.AttributeKeyOperator, node.OperatorAddress.String()),
+			sdk.NewAttribute(types.AttributeKeyMoniker, node.Moniker),
+			sdk.NewAttribute(types.AttributeKeyAmount, node.Bond.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, AttributeValueCategory),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgIPALDelegate(ctx sdk.Context, k Keeper, m MsgIPALDelegate) (*sdk.Result, error) {
+	err := m.ValidateBasic()
+	if err != nil {
+		return nil, err
+	}
+
+	err = k.Delegate(ctx, m.DelegatorAddress, m.OperatorAddress, m.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeDelegate,
+			sdk.NewAttribute(types.AttributeKeyDelegator, m.DelegatorAddress.String()),
+			sdk.NewAttribute(types.AttributeKeyOperator, m.OperatorAddress.String()),
+			sdk.NewAttribute(types.AttributeKeyAmount, m.Amount.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, AttributeValueCategory),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgIPALUndelegate(ctx sdk.Context, k Keeper, m MsgIPALUndelegate) (*sdk.Result, error) {
+	err := m.ValidateBasic()
+	if err != nil {
+		return nil, err
+	}
+
+	err = k.Undelegate(ctx, m.DelegatorAddress, m.OperatorAddress, m.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeUndelegate,
+			sdk.NewAttribute(types.AttributeKeyDelegator, m.DelegatorAddress.String()),
+			sdk.NewAttribute(types.AttributeKeyOperator, m.OperatorAddress.String()),
+			sdk.NewAttribute(types.AttributeKeyAmount, m.Amount.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, AttributeValueCategory),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgIPALHeartbeat(ctx sdk.Context, k Keeper, m MsgIPALHeartbeat) (*sdk.Result, error) {
+	err := m.ValidateBasic()
+	if err != nil {
+		return nil, err
+	}
+
+	reactivated, err := k.Heartbeat(ctx, m.OperatorAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeHeartbeat,
+			sdk.NewAttribute(types.AttributeKeyOperator, m.OperatorAddress.String()),
+			sdk.NewAttribute(types.AttributeKeyReactivated, fmt.Sprintf("%t", reactivated)),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, AttributeValueCategory),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+// NewIPALSlashProposalHandler returns the handler of the ipal governance proposals
+func NewIPALSlashProposalHandler(k Keeper) govtypes.Handler {
+	return func(ctx sdk.Context, content govtypes.Content, pid uint64, proposer sdk.AccAddress) error {
+		switch c := content.(type) {
+		case IPALSlashProposal:
+			return k.AdjudicateReport(ctx, c.ReportID, true)
+
+		default:
+			return sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unrecognized ipal proposal content type: %T", c)
+		}
+	}
+}
+
+// BeginBlocker builds the indexes missing on the chains started before them
+func BeginBlocker(ctx sdk.Context, k keeper.Keeper) {
+	k.BuildMissingIndexes(ctx)
+}
+
 func EndBlocker(ctx sdk.Context, k keeper.Keeper) []abci.ValidatorUpdate {
 	matureUnstakings := k.DequeueAllMatureUnBondingQueue(ctx, ctx.BlockHeader().Time)
 	for _, matureUnstaking := range matureUnstakings {
 		k.DoUnbond(ctx, matureUnstaking)
 	}
+
+	k.InactivateIdleNodes(ctx)
 	return []abci.ValidatorUpdate{}
 }
@@ -6,6 +6,8 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/netcloth/netcloth-chain/app/v0/ipal/keeper"
+	"github.com/netcloth/netcloth-chain/app/v0/ipal/types"
 	sdk "github.com/netcloth/netcloth-chain/types"
 	sdkerrors "github.com/netcloth/netcloth-chain/types/errors"
 
@@ -23,3 +25,97 @@ func TestInvalidMsg(t *testing.T) {
 	_, _, log := sdkerrors.ABCIInfo(err, false)
 	require.True(t, strings.Contains(log, "unrecognized ipal message type"))
 }
+
+func TestMsgIPALReportAndAdjudicate(t *testing.T) {
+	ctx, k, _ := keeper.CreateTestInput(t)
+	h := NewHandler(k)
+	operator, reporter, adjudicator := keeper.Addrs[0], keeper.Addrs[1], keeper.Addrs[2]
+
+	params := k.GetParams(ctx)
+	params.Adjudicators = []sdk.AccAddress{adjudicator}
+	k.SetParams(ctx, params)
+
+	endpoints := Endpoints{NewEndpoint(1, "192.168.1.1:10000")}
+	_, err := h(ctx, NewMsgIPALNodeClaim(operator, "operator", "", "", "", endpoints, sdk.NewCoin(sdk.NativeTokenName, sdk.NewInt(sdk.NativeTokenFraction))))
+	require.Nil(t, err)
+
+	_, err = h(ctx, NewMsgIPALReport(reporter, operator, "slow", "192.168.1.1:10000", "timeout"))
+	require.True(t, ErrInvalidMisbehaviour.Is(err))
+
+	for i := 1; i <= 2; i++ {
+		res, err := h(ctx, NewMsgIPALReport(reporter, operator, types.MisbehaviourUnavailable, "192.168.1.1:10000", "timeout"))
+		require.Nil(t, err)
+		require.Equal(t, sdk.Uint64ToBigEndian(uint64(i)), res.Data)
+	}
+
+	// only the adjudicators can adjudicate
+	_, err = h(ctx, NewMsgIPALAdjudicate(reporter, 1, true))
+	require.True(t, ErrNotAdjudicator.Is(err))
+	_, err = h(ctx, NewMsgIPALAdjudicate(adjudicator, 1, false))
+	require.Nil(t, err)
+
+	// governance accepts the second report
+	proposalHandler := NewIPALSlashProposalHandler(k)
+	require.True(t, ErrReportAdjudicated.Is(proposalHandler(ctx, NewIPALSlashProposal("title", "description", 1), 1, reporter)))
+	require.Nil(t, proposalHandler(ctx, NewIPALSlashProposal("title", "description", 2), 2, reporter))
+
+	report, _ := k.GetReport(ctx, 2)
+	require.Equal(t, types.ReportStatusAccepted, report.Status)
+	require.Equal(t, 0, len(k.GetAllIPALNodes(ctx)))
+
+	// jailed nodes are exported
+	genesis := ExportGenesis(ctx, k)
+	require.Equal(t, 1, len(genesis.IPALNodes))
+	require.Equal(t, 2, len(genesis.Reports))
+}
+
+func TestMsgIPALNodeUpdateAndUnclaim(t *testing.T) {
+	ctx, k, _ := keeper.CreateTestInput(t)
+	h := NewHandler(k)
+	operator, other := keeper.Addrs[0], keeper.Addrs[1]
+	bond := sdk.NewCoin(sdk.NativeTokenName, sdk.NewInt(sdk.NativeTokenFraction))
+
+	_, err := h(ctx, NewMsgIPALNodeClaim(operator, "operator", "", "", "", Endpoints{NewEndpoint(1, "192.168.1.1:10000")}, bond))
+	require.Nil(t, err)
+	_, err = h(ctx, NewMsgIPALNodeClaim(other, "other", "", "", "", Endpoints{NewEndpoint(1, "192.168.1.1:10000")}, bond))
+	require.Nil(t, err)
+
+	// unknown node and taken moniker
+	_, err = h(ctx, NewMsgIPALNodeUpdate(keeper.Addrs[2], "new", DoNotModifyDesc, DoNotModifyDesc, nil))
+	require.True(t, ErrIPALNodeNotFound.Is(err))
+	_, err = h(ctx, NewMsgIPALNodeUpdate(operator, "other", DoNotModifyDesc, DoNotModifyDesc, nil))
+	require.True(t, ErrMonikerExist.Is(err))
+
+	// endpoints rotated, the other fields and the bond unchanged
+	endpoints := Endpoints{NewEndpoint(1, "192.168.1.2:10000")}
+	res, err := h(ctx, NewMsgIPALNodeUpdate(operator, DoNotModifyDesc, "operator.com", DoNotModifyDesc, endpoints))
+	require.Nil(t, err)
+	require.Equal(t, types.EventTypeUpdateIPALNode, res.Events[0].Type)
+	node, _ := k.GetIPALNode(ctx, operator)
+	require.Equal(t, "operator", node.Moniker)
+	require.Equal(t, "operator.com", node.Website)
+	require.Equal(t, endpoints, node.Endpoints)
+	require.Equal(t, bond, node.Bond)
+
+	// renamed
+	_, err = h(ctx, NewMsgIPALNodeUpdate(operator, "renamed", DoNotModifyDesc, DoNotModifyDesc, nil))
+	require.Nil(t, err)
+	_, found := k.GetIPALNodeAddByMoniker(ctx, "operator")
+	require.False(t, found)
+	acc, _ := k.GetIPALNodeAddByMoniker(ctx, "renamed")
+	require.Equal(t, operator, acc)
+
+	// unclaimed, the whole bond unbonding
+	res, err = h(ctx, NewMsgIPALNodeUnclaim(operator))
+	require.Nil(t, err)
+	require.Equal(t, types.EventTypeUnclaimIPALNode, res.Events[0].Type)
+	_, found = k.GetIPALNode(ctx, operator)
+	require.False(t, found)
+	require.Equal(t, 1, len(k.GetAllIPALNodes(ctx)))
+	unBondings := k.GetUnBondingsByAcc(ctx, operator)
+	require.Equal(t, 1, len(unBondings))
+	require.Equal(t, bond, unBondings[0].Amount)
+
+	_, err = h(ctx, NewMsgIPALNodeUnclaim(operator))
+	require.True(t, ErrIPALNodeNotFound.Is(err))
+}
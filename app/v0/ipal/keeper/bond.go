@@ -28,6 +28,37 @@ func (k Keeper) InsertUnBondingQueue(ctx sdk.Context, unBonding types.UnBonding,
 	s := k.GetUnBondingQueueTimeSlice(ctx, endTime)
 	s = append(s, unBonding)
 	k.SetUnBondingQueueTimeSlice(ctx, endTime, s)
+
+	k.insertUnBondingByAcc(ctx, unBonding, endTime)
+}
+
+// insertUnBondingByAcc indexes the unbonding by its account, the unbondings of an account
+// ending at the same time share the same key
+func (k Keeper) insertUnBondingByAcc(ctx sdk.Context, unBonding types.UnBonding, endTime time.Time) {
+	store := ctx.KVStore(k.storeKey)
+	key := types.GetUnBondingByAccKey(unBonding.AccountAddress, endTime)
+
+	var unBondings types.UnBondings
+	if value := store.Get(key); value != nil {
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(value, &unBondings)
+	}
+	unBondings = append(unBondings, unBonding)
+	store.Set(key, k.cdc.MustMarshalBinaryLengthPrefixed(unBondings))
+}
+
+// GetUnBondingsByAcc returns the pending unbondings of an account, ordered by end time
+func (k Keeper) GetUnBondingsByAcc(ctx sdk.Context, aa sdk.AccAddress) (unBondings types.UnBondings) {
+	store := ctx.KVStore(k.storeKey)
+	itr := sdk.KVStorePrefixIterator(store, types.GetUnBondingsByAccKey(aa))
+	defer itr.Close()
+
+	unBondings = types.UnBondings{}
+	for ; itr.Valid(); itr.Next() {
+		var s types.UnBondings
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(itr.Value(), &s)
+		unBondings = append(unBondings, s...)
+	}
+	return unBondings
 }
 
 func (k Keeper) UnBondingQueueIterator(ctx sdk.Context, endTime time.Time) sdk.Iterator {
@@ -63,6 +94,9 @@ func (k Keeper) DequeueAllMatureUnBondingQueue(ctx sdk.Context, curTime time.Tim
 
 		matureUnBondings = append(matureUnBondings, tMatureUnBondings...)
 		store.Delete(itr.Key())
+		for _, v := range tMatureUnBondings {
+			store.Delete(types.GetUnBondingByAccKey(v.AccountAddress, v.EndTime))
+		}
 	}
 
 	return matureUnBondings
@@ -72,8 +106,17 @@ func (k Keeper) DoUnbond(ctx sdk.Context, unBonding types.UnBonding) error {
 	err := k.supplyKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, unBonding.AccountAddress, sdk.NewCoins(unBonding.Amount))
 	if err != nil {
 		ctx.Logger().Error(fmt.Sprintf("DoUnbond failed, err: %s", err.Error()))
+		return err
 	}
-	return err
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeCompleteUnbonding,
+			sdk.NewAttribute(types.AttributeKeyAccount, unBonding.AccountAddress.String()),
+			sdk.NewAttribute(types.AttributeKeyAmount, unBonding.Amount.String()),
+		),
+	)
+	return nil
 }
 
 func (k Keeper) toUnbondingQueue(ctx sdk.Context, aa sdk.AccAddress, amt sdk.Coin) {
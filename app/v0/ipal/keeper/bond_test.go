@@ -0,0 +1,97 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/netcloth/netcloth-chain/app/v0/ipal/types"
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+func TestUnBondingsByAcc(t *testing.T) {
+	ctx, k, ak := CreateTestInput(t)
+	operator, other := Addrs[0], Addrs[1]
+	endpoints := types.Endpoints{types.NewEndpoint(1, "192.168.1.1:10000")}
+
+	claim := func(addr sdk.AccAddress, moniker string, bond int64) {
+		msg := types.NewMsgIPALNodeClaim(addr, moniker, "", "", "", endpoints, sdk.NewCoin(sdk.NativeTokenName, sdk.NewInt(bond).MulRaw(sdk.NativeTokenFraction)))
+		require.Nil(t, k.DoIPALNodeClaim(ctx, msg))
+	}
+
+	claim(operator, "operator", 10)
+	claim(other, "other", 10)
+	require.Equal(t, 0, len(k.GetUnBondingsByAcc(ctx, operator)))
+
+	// lower the bond, then unbond all in the next block
+	claim(operator, "operator", 6)
+	ctx = ctx.WithBlockTime(ctx.BlockTime().Add(1))
+	claim(operator, "operator", 0)
+	claim(other, "other", 0)
+
+	unBondings := k.GetUnBondingsByAcc(ctx, operator)
+	require.Equal(t, 2, len(unBondings))
+	require.Equal(t, sdk.NewInt(4).MulRaw(sdk.NativeTokenFraction), unBondings[0].Amount.Amount)
+	require.Equal(t, sdk.NewInt(6).MulRaw(sdk.NativeTokenFraction), unBondings[1].Amount.Amount)
+	require.True(t, unBondings[0].EndTime.Before(unBondings[1].EndTime))
+
+	// query
+	querier := NewQuerier(k)
+	bz, err := querier(ctx, []string{types.QueryUnBondings}, abci.RequestQuery{Data: types.ModuleCdc.MustMarshalJSON(types.NewQueryUnBondingsParams(operator))})
+	require.Nil(t, err)
+	var res types.UnBondings
+	types.ModuleCdc.MustUnmarshalJSON(bz, &res)
+	require.Equal(t, 2, len(res))
+	require.Equal(t, unBondings[1].Amount, res[1].Amount)
+
+	// the first unbonding is mature
+	ctx = ctx.WithBlockTime(unBondings[0].EndTime).WithEventManager(sdk.NewEventManager())
+	mature := k.DequeueAllMatureUnBondingQueue(ctx, ctx.BlockTime())
+	require.Equal(t, 1, len(mature))
+	require.Nil(t, k.DoUnbond(ctx, mature[0]))
+	require.Equal(t, 1, len(k.GetUnBondingsByAcc(ctx, operator)))
+	require.Equal(t, 1, len(k.GetUnBondingsByAcc(ctx, other)))
+
+	var events sdk.Events
+	for _, event := range ctx.EventManager().Events() {
+		if event.Type == types.EventTypeCompleteUnbonding {
+			events = append(events, event)
+		}
+	}
+	require.Equal(t, 1, len(events))
+	require.Equal(t, operator.String(), string(events[0].Attributes[0].Value))
+	require.Equal(t, mature[0].Amount.String(), string(events[0].Attributes[1].Value))
+
+	// all mature
+	ctx = ctx.WithBlockTime(unBondings[1].EndTime)
+	for _, ub := range k.DequeueAllMatureUnBondingQueue(ctx, ctx.BlockTime()) {
+		require.Nil(t, k.DoUnbond(ctx, ub))
+	}
+	require.Equal(t, 0, len(k.GetUnBondingsByAcc(ctx, operator)))
+	require.Equal(t, 0, len(k.GetUnBondingsByAcc(ctx, other)))
+	require.Equal(t, InitCoins, ak.GetAccount(ctx, operator).GetCoins())
+	require.Equal(t, InitCoins, ak.GetAccount(ctx, other).GetCoins())
+}
+
+func TestBuildMissingUnBondingIndex(t *testing.T) {
+	ctx, k, _ := CreateTestInput(t)
+	operator, other := Addrs[0], Addrs[1]
+	amt := sdk.NewCoin(sdk.NativeTokenName, sdk.NewInt(1))
+
+	// queued before the index by account
+	endTime := ctx.BlockTime().Add(k.GetUnbondingTime(ctx))
+	legacy := types.UnBondings{types.NewUnBonding(operator, amt, endTime), types.NewUnBonding(other, amt, endTime)}
+	k.SetUnBondingQueueTimeSlice(ctx, endTime, legacy)
+	require.Equal(t, 0, len(k.GetUnBondingsByAcc(ctx, operator)))
+
+	k.BuildMissingIndexes(ctx)
+	require.Equal(t, types.UnBondings{legacy[0]}, k.GetUnBondingsByAcc(ctx, operator))
+	require.Equal(t, types.UnBondings{legacy[1]}, k.GetUnBondingsByAcc(ctx, other))
+
+	// built once
+	k.InsertUnBondingQueue(ctx, types.NewUnBonding(operator, amt, endTime.Add(1)), endTime.Add(1))
+	k.BuildMissingIndexes(ctx)
+	require.Equal(t, 2, len(k.GetUnBondingsByAcc(ctx, operator)))
+	require.Equal(t, 1, len(k.GetUnBondingsByAcc(ctx, other)))
+}
@@ -0,0 +1,136 @@
+package keeper
+
+import (
+	"github.com/netcloth/netcloth-chain/app/v0/ipal/types"
+	sdk "github.com/netcloth/netcloth-chain/types"
+	sdkerrors "github.com/netcloth/netcloth-chain/types/errors"
+)
+
+func (k Keeper) GetDelegation(ctx sdk.Context, operator, delegator sdk.AccAddress) (d types.Delegation, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetDelegationKey(operator, delegator))
+	if bz == nil {
+		return d, false
+	}
+
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &d)
+	return d, true
+}
+
+// SetDelegation stores a delegation and indexes it by delegator
+func (k Keeper) SetDelegation(ctx sdk.Context, d types.Delegation) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetDelegationKey(d.OperatorAddress, d.DelegatorAddress), k.cdc.MustMarshalBinaryLengthPrefixed(d))
+	store.Set(types.GetDelegationByDelKey(d.DelegatorAddress, d.OperatorAddress), []byte{})
+}
+
+func (k Keeper) removeDelegation(ctx sdk.Context, d types.Delegation) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetDelegationKey(d.OperatorAddress, d.DelegatorAddress))
+	store.Delete(types.GetDelegationByDelKey(d.DelegatorAddress, d.OperatorAddress))
+}
+
+// GetNodeDelegations returns the delegations to a node
+func (k Keeper) GetNodeDelegations(ctx sdk.Context, operator sdk.AccAddress) (delegations types.Delegations) {
+	k.iterateDelegations(ctx, types.GetDelegationsKey(operator), func(d types.Delegation) bool {
+		delegations = append(delegations, d)
+		return false
+	})
+	return delegations
+}
+
+// GetDelegatorDelegations returns the delegations of a delegator
+func (k Keeper) GetDelegatorDelegations(ctx sdk.Context, delegator sdk.AccAddress) (delegations types.Delegations) {
+	store := ctx.KVStore(k.storeKey)
+	prefix := types.GetDelegationsByDelKey(delegator)
+	iterator := sdk.KVStorePrefixIterator(store, prefix)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		operator := sdk.AccAddress(iterator.Key()[len(prefix):])
+		if d, found := k.GetDelegation(ctx, operator, delegator); found {
+			delegations = append(delegations, d)
+		}
+	}
+	return delegations
+}
+
+// IterateDelegations iterates over all the delegations, by node
+func (k Keeper) IterateDelegations(ctx sdk.Context, cb func(d types.Delegation) (stop bool)) {
+	k.iterateDelegations(ctx, types.DelegationKey, cb)
+}
+
+func (k Keeper) iterateDelegations(ctx sdk.Context, prefix []byte, cb func(d types.Delegation) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, prefix)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var d types.Delegation
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &d)
+		if cb(d) {
+			break
+		}
+	}
+}
+
+// Delegate adds the amount of the delegator to the bond of a node
+func (k Keeper) Delegate(ctx sdk.Context, delegator, operator sdk.AccAddress, amt sdk.Coin) error {
+	n, found := k.GetIPALNode(ctx, operator)
+	if !found {
+		return sdkerrors.Wrapf(types.ErrIPALNodeNotFound, "%s", operator)
+	}
+
+	if err := k.bond(ctx, delegator, amt); err != nil {
+		return err
+	}
+
+	d, found := k.GetDelegation(ctx, operator, delegator)
+	if !found {
+		d = types.NewDelegation(delegator, operator, sdk.NewCoin(amt.Denom, sdk.ZeroInt()))
+	}
+	d.Amount = d.Amount.Add(amt)
+	k.SetDelegation(ctx, d)
+
+	updated := n
+	updated.DelegatedBond = amt
+	if !n.DelegatedBond.Amount.IsNil() { // not set for the nodes claimed before the delegations
+		updated.DelegatedBond = n.DelegatedBond.Add(amt)
+	}
+	k.updateIPALNode(ctx, n, updated)
+	return nil
+}
+
+// Undelegate removes the amount of the delegator from the bond of a node, the amount unbonding.
+// The delegations to a node with a pending report can't be undelegated.
+func (k Keeper) Undelegate(ctx sdk.Context, delegator, operator sdk.AccAddress, amt sdk.Coin) error {
+	d, found := k.GetDelegation(ctx, operator, delegator)
+	if !found {
+		return sdkerrors.Wrapf(types.ErrDelegationNotFound, "delegator: %s, operator: %s", delegator, operator)
+	}
+
+	if err := k.checkNoPendingReport(ctx, operator); err != nil {
+		return err
+	}
+
+	if d.Amount.IsLT(amt) {
+		return sdkerrors.Wrapf(types.ErrInsufficientDelegation, "delegation: %s, undelegation: %s", d.Amount, amt)
+	}
+
+	d.Amount = d.Amount.Sub(amt)
+	if d.Amount.IsZero() {
+		k.removeDelegation(ctx, d)
+	} else {
+		k.SetDelegation(ctx, d)
+	}
+
+	n, found := k.GetIPALNode(ctx, operator)
+	if found {
+		updated := n
+		updated.DelegatedBond = n.DelegatedBond.Sub(amt)
+		k.updateIPALNode(ctx, n, updated)
+	}
+
+	k.toUnbondingQueue(ctx, delegator, amt)
+	return nil
+}
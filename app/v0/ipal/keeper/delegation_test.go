@@ -0,0 +1,66 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/netcloth/netcloth-chain/app/v0/ipal/types"
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+func TestDelegations(t *testing.T) {
+	ctx, k, ak := CreateTestInput(t)
+	nch := func(amount int64) sdk.Coin {
+		return sdk.NewCoin(sdk.NativeTokenName, sdk.NewInt(amount).MulRaw(sdk.NativeTokenFraction))
+	}
+	operator1, operator2, delegator1, delegator2 := Addrs[0], Addrs[1], Addrs[2], Addrs[3]
+	endpoints := types.Endpoints{types.NewEndpoint(1, "192.168.1.1:10000")}
+	require.Nil(t, k.DoIPALNodeClaim(ctx, types.NewMsgIPALNodeClaim(operator1, "node1", "", "", "", endpoints, nch(100))))
+	require.Nil(t, k.DoIPALNodeClaim(ctx, types.NewMsgIPALNodeClaim(operator2, "node2", "", "", "", endpoints, nch(200))))
+
+	require.True(t, types.ErrIPALNodeNotFound.Is(k.Delegate(ctx, delegator1, delegator2, nch(1))))
+
+	// the delegations rank node1 first
+	require.Nil(t, k.Delegate(ctx, delegator1, operator1, nch(100)))
+	require.Nil(t, k.Delegate(ctx, delegator2, operator1, nch(50)))
+	require.Nil(t, k.Delegate(ctx, delegator1, operator1, nch(50)))
+	require.Equal(t, InitCoins.Sub(sdk.NewCoins(nch(150))), ak.GetAccount(ctx, delegator1).GetCoins())
+
+	node, _ := k.GetIPALNode(ctx, operator1)
+	require.Equal(t, nch(100), node.Bond)
+	require.Equal(t, nch(200), node.DelegatedBond)
+	nodes := k.GetAllIPALNodes(ctx)
+	require.Equal(t, operator1, nodes[len(nodes)-1].OperatorAddress)
+
+	d, _ := k.GetDelegation(ctx, operator1, delegator1)
+	require.Equal(t, nch(150), d.Amount)
+	require.Equal(t, 2, len(k.GetNodeDelegations(ctx, operator1)))
+	require.Equal(t, 1, len(k.GetDelegatorDelegations(ctx, delegator1)))
+
+	// undelegated through the unbonding queue
+	require.True(t, types.ErrInsufficientDelegation.Is(k.Undelegate(ctx, delegator2, operator1, nch(51))))
+	require.True(t, types.ErrDelegationNotFound.Is(k.Undelegate(ctx, delegator2, operator2, nch(1))))
+	require.Nil(t, k.Undelegate(ctx, delegator2, operator1, nch(50)))
+	_, found := k.GetDelegation(ctx, operator1, delegator2)
+	require.False(t, found)
+	require.Equal(t, 0, len(k.GetDelegatorDelegations(ctx, delegator2)))
+	require.Equal(t, nch(50), k.GetUnBondingsByAcc(ctx, delegator2)[0].Amount)
+	node, _ = k.GetIPALNode(ctx, operator1)
+	require.Equal(t, nch(150), node.DelegatedBond)
+
+	// the delegations are slashed with the node
+	require.Nil(t, k.Slash(ctx, node))
+	node, _ = k.GetIPALNode(ctx, operator1)
+	require.Equal(t, nch(99), node.Bond)
+	require.Equal(t, sdk.NewCoin(sdk.NativeTokenName, sdk.NewInt(1485).MulRaw(sdk.NativeTokenFraction/10)), node.DelegatedBond)
+	d, _ = k.GetDelegation(ctx, operator1, delegator1)
+	require.Equal(t, node.DelegatedBond, d.Amount)
+
+	// the delegations unbond with the node, once released from the jail
+	ctx = ctx.WithBlockTime(node.JailedUntil)
+	_, err := k.UnclaimIPALNode(ctx, operator1)
+	require.Nil(t, err)
+	require.Equal(t, 0, len(k.GetDelegatorDelegations(ctx, delegator1)))
+	require.Equal(t, d.Amount, k.GetUnBondingsByAcc(ctx, delegator1)[0].Amount)
+}
@@ -0,0 +1,55 @@
+package keeper
+
+import (
+	"time"
+
+	"github.com/netcloth/netcloth-chain/app/v0/ipal/types"
+	sdk "github.com/netcloth/netcloth-chain/types"
+	sdkerrors "github.com/netcloth/netcloth-chain/types/errors"
+)
+
+// Heartbeat records a heartbeat of a node, reactivating it if inactive
+func (k Keeper) Heartbeat(ctx sdk.Context, operator sdk.AccAddress) (reactivated bool, err error) {
+	n, found := k.GetIPALNode(ctx, operator)
+	if !found {
+		return false, sdkerrors.Wrapf(types.ErrIPALNodeNotFound, "%s", operator)
+	}
+
+	updated := n
+	updated.LastHeartbeat = ctx.BlockHeader().Time
+	updated.Inactive = false
+	k.updateIPALNode(ctx, n, updated)
+	return n.Inactive, nil
+}
+
+// InactivateIdleNodes flags inactive the nodes whose last heartbeat is older than the max missed heartbeats
+func (k Keeper) InactivateIdleNodes(ctx sdk.Context) {
+	cutoff := ctx.BlockHeader().Time.Add(-k.GetHeartbeatInterval(ctx) * time.Duration(k.GetMaxMissedHeartbeats(ctx)))
+
+	store := ctx.KVStore(k.storeKey)
+	iterator := store.Iterator(types.HeartbeatQueueKey, types.GetHeartbeatQueueTimeKey(cutoff))
+	var operators []sdk.AccAddress
+	for ; iterator.Valid(); iterator.Next() {
+		key := iterator.Key()
+		operators = append(operators, sdk.AccAddress(key[len(key)-sdk.AddrLen:]))
+	}
+	iterator.Close()
+
+	for _, operator := range operators {
+		n, found := k.GetIPALNode(ctx, operator)
+		if !found {
+			continue
+		}
+
+		updated := n
+		updated.Inactive = true
+		k.updateIPALNode(ctx, n, updated)
+
+		ctx.EventManager().EmitEvent(
+			sdk.NewEvent(
+				types.EventTypeInactivate,
+				sdk.NewAttribute(types.AttributeKeyOperator, operator.String()),
+			),
+		)
+	}
+}
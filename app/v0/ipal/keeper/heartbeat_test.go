@@ -0,0 +1,134 @@
+package keeper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/netcloth/netcloth-chain/app/v0/ipal/types"
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+func TestHeartbeats(t *testing.T) {
+	ctx, k, _ := CreateTestInput(t)
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	ctx = ctx.WithBlockTime(start)
+	operator1, operator2 := Addrs[0], Addrs[1]
+	endpoints := types.Endpoints{types.NewEndpoint(1, "192.168.1.1:10000")}
+	bond := sdk.NewCoin(sdk.NativeTokenName, sdk.NewInt(100).MulRaw(sdk.NativeTokenFraction))
+	require.Nil(t, k.DoIPALNodeClaim(ctx, types.NewMsgIPALNodeClaim(operator1, "node1", "", "", "", endpoints, bond)))
+	require.Nil(t, k.DoIPALNodeClaim(ctx, types.NewMsgIPALNodeClaim(operator2, "node2", "", "", "", endpoints, bond)))
+
+	_, err := k.Heartbeat(ctx, Addrs[2])
+	require.True(t, types.ErrIPALNodeNotFound.Is(err))
+
+	// node2 heartbeats, node1 misses the max missed heartbeats
+	ctx = ctx.WithBlockTime(start.Add(2 * time.Hour))
+	reactivated, err := k.Heartbeat(ctx, operator2)
+	require.Nil(t, err)
+	require.False(t, reactivated)
+
+	ctx = ctx.WithBlockTime(start.Add(3 * time.Hour))
+	k.InactivateIdleNodes(ctx)
+	node1, _ := k.GetIPALNode(ctx, operator1)
+	require.False(t, node1.Inactive)
+
+	ctx = ctx.WithBlockTime(start.Add(3*time.Hour + time.Second))
+	k.InactivateIdleNodes(ctx)
+	node1, _ = k.GetIPALNode(ctx, operator1)
+	require.True(t, node1.Inactive)
+	node2, _ := k.GetIPALNode(ctx, operator2)
+	require.False(t, node2.Inactive)
+
+	// the inactive nodes are hidden unless included
+	require.Equal(t, types.IPALNodes{node2}, k.GetAllIPALNodes(ctx))
+	nodes, _ := k.GetIPALNodesPage(ctx, types.IPALNodeFilter{}, nil, 0, 0)
+	require.Equal(t, 1, len(nodes))
+	nodes, _ = k.GetIPALNodesPage(ctx, types.NewIPALNodeFilter(0, sdk.ZeroInt(), true), nil, 0, 0)
+	require.Equal(t, 2, len(nodes))
+
+	// the claims keep the inactive flag, the next heartbeat reactivates the node
+	require.Nil(t, k.DoIPALNodeClaim(ctx, types.NewMsgIPALNodeClaim(operator1, "node1", "node1.com", "", "", endpoints, bond)))
+	node1, _ = k.GetIPALNode(ctx, operator1)
+	require.True(t, node1.Inactive)
+
+	reactivated, err = k.Heartbeat(ctx, operator1)
+	require.Nil(t, err)
+	require.True(t, reactivated)
+	require.Equal(t, 2, len(k.GetAllIPALNodes(ctx)))
+
+	ctx = ctx.WithBlockTime(start.Add(5*time.Hour + 2*time.Second))
+	k.InactivateIdleNodes(ctx)
+	node1, _ = k.GetIPALNode(ctx, operator1)
+	require.False(t, node1.Inactive)
+	node2, _ = k.GetIPALNode(ctx, operator2)
+	require.True(t, node2.Inactive)
+}
+
+func TestNodeClaimedBeforeHeartbeats(t *testing.T) {
+	ctx, k, _ := CreateTestInput(t)
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	ctx = ctx.WithBlockTime(start)
+	operator, delegator := Addrs[0], Addrs[1]
+	endpoints := types.Endpoints{types.NewEndpoint(1, "192.168.1.1:10000")}
+	bond := sdk.NewCoin(sdk.NativeTokenName, sdk.NewInt(100).MulRaw(sdk.NativeTokenFraction))
+
+	// a node which never sent a heartbeat, stored without the heartbeat queue
+	node := types.NewIPALNode(operator, "node", "", "", "", endpoints, bond)
+	k.setIPALNode(ctx, node)
+	k.setIPALNodeByBond(ctx, node)
+	k.setIPALNodeByMonikerIndex(ctx, node)
+
+	k.InactivateIdleNodes(ctx)
+	node, _ = k.GetIPALNode(ctx, operator)
+	require.False(t, node.Inactive)
+
+	// it's queued from the first time it's written, not from the zero time
+	ctx = ctx.WithBlockTime(start.Add(time.Hour))
+	require.Nil(t, k.Delegate(ctx, delegator, operator, sdk.NewCoin(sdk.NativeTokenName, sdk.NewInt(sdk.NativeTokenFraction))))
+	node, _ = k.GetIPALNode(ctx, operator)
+	require.Equal(t, ctx.BlockTime(), node.LastHeartbeat)
+
+	k.InactivateIdleNodes(ctx)
+	node, _ = k.GetIPALNode(ctx, operator)
+	require.False(t, node.Inactive)
+
+	ctx = ctx.WithBlockTime(start.Add(4*time.Hour + time.Second))
+	k.InactivateIdleNodes(ctx)
+	node, _ = k.GetIPALNode(ctx, operator)
+	require.True(t, node.Inactive)
+}
+
+func TestQueueNodesClaimedBeforeHeartbeats(t *testing.T) {
+	ctx, k, _ := CreateTestInput(t)
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	ctx = ctx.WithBlockTime(start)
+	operator := Addrs[0]
+	endpoints := types.Endpoints{types.NewEndpoint(1, "192.168.1.1:10000")}
+	bond := sdk.NewCoin(sdk.NativeTokenName, sdk.NewInt(100).MulRaw(sdk.NativeTokenFraction))
+
+	// a node stored without the heartbeat queue, and never written afterwards
+	node := types.NewIPALNode(operator, "node", "", "", "", endpoints, bond)
+	k.setIPALNode(ctx, node)
+	k.setIPALNodeByBond(ctx, node)
+	k.setIPALNodeByMonikerIndex(ctx, node)
+
+	// it's queued from the upgrade block time
+	ctx = ctx.WithBlockTime(start.Add(time.Hour))
+	k.BuildMissingIndexes(ctx)
+	node, _ = k.GetIPALNode(ctx, operator)
+	require.Equal(t, ctx.BlockTime(), node.LastHeartbeat)
+	require.Equal(t, 1, len(k.GetAllIPALNodes(ctx)))
+
+	// queued once
+	ctx = ctx.WithBlockTime(start.Add(2 * time.Hour))
+	k.BuildMissingIndexes(ctx)
+	node, _ = k.GetIPALNode(ctx, operator)
+	require.Equal(t, start.Add(time.Hour), node.LastHeartbeat)
+
+	ctx = ctx.WithBlockTime(start.Add(5*time.Hour + time.Second))
+	k.InactivateIdleNodes(ctx)
+	node, _ = k.GetIPALNode(ctx, operator)
+	require.True(t, node.Inactive)
+}
@@ -11,22 +11,24 @@ import (
 )
 
 type Keeper struct {
-	storeKey     sdk.StoreKey
-	cdc          *codec.Codec
-	supplyKeeper types.SupplyKeeper
-	paramstore   params.Subspace
+	storeKey         sdk.StoreKey
+	cdc              *codec.Codec
+	supplyKeeper     types.SupplyKeeper
+	paramstore       params.Subspace
+	feeCollectorName string // name of the FeeCollector ModuleAccount, receiving the slashed bonds
 }
 
-func NewKeeper(storeKey sdk.StoreKey, cdc *codec.Codec, supplyKeeper types.SupplyKeeper, paramstore params.Subspace) Keeper {
+func NewKeeper(storeKey sdk.StoreKey, cdc *codec.Codec, supplyKeeper types.SupplyKeeper, paramstore params.Subspace, feeCollectorName string) Keeper {
 	if addr := supplyKeeper.GetModuleAddress(types.ModuleName); addr == nil {
 		panic(fmt.Sprintf("%s module account has not been set", types.ModuleName))
 	}
 
 	return Keeper{
-		storeKey:     storeKey,
-		cdc:          cdc,
-		supplyKeeper: supplyKeeper,
-		paramstore:   paramstore.WithKeyTable(ParamKeyTable()),
+		storeKey:         storeKey,
+		cdc:              cdc,
+		supplyKeeper:     supplyKeeper,
+		paramstore:       paramstore.WithKeyTable(ParamKeyTable()),
+		feeCollectorName: feeCollectorName,
 	}
 }
 
@@ -79,13 +81,31 @@ func (k Keeper) delIPALNodeByMonikerIndex(ctx sdk.Context, moniker string) {
 	store.Delete(types.GetIPALNodeByMonikerKey(moniker))
 }
 
+// setIPALNodeHeartbeat queues the active nodes by last heartbeat, to be flagged inactive when missing heartbeats
+func (k Keeper) setIPALNodeHeartbeat(ctx sdk.Context, obj types.IPALNode) {
+	if obj.Inactive {
+		return
+	}
+
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetHeartbeatQueueKey(obj.LastHeartbeat, obj.OperatorAddress), []byte{})
+}
+
+func (k Keeper) delIPALNodeHeartbeat(ctx sdk.Context, obj types.IPALNode) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetHeartbeatQueueKey(obj.LastHeartbeat, obj.OperatorAddress))
+}
+
 func (k Keeper) CreateIPALNode(ctx sdk.Context, node types.IPALNode) {
+	node = initLastHeartbeat(ctx, node)
 	k.setIPALNode(ctx, node)
 	k.setIPALNodeByBond(ctx, node)
 	k.setIPALNodeByMonikerIndex(ctx, node)
+	k.setIPALNodeHeartbeat(ctx, node)
 }
 
 func (k Keeper) updateIPALNode(ctx sdk.Context, old types.IPALNode, new types.IPALNode) {
+	new = initLastHeartbeat(ctx, new)
 	k.setIPALNode(ctx, new)
 
 	k.delIPALNodeByBond(ctx, old)
@@ -93,12 +113,25 @@ func (k Keeper) updateIPALNode(ctx sdk.Context, old types.IPALNode, new types.IP
 
 	k.delIPALNodeByMonikerIndex(ctx, old.Moniker)
 	k.setIPALNodeByMonikerIndex(ctx, new)
+
+	k.delIPALNodeHeartbeat(ctx, old)
+	k.setIPALNodeHeartbeat(ctx, new)
+}
+
+// initLastHeartbeat sets the last heartbeat of the nodes claimed before the heartbeats to the block
+// time, so that they are given the max missed heartbeats from the first time they are written
+func initLastHeartbeat(ctx sdk.Context, node types.IPALNode) types.IPALNode {
+	if node.LastHeartbeat.IsZero() {
+		node.LastHeartbeat = ctx.BlockHeader().Time
+	}
+	return node
 }
 
 func (k Keeper) deleteIPALNode(ctx sdk.Context, obj types.IPALNode) {
 	k.delIPALNode(ctx, obj.OperatorAddress)
 	k.delIPALNodeByBond(ctx, obj)
 	k.delIPALNodeByMonikerIndex(ctx, obj.Moniker)
+	k.delIPALNodeHeartbeat(ctx, obj)
 }
 
 func (k Keeper) bond(ctx sdk.Context, aa sdk.AccAddress, amt sdk.Coin) error {
@@ -106,8 +139,23 @@ func (k Keeper) bond(ctx sdk.Context, aa sdk.AccAddress, amt sdk.Coin) error {
 }
 
 func (k Keeper) DoIPALNodeClaim(ctx sdk.Context, m types.MsgIPALNodeClaim) (err error) {
+	if err := k.GetServiceTypes(ctx).ValidateEndpoints(m.Endpoints); err != nil {
+		return err
+	}
+
 	minBond := k.GetMinBond(ctx)
 	n, found := k.GetIPALNode(ctx, m.OperatorAddress)
+	if found && (m.Bond.IsLT(n.Bond) || !m.Bond.IsGTE(minBond)) {
+		if err := k.checkNoPendingReport(ctx, m.OperatorAddress); err != nil {
+			return err
+		}
+	}
+	if found && !m.Bond.IsGTE(minBond) {
+		if err := checkNotJailed(ctx, n); err != nil {
+			return err
+		}
+	}
+
 	if found {
 		if m.Bond.IsGTE(minBond) {
 			if n.Bond.IsLT(m.Bond) {
@@ -120,10 +168,13 @@ func (k Keeper) DoIPALNodeClaim(ctx sdk.Context, m types.MsgIPALNodeClaim) (err
 			}
 
 			ipalNode := types.NewIPALNode(m.OperatorAddress, m.Moniker, m.Website, m.Details, m.Extension, m.Endpoints, m.Bond)
+			ipalNode.DelegatedBond = n.DelegatedBond
+			ipalNode.JailedUntil = n.JailedUntil
+			ipalNode.LastHeartbeat = n.LastHeartbeat
+			ipalNode.Inactive = n.Inactive
 			k.updateIPALNode(ctx, n, ipalNode)
 		} else {
-			k.toUnbondingQueue(ctx, m.OperatorAddress, n.Bond)
-			k.deleteIPALNode(ctx, n)
+			k.removeIPALNode(ctx, n)
 		}
 	} else {
 		if m.Bond.IsGTE(minBond) {
@@ -133,6 +184,7 @@ func (k Keeper) DoIPALNodeClaim(ctx sdk.Context, m types.MsgIPALNodeClaim) (err
 			}
 
 			ipalNode := types.NewIPALNode(m.OperatorAddress, m.Moniker, m.Website, m.Details, m.Extension, m.Endpoints, m.Bond)
+			ipalNode.LastHeartbeat = ctx.BlockHeader().Time
 			k.CreateIPALNode(ctx, ipalNode)
 		} else {
 			return sdkerrors.Wrapf(types.ErrBondInsufficient, "bond insufficient, min bond: %s, actual bond: %s", minBond.String(), m.Bond.String())
@@ -142,14 +194,121 @@ func (k Keeper) DoIPALNodeClaim(ctx sdk.Context, m types.MsgIPALNodeClaim) (err
 	return nil
 }
 
+// UpdateIPALNode updates the description and the endpoints of a node, its bond unchanged
+func (k Keeper) UpdateIPALNode(ctx sdk.Context, m types.MsgIPALNodeUpdate) (types.IPALNode, error) {
+	n, found := k.GetIPALNode(ctx, m.OperatorAddress)
+	if !found {
+		return n, sdkerrors.Wrapf(types.ErrIPALNodeNotFound, "%s", m.OperatorAddress)
+	}
+
+	updated := n
+	if m.Moniker != types.DoNotModifyDesc {
+		updated.Moniker = m.Moniker
+	}
+	if m.Website != types.DoNotModifyDesc {
+		updated.Website = m.Website
+	}
+	if m.Details != types.DoNotModifyDesc {
+		updated.Details = m.Details
+	}
+	if len(m.Endpoints) > 0 {
+		if err := k.GetServiceTypes(ctx).ValidateEndpoints(m.Endpoints); err != nil {
+			return n, err
+		}
+		updated.Endpoints = m.Endpoints
+	}
+
+	k.updateIPALNode(ctx, n, updated)
+	return updated, nil
+}
+
+// UnclaimIPALNode removes a node from the directory, its whole bond and its delegations unbonding.
+// A node with a pending report, or jailed, can't be unclaimed.
+func (k Keeper) UnclaimIPALNode(ctx sdk.Context, operator sdk.AccAddress) (types.IPALNode, error) {
+	n, found := k.GetIPALNode(ctx, operator)
+	if !found {
+		return n, sdkerrors.Wrapf(types.ErrIPALNodeNotFound, "%s", operator)
+	}
+
+	if err := k.checkNoPendingReport(ctx, operator); err != nil {
+		return n, err
+	}
+	if err := checkNotJailed(ctx, n); err != nil {
+		return n, err
+	}
+
+	k.removeIPALNode(ctx, n)
+	return n, nil
+}
+
+// removeIPALNode deletes a node, the bond of the operator and the delegations unbonding
+func (k Keeper) removeIPALNode(ctx sdk.Context, n types.IPALNode) {
+	k.toUnbondingQueue(ctx, n.OperatorAddress, n.Bond)
+	for _, d := range k.GetNodeDelegations(ctx, n.OperatorAddress) {
+		k.toUnbondingQueue(ctx, d.DelegatorAddress, d.Amount)
+		k.removeDelegation(ctx, d)
+	}
+	k.deleteIPALNode(ctx, n)
+}
+
+// GetAllIPALNodes returns the nodes neither jailed nor inactive, by ascending bond
 func (k Keeper) GetAllIPALNodes(ctx sdk.Context) (ipalNodes types.IPALNodes) {
+	k.IterateIPALNodes(ctx, func(n types.IPALNode) bool {
+		if !n.IsJailed(ctx.BlockHeader().Time) && !n.Inactive {
+			ipalNodes = append(ipalNodes, n)
+		}
+		return false
+	})
+	return ipalNodes
+}
+
+// IterateIPALNodes iterates over all the nodes, the jailed ones included, by ascending bond
+func (k Keeper) IterateIPALNodes(ctx sdk.Context, cb func(n types.IPALNode) (stop bool)) {
 	store := ctx.KVStore(k.storeKey)
 	iterator := sdk.KVStorePrefixIterator(store, types.IPALNodeByBondKey)
 	defer iterator.Close()
 
+	for ; iterator.Valid(); iterator.Next() {
+		if cb(types.MustUnmarshalIPALNode(k.cdc, iterator.Value())) {
+			break
+		}
+	}
+}
+
+// GetIPALNodesPage returns a page of the nodes not jailed matching the filter, by descending bond.
+// The page starts after the cursor, the key of the last node of the previous page, if any, and
+// then skips offset nodes. The returned cursor is nil if there are no more nodes.
+func (k Keeper) GetIPALNodesPage(ctx sdk.Context, filter types.IPALNodeFilter, cursor []byte, offset, limit int) (ipalNodes types.IPALNodes, next []byte) {
+	store := ctx.KVStore(k.storeKey)
+	end := sdk.PrefixEndBytes(types.IPALNodeByBondKey)
+	if len(cursor) > 0 {
+		end = cursor
+	}
+	iterator := store.ReverseIterator(types.IPALNodeByBondKey, end)
+	defer iterator.Close()
+
+	ipalNodes = types.IPALNodes{}
+	var last []byte
 	for ; iterator.Valid(); iterator.Next() {
 		n := types.MustUnmarshalIPALNode(k.cdc, iterator.Value())
+		if !filter.MinBond.IsNil() && n.TotalBond().Amount.LT(filter.MinBond) {
+			break
+		}
+		if n.IsJailed(ctx.BlockHeader().Time) || !filter.Matches(n) {
+			continue
+		}
+		if offset > 0 {
+			offset--
+			continue
+		}
+		if limit > 0 && len(ipalNodes) == limit {
+			next = last
+			break
+		}
+
 		ipalNodes = append(ipalNodes, n)
+		last = sdk.CopyBytes(iterator.Key())
 	}
-	return ipalNodes
+
+	return ipalNodes, next
 }
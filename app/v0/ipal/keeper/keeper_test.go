@@ -0,0 +1,75 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/netcloth/netcloth-chain/app/v0/ipal/types"
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+func TestGetIPALNodesPage(t *testing.T) {
+	ctx, k, _ := CreateTestInput(t)
+	nch := func(amount int64) sdk.Int { return sdk.NewInt(amount).MulRaw(sdk.NativeTokenFraction) }
+
+	// nodes 0 to 4 bonding 100 to 500 nch, the even ones chatting and the odd ones storage
+	for i := 0; i < 5; i++ {
+		endpoints := types.Endpoints{types.NewEndpoint(uint64(1+i%2), "192.168.1.1:10000")}
+		bond := sdk.NewCoin(sdk.NativeTokenName, nch(int64(100*(i+1))))
+		require.Nil(t, k.DoIPALNodeClaim(ctx, types.NewMsgIPALNodeClaim(Addrs[i], string(rune('a'+i)), "", "", "", endpoints, bond)))
+	}
+	operators := func(nodes types.IPALNodes) (addrs []sdk.AccAddress) {
+		for _, n := range nodes {
+			addrs = append(addrs, n.OperatorAddress)
+		}
+		return addrs
+	}
+
+	// all the nodes, by descending bond
+	nodes, next := k.GetIPALNodesPage(ctx, types.IPALNodeFilter{}, nil, 0, 0)
+	require.Equal(t, []sdk.AccAddress{Addrs[4], Addrs[3], Addrs[2], Addrs[1], Addrs[0]}, operators(nodes))
+	require.Nil(t, next)
+
+	// filtered
+	nodes, _ = k.GetIPALNodesPage(ctx, types.NewIPALNodeFilter(1, nch(200), false), nil, 0, 0)
+	require.Equal(t, []sdk.AccAddress{Addrs[4], Addrs[2]}, operators(nodes))
+
+	// pages by offset and by cursor
+	nodes, next = k.GetIPALNodesPage(ctx, types.IPALNodeFilter{}, nil, 2, 2)
+	require.Equal(t, []sdk.AccAddress{Addrs[2], Addrs[1]}, operators(nodes))
+	require.NotNil(t, next)
+	nodes, next = k.GetIPALNodesPage(ctx, types.IPALNodeFilter{}, next, 0, 2)
+	require.Equal(t, []sdk.AccAddress{Addrs[0]}, operators(nodes))
+	require.Nil(t, next)
+
+	// no cursor on the last full page
+	nodes, next = k.GetIPALNodesPage(ctx, types.NewIPALNodeFilter(2, sdk.ZeroInt(), false), nil, 0, 2)
+	require.Equal(t, []sdk.AccAddress{Addrs[3], Addrs[1]}, operators(nodes))
+	require.Nil(t, next)
+
+	// jailed nodes are hidden
+	node, _ := k.GetIPALNode(ctx, Addrs[4])
+	require.Nil(t, k.Slash(ctx, node))
+	nodes, _ = k.GetIPALNodesPage(ctx, types.IPALNodeFilter{}, nil, 0, 1)
+	require.Equal(t, []sdk.AccAddress{Addrs[3]}, operators(nodes))
+}
+
+func TestIPALNodeClaimServiceTypes(t *testing.T) {
+	ctx, k, _ := CreateTestInput(t)
+	bond := sdk.NewCoin(sdk.NativeTokenName, sdk.NewInt(100).MulRaw(sdk.NativeTokenFraction))
+	claim := func(endpoints types.Endpoints) error {
+		return k.DoIPALNodeClaim(ctx, types.NewMsgIPALNodeClaim(Addrs[0], "operator", "", "", "", endpoints, bond))
+	}
+
+	require.True(t, types.ErrUnknownServiceType.Is(claim(types.Endpoints{types.NewEndpoint(3, "192.168.1.1:10000")})))
+	require.True(t, types.ErrEndpointsFormat.Is(claim(types.Endpoints{types.NewEndpoint(2, "wss://192.168.1.1")})))
+
+	// registered by governance
+	params := k.GetParams(ctx)
+	params.ServiceTypes = append(params.ServiceTypes, types.NewServiceTypeInfo(3, "relay", []string{"wss"}, 2))
+	k.SetParams(ctx, params)
+	require.Nil(t, claim(types.Endpoints{types.NewEndpoint(3, "wss://192.168.1.1"), types.NewEndpoint(3, "wss://192.168.1.2")}))
+	_, found := k.GetIPALNode(ctx, Addrs[0])
+	require.True(t, found)
+}
@@ -0,0 +1,58 @@
+package keeper
+
+import (
+	"github.com/netcloth/netcloth-chain/app/v0/ipal/types"
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+// BuildMissingIndexes indexes by account, once, the unbondings queued before the index, and queues,
+// once, the nodes claimed before the heartbeats, from the block time. It iterates over the whole
+// unbonding queue and all the nodes, so it runs in the begin blocker with an infinite gas meter
+// rather than in a transaction.
+func (k Keeper) BuildMissingIndexes(ctx sdk.Context) {
+	ctx = ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+	k.indexUnBondingsByAcc(ctx)
+	k.queueHeartbeats(ctx)
+}
+
+func (k Keeper) indexUnBondingsByAcc(ctx sdk.Context) {
+	store := ctx.KVStore(k.storeKey)
+	if store.Has(types.UnBondingIndexedKey) {
+		return
+	}
+
+	iterator := sdk.KVStorePrefixIterator(store, types.UnBondingKey)
+	var unBondings types.UnBondings
+	for ; iterator.Valid(); iterator.Next() {
+		var s types.UnBondings
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &s)
+		unBondings = append(unBondings, s...)
+	}
+	iterator.Close()
+
+	for _, unBonding := range unBondings {
+		k.insertUnBondingByAcc(ctx, unBonding, unBonding.EndTime)
+	}
+	store.Set(types.UnBondingIndexedKey, []byte{})
+}
+
+func (k Keeper) queueHeartbeats(ctx sdk.Context) {
+	store := ctx.KVStore(k.storeKey)
+	if store.Has(types.HeartbeatIndexedKey) {
+		return
+	}
+
+	var nodes types.IPALNodes
+	k.IterateIPALNodes(ctx, func(n types.IPALNode) bool {
+		if n.LastHeartbeat.IsZero() {
+			nodes = append(nodes, n)
+		}
+		return false
+	})
+
+	// the last heartbeat is initialized to the block time when the node is written
+	for _, n := range nodes {
+		k.updateIPALNode(ctx, n, n)
+	}
+	store.Set(types.HeartbeatIndexedKey, []byte{})
+}
@@ -34,10 +34,67 @@ func (k Keeper) SetMinBond(ctx sdk.Context, minBond sdk.Coin) {
 	k.paramstore.Set(ctx, types.KeyMinBond, minBond)
 }
 
+// GetSlashFraction returns the slash fraction param, the default one for the chains started before the param
+func (k Keeper) GetSlashFraction(ctx sdk.Context) (res sdk.Dec) {
+	res = types.DefaultSlashFraction
+	k.paramstore.GetIfExists(ctx, types.KeySlashFraction, &res)
+	return
+}
+
+// GetJailDuration returns the jail duration param, the default one for the chains started before the param
+func (k Keeper) GetJailDuration(ctx sdk.Context) (res time.Duration) {
+	res = types.DefaultJailDuration
+	k.paramstore.GetIfExists(ctx, types.KeyJailDuration, &res)
+	return
+}
+
+// GetAdjudicators returns the adjudicator panel param, empty for the chains started before the param
+func (k Keeper) GetAdjudicators(ctx sdk.Context) (res []sdk.AccAddress) {
+	k.paramstore.GetIfExists(ctx, types.KeyAdjudicators, &res)
+	return
+}
+
+// IsAdjudicator returns whether the account is a member of the adjudicator panel
+func (k Keeper) IsAdjudicator(ctx sdk.Context, addr sdk.AccAddress) bool {
+	for _, adjudicator := range k.GetAdjudicators(ctx) {
+		if adjudicator.Equals(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetServiceTypes returns the service types param, the default ones for the chains started before the param
+func (k Keeper) GetServiceTypes(ctx sdk.Context) (res types.ServiceTypeInfos) {
+	res = types.DefaultServiceTypes()
+	k.paramstore.GetIfExists(ctx, types.KeyServiceTypes, &res)
+	return
+}
+
+// GetHeartbeatInterval returns the heartbeat interval param, the default one for the chains started before the param
+func (k Keeper) GetHeartbeatInterval(ctx sdk.Context) (res time.Duration) {
+	res = types.DefaultHeartbeatInterval
+	k.paramstore.GetIfExists(ctx, types.KeyHeartbeatInterval, &res)
+	return
+}
+
+// GetMaxMissedHeartbeats returns the max missed heartbeats param, the default one for the chains started before the param
+func (k Keeper) GetMaxMissedHeartbeats(ctx sdk.Context) (res uint64) {
+	res = types.DefaultMaxMissedHeartbeats
+	k.paramstore.GetIfExists(ctx, types.KeyMaxMissedHeartbeats, &res)
+	return
+}
+
 func (k Keeper) GetParams(ctx sdk.Context) (res types.Params) {
 	return types.NewParams(
 		k.GetUnbondingTime(ctx),
-		k.GetMinBond(ctx))
+		k.GetMinBond(ctx),
+		k.GetSlashFraction(ctx),
+		k.GetJailDuration(ctx),
+		k.GetAdjudicators(ctx),
+		k.GetServiceTypes(ctx),
+		k.GetHeartbeatInterval(ctx),
+		k.GetMaxMissedHeartbeats(ctx))
 }
 
 func (k Keeper) SetParams(ctx sdk.Context, params types.Params) {
@@ -1,6 +1,9 @@
 package keeper
 
 import (
+	"bytes"
+	"encoding/hex"
+
 	"github.com/netcloth/netcloth-chain/app/v0/ipal/types"
 	"github.com/netcloth/netcloth-chain/codec"
 	sdk "github.com/netcloth/netcloth-chain/types"
@@ -14,11 +17,19 @@ func NewQuerier(k Keeper) sdk.Querier {
 		case types.QueryParameters:
 			return queryParameters(ctx, k)
 		case types.QueryIPALNodeList:
-			return queryIPALNodeList(ctx, k)
+			return queryIPALNodeList(ctx, req, k)
 		case types.QueryIPALNode:
 			return queryIPALNode(ctx, req, k)
 		case types.QueryIPALNodes:
 			return queryIPALNodes(ctx, req, k)
+		case types.QueryUnBondings:
+			return queryUnBondings(ctx, req, k)
+		case types.QueryReport:
+			return queryReport(ctx, req, k)
+		case types.QueryReports:
+			return queryReports(ctx, req, k)
+		case types.QueryDelegations:
+			return queryDelegations(ctx, req, k)
 		default:
 			return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unknown ipal query path: %s", path[0])
 		}
@@ -35,9 +46,36 @@ func queryParameters(ctx sdk.Context, k Keeper) ([]byte, error) {
 	return res, nil
 }
 
-func queryIPALNodeList(ctx sdk.Context, k Keeper) ([]byte, error) {
-	ipalNodes := k.GetAllIPALNodes(ctx)
-	bz, err := codec.MarshalJSONIndent(types.ModuleCdc, ipalNodes)
+// queryIPALNodeList returns a page of the nodes not jailed, by descending bond
+func queryIPALNodeList(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QueryIPALNodeListParams
+	if len(req.Data) > 0 {
+		err := types.ModuleCdc.UnmarshalJSON(req.Data, &params)
+		if err != nil {
+			return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+		}
+	}
+
+	if params.Page < 0 || params.Limit < 0 {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "page and limit must not be negative")
+	}
+
+	var cursor []byte
+	if params.Cursor != "" {
+		var err error
+		cursor, err = hex.DecodeString(params.Cursor)
+		if err != nil || !bytes.HasPrefix(cursor, types.IPALNodeByBondKey) {
+			return nil, sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "invalid cursor: %s", params.Cursor)
+		}
+	}
+
+	offset := 0
+	if cursor == nil && params.Page > 1 {
+		offset = (params.Page - 1) * params.Limit
+	}
+
+	ipalNodes, next := k.GetIPALNodesPage(ctx, params.IPALNodeFilter, cursor, offset, params.Limit)
+	bz, err := codec.MarshalJSONIndent(types.ModuleCdc, types.IPALNodeListResult{Nodes: ipalNodes, NextCursor: hex.EncodeToString(next)})
 	if err != nil {
 		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
 	}
@@ -86,3 +124,94 @@ func queryIPALNodes(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, e
 	}
 	return bz, nil
 }
+
+func queryUnBondings(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QueryUnBondingsParams
+
+	err := types.ModuleCdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	unBondings := k.GetUnBondingsByAcc(ctx, params.AccAddr)
+	bz, err := codec.MarshalJSONIndent(types.ModuleCdc, unBondings)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return bz, nil
+}
+
+func queryReport(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QueryReportParams
+
+	err := types.ModuleCdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	report, found := k.GetReport(ctx, params.ReportID)
+	if !found {
+		return nil, sdkerrors.Wrapf(types.ErrReportNotFound, "report id: %d", params.ReportID)
+	}
+
+	bz, err := codec.MarshalJSONIndent(types.ModuleCdc, report)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return bz, nil
+}
+
+func queryReports(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QueryReportsParams
+
+	err := types.ModuleCdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	reports := types.MisbehaviourReports{}
+	k.IterateReports(ctx, func(report types.MisbehaviourReport) bool {
+		if (params.Operator.Empty() || params.Operator.Equals(report.Operator)) && (params.Status == "" || params.Status == report.Status) {
+			reports = append(reports, report)
+		}
+		return false
+	})
+
+	bz, err := codec.MarshalJSONIndent(types.ModuleCdc, reports)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return bz, nil
+}
+
+func queryDelegations(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QueryDelegationsParams
+
+	err := types.ModuleCdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	delegations := types.Delegations{}
+	switch {
+	case !params.Delegator.Empty():
+		for _, d := range k.GetDelegatorDelegations(ctx, params.Delegator) {
+			if params.Operator.Empty() || params.Operator.Equals(d.OperatorAddress) {
+				delegations = append(delegations, d)
+			}
+		}
+	case !params.Operator.Empty():
+		delegations = append(delegations, k.GetNodeDelegations(ctx, params.Operator)...)
+	default:
+		k.IterateDelegations(ctx, func(d types.Delegation) bool {
+			delegations = append(delegations, d)
+			return false
+		})
+	}
+
+	bz, err := codec.MarshalJSONIndent(types.ModuleCdc, delegations)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return bz, nil
+}
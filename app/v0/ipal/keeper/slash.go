@@ -0,0 +1,201 @@
+package keeper
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/netcloth/netcloth-chain/app/v0/ipal/types"
+	sdk "github.com/netcloth/netcloth-chain/types"
+	sdkerrors "github.com/netcloth/netcloth-chain/types/errors"
+)
+
+func (k Keeper) GetReport(ctx sdk.Context, id uint64) (report types.MisbehaviourReport, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	value := store.Get(types.GetReportKey(id))
+	if value == nil {
+		return report, false
+	}
+
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(value, &report)
+	return report, true
+}
+
+// SetReport sets a report, and indexes it by node while pending
+func (k Keeper) SetReport(ctx sdk.Context, report types.MisbehaviourReport) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetReportKey(report.ID), k.cdc.MustMarshalBinaryLengthPrefixed(report))
+
+	if report.IsPending() {
+		store.Set(types.GetPendingReportKey(report.Operator, report.ID), []byte{})
+	} else {
+		store.Delete(types.GetPendingReportKey(report.Operator, report.ID))
+	}
+}
+
+// HasPendingReport returns whether a node has a pending report
+func (k Keeper) HasPendingReport(ctx sdk.Context, operator sdk.AccAddress) bool {
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), types.GetPendingReportsKey(operator))
+	defer iterator.Close()
+
+	return iterator.Valid()
+}
+
+// checkNoPendingReport returns an error if a node has a pending report, its bond can't
+// decrease until the report is adjudicated, so that it can't escape the slash
+func (k Keeper) checkNoPendingReport(ctx sdk.Context, operator sdk.AccAddress) error {
+	if k.HasPendingReport(ctx, operator) {
+		return sdkerrors.Wrapf(types.ErrPendingReport, "operator: %s", operator)
+	}
+	return nil
+}
+
+// checkNotJailed returns an error if a node is jailed, it can't be removed until released,
+// so that the jail, recorded in the node only, can't be escaped by claiming the node again
+func checkNotJailed(ctx sdk.Context, n types.IPALNode) error {
+	if n.IsJailed(ctx.BlockHeader().Time) {
+		return sdkerrors.Wrapf(types.ErrIPALNodeJailed, "operator: %s, jailed until: %s", n.OperatorAddress, n.JailedUntil.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// IterateReports iterates over all the misbehaviour reports by ascending id
+func (k Keeper) IterateReports(ctx sdk.Context, cb func(report types.MisbehaviourReport) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.ReportKey)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var report types.MisbehaviourReport
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &report)
+		if cb(report) {
+			break
+		}
+	}
+}
+
+// GetNextReportID returns the id of the next misbehaviour report
+func (k Keeper) GetNextReportID(ctx sdk.Context) (id uint64) {
+	store := ctx.KVStore(k.storeKey)
+	value := store.Get(types.NextReportIDKey)
+	if value == nil {
+		return 1
+	}
+
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(value, &id)
+	return id
+}
+
+func (k Keeper) SetNextReportID(ctx sdk.Context, id uint64) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.NextReportIDKey, k.cdc.MustMarshalBinaryLengthPrefixed(id))
+}
+
+// SubmitReport records a pending report of a misbehaving endpoint of a node
+func (k Keeper) SubmitReport(ctx sdk.Context, m types.MsgIPALReport) (types.MisbehaviourReport, error) {
+	node, found := k.GetIPALNode(ctx, m.Operator)
+	if !found {
+		return types.MisbehaviourReport{}, sdkerrors.Wrapf(types.ErrIPALNodeNotFound, "operator: %s", m.Operator)
+	}
+
+	endpointFound := false
+	for _, endpoint := range node.Endpoints {
+		if endpoint.Endpoint == m.Endpoint {
+			endpointFound = true
+			break
+		}
+	}
+	if !endpointFound {
+		return types.MisbehaviourReport{}, sdkerrors.Wrapf(types.ErrInvalidMisbehaviour, "endpoint [%s] not claimed by the node", m.Endpoint)
+	}
+
+	id := k.GetNextReportID(ctx)
+	report := types.NewMisbehaviourReport(id, m.Reporter, m.Operator, m.MisbehaviourType, m.Endpoint, m.Evidence, ctx.BlockHeader().Time)
+	k.SetReport(ctx, report)
+	k.SetNextReportID(ctx, id+1)
+
+	return report, nil
+}
+
+// AdjudicateReport accepts or rejects a pending report, the reported node is slashed and jailed
+// if the report is accepted
+func (k Keeper) AdjudicateReport(ctx sdk.Context, id uint64, accept bool) error {
+	report, found := k.GetReport(ctx, id)
+	if !found {
+		return sdkerrors.Wrapf(types.ErrReportNotFound, "report id: %d", id)
+	}
+
+	if !report.IsPending() {
+		return sdkerrors.Wrapf(types.ErrReportAdjudicated, "report id: %d, status: %s", id, report.Status)
+	}
+
+	report.Status = types.ReportStatusRejected
+	if accept {
+		node, found := k.GetIPALNode(ctx, report.Operator)
+		if !found {
+			return sdkerrors.Wrapf(types.ErrIPALNodeNotFound, "operator: %s", report.Operator)
+		}
+
+		if err := k.Slash(ctx, node); err != nil {
+			return err
+		}
+		report.Status = types.ReportStatusAccepted
+	}
+	k.SetReport(ctx, report)
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeAdjudicate,
+			sdk.NewAttribute(types.AttributeKeyReportID, fmt.Sprintf("%d", report.ID)),
+			sdk.NewAttribute(types.AttributeKeyOperator, report.Operator.String()),
+			sdk.NewAttribute(types.AttributeKeyStatus, report.Status),
+		),
+	)
+	return nil
+}
+
+// Slash sends the slash fraction of the bond of a node and of its delegations to the fee collector,
+// to be distributed like the fees, and jails the node for the jail duration
+func (k Keeper) Slash(ctx sdk.Context, node types.IPALNode) error {
+	fraction := k.GetSlashFraction(ctx)
+	slashed := sdk.NewCoin(node.Bond.Denom, node.Bond.Amount.ToDec().Mul(fraction).TruncateInt())
+
+	slashedNode := node
+	slashedNode.Bond = node.Bond.Sub(slashed)
+	for _, d := range k.GetNodeDelegations(ctx, node.OperatorAddress) {
+		amt := sdk.NewCoin(d.Amount.Denom, d.Amount.Amount.ToDec().Mul(fraction).TruncateInt())
+		if !amt.IsPositive() {
+			continue
+		}
+
+		d.Amount = d.Amount.Sub(amt)
+		if d.Amount.IsZero() {
+			k.removeDelegation(ctx, d)
+		} else {
+			k.SetDelegation(ctx, d)
+		}
+		slashedNode.DelegatedBond = slashedNode.DelegatedBond.Sub(amt)
+		slashed = slashed.Add(amt)
+	}
+
+	if slashed.IsPositive() {
+		err := k.supplyKeeper.SendCoinsFromModuleToModule(ctx, types.ModuleName, k.feeCollectorName, sdk.NewCoins(slashed))
+		if err != nil {
+			return err
+		}
+	}
+
+	if jailedUntil := ctx.BlockHeader().Time.Add(k.GetJailDuration(ctx)); jailedUntil.After(node.JailedUntil) {
+		slashedNode.JailedUntil = jailedUntil
+	}
+	k.updateIPALNode(ctx, node, slashedNode)
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeSlash,
+			sdk.NewAttribute(types.AttributeKeyOperator, node.OperatorAddress.String()),
+			sdk.NewAttribute(types.AttributeKeyAmount, slashed.String()),
+			sdk.NewAttribute(types.AttributeKeyJailedUntil, slashedNode.JailedUntil.Format(time.RFC3339)),
+		),
+	)
+	return nil
+}
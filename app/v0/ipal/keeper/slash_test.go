@@ -0,0 +1,135 @@
+package keeper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/netcloth/netcloth-chain/app/v0/auth"
+	"github.com/netcloth/netcloth-chain/app/v0/ipal/types"
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+func TestReportAndSlash(t *testing.T) {
+	ctx, k, ak := CreateTestInput(t)
+	operator, reporter := Addrs[0], Addrs[1]
+	endpoints := types.Endpoints{types.NewEndpoint(1, "192.168.1.1:10000")}
+	bond := sdk.NewCoin(sdk.NativeTokenName, sdk.NewInt(100).MulRaw(sdk.NativeTokenFraction))
+	require.Nil(t, k.DoIPALNodeClaim(ctx, types.NewMsgIPALNodeClaim(operator, "operator", "", "", "", endpoints, bond)))
+
+	// reports of unknown nodes or endpoints are rejected
+	_, err := k.SubmitReport(ctx, types.NewMsgIPALReport(reporter, reporter, types.MisbehaviourUnavailable, "192.168.1.1:10000", "timeout"))
+	require.True(t, types.ErrIPALNodeNotFound.Is(err))
+	_, err = k.SubmitReport(ctx, types.NewMsgIPALReport(reporter, operator, types.MisbehaviourUnavailable, "192.168.1.2:10000", "timeout"))
+	require.True(t, types.ErrInvalidMisbehaviour.Is(err))
+
+	report1, err := k.SubmitReport(ctx, types.NewMsgIPALReport(reporter, operator, types.MisbehaviourUnavailable, "192.168.1.1:10000", "timeout"))
+	require.Nil(t, err)
+	require.Equal(t, uint64(1), report1.ID)
+	report2, err := k.SubmitReport(ctx, types.NewMsgIPALReport(reporter, operator, types.MisbehaviourTampering, "192.168.1.1:10000", "tampered"))
+	require.Nil(t, err)
+	require.Equal(t, uint64(2), report2.ID)
+
+	// rejected
+	require.Nil(t, k.AdjudicateReport(ctx, report1.ID, false))
+	report, _ := k.GetReport(ctx, report1.ID)
+	require.Equal(t, types.ReportStatusRejected, report.Status)
+	require.True(t, types.ErrReportAdjudicated.Is(k.AdjudicateReport(ctx, report1.ID, true)))
+	require.True(t, types.ErrReportNotFound.Is(k.AdjudicateReport(ctx, 3, true)))
+	node, _ := k.GetIPALNode(ctx, operator)
+	require.Equal(t, bond, node.Bond)
+
+	// accepted, the slashed bond goes to the fee collector
+	require.Nil(t, k.AdjudicateReport(ctx, report2.ID, true))
+	report, _ = k.GetReport(ctx, report2.ID)
+	require.Equal(t, types.ReportStatusAccepted, report.Status)
+
+	slashed := sdk.NewCoin(sdk.NativeTokenName, sdk.NewInt(1).MulRaw(sdk.NativeTokenFraction))
+	node, _ = k.GetIPALNode(ctx, operator)
+	require.Equal(t, bond.Sub(slashed), node.Bond)
+	require.Equal(t, ctx.BlockTime().Add(types.DefaultJailDuration), node.JailedUntil)
+	feeCollector := k.supplyKeeper.GetModuleAccount(ctx, auth.FeeCollectorName)
+	require.Equal(t, sdk.NewCoins(slashed), ak.GetAccount(ctx, feeCollector.GetAddress()).GetCoins())
+
+	// hidden while jailed, and still jailed after a new claim
+	require.Equal(t, 0, len(k.GetAllIPALNodes(ctx)))
+	require.Nil(t, k.DoIPALNodeClaim(ctx, types.NewMsgIPALNodeClaim(operator, "operator", "", "", "", endpoints, bond)))
+	require.Equal(t, 0, len(k.GetAllIPALNodes(ctx)))
+
+	ctx = ctx.WithBlockTime(ctx.BlockTime().Add(types.DefaultJailDuration + time.Second))
+	require.Equal(t, 1, len(k.GetAllIPALNodes(ctx)))
+}
+
+func TestPendingReportLocksBond(t *testing.T) {
+	ctx, k, _ := CreateTestInput(t)
+	operator, reporter, delegator := Addrs[0], Addrs[1], Addrs[2]
+	endpoints := types.Endpoints{types.NewEndpoint(1, "192.168.1.1:10000")}
+	bond := sdk.NewCoin(sdk.NativeTokenName, sdk.NewInt(100).MulRaw(sdk.NativeTokenFraction))
+	delegation := sdk.NewCoin(sdk.NativeTokenName, sdk.NewInt(10).MulRaw(sdk.NativeTokenFraction))
+	require.Nil(t, k.DoIPALNodeClaim(ctx, types.NewMsgIPALNodeClaim(operator, "operator", "", "", "", endpoints, bond)))
+	require.Nil(t, k.Delegate(ctx, delegator, operator, delegation))
+
+	report, err := k.SubmitReport(ctx, types.NewMsgIPALReport(reporter, operator, types.MisbehaviourUnavailable, "192.168.1.1:10000", "timeout"))
+	require.Nil(t, err)
+	require.True(t, k.HasPendingReport(ctx, operator))
+
+	// the bond can't decrease while the report is pending
+	_, err = k.UnclaimIPALNode(ctx, operator)
+	require.True(t, types.ErrPendingReport.Is(err))
+	lowerBond := sdk.NewCoin(sdk.NativeTokenName, sdk.NewInt(50).MulRaw(sdk.NativeTokenFraction))
+	err = k.DoIPALNodeClaim(ctx, types.NewMsgIPALNodeClaim(operator, "operator", "", "", "", endpoints, lowerBond))
+	require.True(t, types.ErrPendingReport.Is(err))
+	err = k.Undelegate(ctx, delegator, operator, delegation)
+	require.True(t, types.ErrPendingReport.Is(err))
+	require.Equal(t, 0, len(k.GetUnBondingsByAcc(ctx, operator)))
+	require.Equal(t, 0, len(k.GetUnBondingsByAcc(ctx, delegator)))
+
+	// it may still be updated with the same bond
+	require.Nil(t, k.DoIPALNodeClaim(ctx, types.NewMsgIPALNodeClaim(operator, "renamed", "", "", "", endpoints, bond)))
+
+	// once the report is adjudicated, the bond can decrease again
+	require.Nil(t, k.AdjudicateReport(ctx, report.ID, true))
+	require.False(t, k.HasPendingReport(ctx, operator))
+	node, _ := k.GetIPALNode(ctx, operator)
+	slashed := sdk.NewCoin(sdk.NativeTokenName, sdk.NewInt(1).MulRaw(sdk.NativeTokenFraction))
+	require.Equal(t, bond.Sub(slashed), node.Bond)
+
+	require.Nil(t, k.Undelegate(ctx, delegator, operator, sdk.NewCoin(sdk.NativeTokenName, sdk.NewInt(1).MulRaw(sdk.NativeTokenFraction))))
+	ctx = ctx.WithBlockTime(node.JailedUntil)
+	_, err = k.UnclaimIPALNode(ctx, operator)
+	require.Nil(t, err)
+}
+
+func TestJailedNodeCantBeRemoved(t *testing.T) {
+	ctx, k, _ := CreateTestInput(t)
+	operator := Addrs[0]
+	endpoints := types.Endpoints{types.NewEndpoint(1, "192.168.1.1:10000")}
+	bond := sdk.NewCoin(sdk.NativeTokenName, sdk.NewInt(100).MulRaw(sdk.NativeTokenFraction))
+	claim := func(bond sdk.Coin) error {
+		return k.DoIPALNodeClaim(ctx, types.NewMsgIPALNodeClaim(operator, "operator", "", "", "", endpoints, bond))
+	}
+
+	require.Nil(t, claim(bond))
+	node, _ := k.GetIPALNode(ctx, operator)
+	require.Nil(t, k.Slash(ctx, node))
+	node, _ = k.GetIPALNode(ctx, operator)
+	require.True(t, node.IsJailed(ctx.BlockTime()))
+
+	// neither unclaimed nor unbonded below the min bond while jailed, to be re-claimed unjailed
+	_, err := k.UnclaimIPALNode(ctx, operator)
+	require.True(t, types.ErrIPALNodeJailed.Is(err))
+	require.True(t, types.ErrIPALNodeJailed.Is(claim(sdk.NewCoin(sdk.NativeTokenName, sdk.NewInt(0)))))
+	require.Nil(t, claim(bond))
+	node, _ = k.GetIPALNode(ctx, operator)
+	require.True(t, node.IsJailed(ctx.BlockTime()))
+	require.Equal(t, 0, len(k.GetUnBondingsByAcc(ctx, operator)))
+
+	// once released
+	ctx = ctx.WithBlockTime(node.JailedUntil)
+	_, err = k.UnclaimIPALNode(ctx, operator)
+	require.Nil(t, err)
+	require.Nil(t, claim(bond))
+	node, _ = k.GetIPALNode(ctx, operator)
+	require.False(t, node.IsJailed(ctx.BlockTime()))
+}
@@ -0,0 +1,97 @@
+package keeper
+
+import (
+	"bytes"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/netcloth/netcloth-chain/app/v0/auth"
+	"github.com/netcloth/netcloth-chain/app/v0/bank"
+	"github.com/netcloth/netcloth-chain/app/v0/ipal/types"
+	"github.com/netcloth/netcloth-chain/app/v0/params"
+	"github.com/netcloth/netcloth-chain/app/v0/supply"
+	"github.com/netcloth/netcloth-chain/codec"
+	"github.com/netcloth/netcloth-chain/store"
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+var (
+	Addrs = createTestAddrs(100)
+
+	InitCoins = sdk.NewCoins(sdk.NewCoin(sdk.NativeTokenName, sdk.NewInt(1000).MulRaw(sdk.NativeTokenFraction)))
+)
+
+// create a codec used only for testing
+func MakeTestCodec() *codec.Codec {
+	cdc := codec.New()
+	sdk.RegisterCodec(cdc)
+	auth.RegisterCodec(cdc)
+	supply.RegisterCodec(cdc)
+	bank.RegisterCodec(cdc)
+	types.RegisterCodec(cdc)
+	codec.RegisterCrypto(cdc)
+	return cdc
+}
+
+// CreateTestInput returns a context, an ipal keeper with the default params and the
+// account keeper, each of the Addrs owns InitCoins
+func CreateTestInput(t *testing.T) (sdk.Context, Keeper, auth.AccountKeeper) {
+	keys := sdk.NewKVStoreKeys(auth.StoreKey, supply.StoreKey, params.StoreKey, types.StoreKey)
+	tkeys := sdk.NewTransientStoreKeys(params.TStoreKey)
+
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	for _, key := range keys {
+		ms.MountStoreWithDB(key, sdk.StoreTypeIAVL, db)
+	}
+	for _, key := range tkeys {
+		ms.MountStoreWithDB(key, sdk.StoreTypeTransient, db)
+	}
+	require.Nil(t, ms.LoadLatestVersion())
+
+	ctx := sdk.NewContext(ms, abci.Header{Time: time.Unix(0, 0), ChainID: "foochainid"}, false, log.NewTMLogger(os.Stdout))
+	cdc := MakeTestCodec()
+
+	paramsKeeper := params.NewKeeper(cdc, keys[params.StoreKey], tkeys[params.TStoreKey])
+	accountKeeper := auth.NewAccountKeeper(cdc, keys[auth.StoreKey], paramsKeeper.Subspace(auth.DefaultParamspace), auth.ProtoBaseAccount)
+	bankKeeper := bank.NewBaseKeeper(accountKeeper, paramsKeeper.Subspace(bank.DefaultParamspace), make(map[string]bool))
+	maccPerms := map[string][]string{
+		auth.FeeCollectorName: nil,
+		types.ModuleName:      {supply.Staking},
+	}
+	supplyKeeper := supply.NewKeeper(cdc, keys[supply.StoreKey], accountKeeper, bankKeeper, maccPerms)
+	supplyKeeper.SetSupply(ctx, supply.NewSupply(sdk.NewCoins()))
+
+	keeper := NewKeeper(keys[types.StoreKey], cdc, supplyKeeper, paramsKeeper.Subspace(DefaultParamspace), auth.FeeCollectorName)
+	keeper.SetParams(ctx, types.DefaultParams())
+
+	for _, addr := range Addrs {
+		_, err := bankKeeper.AddCoins(ctx, addr, InitCoins)
+		require.Nil(t, err)
+	}
+
+	return ctx, keeper, accountKeeper
+}
+
+func createTestAddrs(numAddrs int) []sdk.AccAddress {
+	var addresses []sdk.AccAddress
+	var buffer bytes.Buffer
+
+	// start at 100 so we can make up to 999 test addresses with valid test addresses
+	for i := 100; i < (numAddrs + 100); i++ {
+		buffer.WriteString("A58856F0FD53BF058B4909A21AEC019107BA6") //base address string
+		buffer.WriteString(strconv.Itoa(i))
+		res, _ := sdk.AccAddressFromHex(buffer.String())
+		addresses = append(addresses, res)
+		buffer.Reset()
+	}
+	return addresses
+}
@@ -93,7 +93,8 @@ func (a AppModule) NewQuerierHandler() sdk.Querier {
 	return NewQuerier(a.keeper)
 }
 
-func (a AppModule) BeginBlock(sdk.Context, abci.RequestBeginBlock) {
+func (a AppModule) BeginBlock(ctx sdk.Context, _ abci.RequestBeginBlock) {
+	BeginBlocker(ctx, a.keeper)
 }
 
 func (a AppModule) EndBlock(ctx sdk.Context, end abci.RequestEndBlock) []abci.ValidatorUpdate {
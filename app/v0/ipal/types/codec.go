@@ -7,6 +7,14 @@ import (
 // RegisterCodec - register the sdk message type
 func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(MsgIPALNodeClaim{}, "nch/IPALClaim", nil)
+	cdc.RegisterConcrete(MsgIPALReport{}, "nch/IPALReport", nil)
+	cdc.RegisterConcrete(MsgIPALAdjudicate{}, "nch/IPALAdjudicate", nil)
+	cdc.RegisterConcrete(IPALSlashProposal{}, "nch/IPALSlashProposal", nil)
+	cdc.RegisterConcrete(MsgIPALNodeUpdate{}, "nch/IPALNodeUpdate", nil)
+	cdc.RegisterConcrete(MsgIPALNodeUnclaim{}, "nch/IPALNodeUnclaim", nil)
+	cdc.RegisterConcrete(MsgIPALDelegate{}, "nch/IPALDelegate", nil)
+	cdc.RegisterConcrete(MsgIPALUndelegate{}, "nch/IPALUndelegate", nil)
+	cdc.RegisterConcrete(MsgIPALHeartbeat{}, "nch/IPALHeartbeat", nil)
 }
 
 // ModuleCdc generic sealed codec to be used throughout module
@@ -0,0 +1,38 @@
+package types
+
+import (
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+// Delegation - bond added by an account to an IPAL node, ranking the node with the bond of its operator
+type Delegation struct {
+	DelegatorAddress sdk.AccAddress `json:"delegator_address" yaml:"delegator_address"`
+	OperatorAddress  sdk.AccAddress `json:"operator_address" yaml:"operator_address"` // address of the backed IPALNode's operator
+	Amount           sdk.Coin       `json:"amount" yaml:"amount"`
+}
+
+func NewDelegation(delegator, operator sdk.AccAddress, amount sdk.Coin) Delegation {
+	return Delegation{
+		DelegatorAddress: delegator,
+		OperatorAddress:  operator,
+		Amount:           amount,
+	}
+}
+
+func (d Delegation) String() string {
+	out, _ := yaml.Marshal(d)
+	return string(out)
+}
+
+type Delegations []Delegation
+
+func (v Delegations) String() (out string) {
+	for _, val := range v {
+		out += val.String() + "\n"
+	}
+	return strings.TrimSpace(out)
+}
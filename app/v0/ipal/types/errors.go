@@ -5,38 +5,33 @@ import (
 )
 
 var (
-	ErrEmptyInputs        = sdkerrors.New(ModuleName, 1, "input empty")
-	ErrBadDenom           = sdkerrors.New(ModuleName, 2, "bad denom")
-	ErrBondInsufficient   = sdkerrors.New(ModuleName, 3, "bond insufficient")
-	ErrMonikerExist       = sdkerrors.New(ModuleName, 4, "moniker exists")
-	ErrEndpointsEmpty     = sdkerrors.New(ModuleName, 6, "no endpoints")
-	ErrEndpointsDuplicate = sdkerrors.New(ModuleName, 7, "endpoints duplicate")
-	ErrEndpointsFormat    = sdkerrors.New(ModuleName, 8, "endpoints format error")
+	ErrEmptyInputs            = sdkerrors.New(ModuleName, 1, "input empty")
+	ErrBadDenom               = sdkerrors.New(ModuleName, 2, "bad denom")
+	ErrBondInsufficient       = sdkerrors.New(ModuleName, 3, "bond insufficient")
+	ErrMonikerExist           = sdkerrors.New(ModuleName, 4, "moniker exists")
+	ErrEndpointsEmpty         = sdkerrors.New(ModuleName, 6, "no endpoints")
+	ErrEndpointsDuplicate     = sdkerrors.New(ModuleName, 7, "endpoints duplicate")
+	ErrEndpointsFormat        = sdkerrors.New(ModuleName, 8, "endpoints format error")
+	ErrIPALNodeNotFound       = sdkerrors.New(ModuleName, 9, "ipal node not found")
+	ErrInvalidMisbehaviour    = sdkerrors.New(ModuleName, 10, "invalid misbehaviour")
+	ErrReportNotFound         = sdkerrors.New(ModuleName, 11, "misbehaviour report not found")
+	ErrReportAdjudicated      = sdkerrors.New(ModuleName, 12, "misbehaviour report already adjudicated")
+	ErrNotAdjudicator         = sdkerrors.New(ModuleName, 13, "not an adjudicator")
+	ErrUnknownServiceType     = sdkerrors.New(ModuleName, 14, "unknown service type")
+	ErrDelegationNotFound     = sdkerrors.New(ModuleName, 15, "delegation not found")
+	ErrInsufficientDelegation = sdkerrors.New(ModuleName, 16, "insufficient delegation")
+	ErrPendingReport          = sdkerrors.New(ModuleName, 17, "pending misbehaviour report")
+	ErrIPALNodeJailed         = sdkerrors.New(ModuleName, 18, "ipal node jailed")
 )
 
-type EndpointDuplicateErrDetector struct {
-	V map[int]int
-}
-
-func (d *EndpointDuplicateErrDetector) detecte(t int) error {
-	d.V[t]++
-
-	if d.V[t] > 1 {
-		return sdkerrors.Wrapf(ErrEndpointsDuplicate, "endpoint type: [%d] is duplicate", t)
-	}
-
-	return nil
-}
-
+// EndpointsDupCheck checks an endpoint is not given twice with the same type
 func EndpointsDupCheck(eps Endpoints) error {
-	d := EndpointDuplicateErrDetector{
-		V: make(map[int]int),
-	}
-
+	seen := make(map[Endpoint]bool)
 	for _, v := range eps {
-		if e := d.detecte(int(v.Type)); e != nil {
-			return e
+		if seen[v] {
+			return sdkerrors.Wrapf(ErrEndpointsDuplicate, "endpoint: [%d|%s] is duplicate", v.Type, v.Endpoint)
 		}
+		seen[v] = true
 	}
 
 	return nil
@@ -1,5 +1,30 @@
 package types
 
+const (
+	EventTypeCompleteUnbonding  = "complete_unbonding"
+	EventTypeReportMisbehaviour = "report_misbehaviour"
+	EventTypeAdjudicate         = "adjudicate"
+	EventTypeSlash              = "slash"
+	EventTypeUpdateIPALNode     = "update_ipal_node"
+	EventTypeUnclaimIPALNode    = "unclaim_ipal_node"
+	EventTypeDelegate           = "delegate"
+	EventTypeUndelegate         = "undelegate"
+	EventTypeHeartbeat          = "ipal_heartbeat"
+	EventTypeInactivate         = "inactivate_ipal_node"
+
+	AttributeKeyAccount     = "account"
+	AttributeKeyAmount      = "amount"
+	AttributeKeyReportID    = "report_id"
+	AttributeKeyReporter    = "reporter"
+	AttributeKeyOperator    = "operator"
+	AttributeKeyStatus      = "status"
+	AttributeKeyJailedUntil = "jailed_until"
+	AttributeKeyMoniker     = "moniker"
+	AttributeKeyEndpoints   = "endpoints"
+	AttributeKeyDelegator   = "delegator"
+	AttributeKeyReactivated = "reactivated"
+)
+
 var (
 	AttributeValueCategory = ModuleName
 )
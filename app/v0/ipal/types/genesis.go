@@ -1,8 +1,10 @@
 package types
 
 type GenesisState struct {
-	Params    Params    `json:"params" yaml:"params"`
-	IPALNodes IPALNodes `json:"ipal_nodes" yaml:"ipal_nodes"`
+	Params      Params              `json:"params" yaml:"params"`
+	IPALNodes   IPALNodes           `json:"ipal_nodes" yaml:"ipal_nodes"`
+	Reports     MisbehaviourReports `json:"reports,omitempty" yaml:"reports"`
+	Delegations Delegations         `json:"delegations,omitempty" yaml:"delegations"`
 }
 
 func DefaultGenesisState() GenesisState {
@@ -3,6 +3,7 @@ package types
 import (
 	"strconv"
 	"strings"
+	"time"
 
 	"gopkg.in/yaml.v2"
 
@@ -11,13 +12,6 @@ import (
 	sdkerrors "github.com/netcloth/netcloth-chain/types/errors"
 )
 
-type ServiceType uint64
-
-const (
-	Chatting ServiceType = 1
-	Storage
-)
-
 func EndpointsFromString(endpointsStr, endpointDelimiter, endpointTypeDelimiter string) (endpoints Endpoints, e error) {
 	endpointStrings := strings.Split(endpointsStr, endpointDelimiter)
 	for _, endpointString := range endpointStrings {
@@ -47,7 +41,7 @@ func EndpointsFromString(endpointsStr, endpointDelimiter, endpointTypeDelimiter
 		}
 	}
 
-	if err := EndpointsDupCheck(endpoints); err != nil {
+	if err := ValidateEndpoints(endpoints); err != nil {
 		return nil, err
 	}
 
@@ -62,6 +56,10 @@ type IPALNode struct {
 	Extension       string         `json:"extension" yaml:"extension"`
 	Endpoints       Endpoints      `json:"endpoints" yaml:"endpoints"`
 	Bond            sdk.Coin       `json:"bond" yaml:"bond"`
+	DelegatedBond   sdk.Coin       `json:"delegated_bond" yaml:"delegated_bond"` // sum of the delegations to the node
+	JailedUntil     time.Time      `json:"jailed_until" yaml:"jailed_until"`     // the node is hidden from the node list until then
+	LastHeartbeat   time.Time      `json:"last_heartbeat" yaml:"last_heartbeat"`
+	Inactive        bool           `json:"inactive" yaml:"inactive"` // missed too many heartbeats, hidden from the node list until the next one
 }
 
 type IPALNodes []IPALNode
@@ -73,6 +71,70 @@ func (v IPALNodes) String() (out string) {
 	return strings.TrimSpace(out)
 }
 
+// TotalBond returns the bond of the operator and the delegations, ranking the node
+func (obj IPALNode) TotalBond() sdk.Coin {
+	if obj.DelegatedBond.Amount.IsNil() {
+		return obj.Bond
+	}
+	return obj.Bond.Add(obj.DelegatedBond)
+}
+
+// IsJailed returns whether the node is jailed at the time
+func (obj IPALNode) IsJailed(now time.Time) bool {
+	return now.Before(obj.JailedUntil)
+}
+
+// IPALNodeFilter - filters of the node list, ignored if empty
+type IPALNodeFilter struct {
+	ServiceType uint64  `json:"service_type"` // a node matches if one of its endpoints has the service type
+	MinBond     sdk.Int `json:"min_bond"`     // min total bond, the delegations included
+
+	IncludeInactive bool `json:"include_inactive"` // the inactive nodes are hidden unless true
+}
+
+func NewIPALNodeFilter(serviceType uint64, minBond sdk.Int, includeInactive bool) IPALNodeFilter {
+	return IPALNodeFilter{
+		ServiceType:     serviceType,
+		MinBond:         minBond,
+		IncludeInactive: includeInactive,
+	}
+}
+
+// ParseMinBond parses a min bond filter coin, the zero value if empty
+func ParseMinBond(minBondStr string) (sdk.Int, error) {
+	if minBondStr == "" {
+		return sdk.ZeroInt(), nil
+	}
+
+	minBond, err := sdk.ParseCoin(minBondStr)
+	if err != nil {
+		return sdk.Int{}, err
+	}
+	if minBond.Denom != sdk.NativeTokenName {
+		return sdk.Int{}, sdkerrors.Wrapf(ErrBadDenom, "min bond denom must be %s", sdk.NativeTokenName)
+	}
+	return minBond.Amount, nil
+}
+
+// Matches returns whether the node matches the filter
+func (f IPALNodeFilter) Matches(obj IPALNode) bool {
+	if !f.MinBond.IsNil() && obj.TotalBond().Amount.LT(f.MinBond) {
+		return false
+	}
+	if obj.Inactive && !f.IncludeInactive {
+		return false
+	}
+	if f.ServiceType == 0 {
+		return true
+	}
+	for _, endpoint := range obj.Endpoints {
+		if endpoint.Type == f.ServiceType {
+			return true
+		}
+	}
+	return false
+}
+
 func NewIPALNode(operator sdk.AccAddress, moniker, website, details, extension string, endpoints Endpoints, amount sdk.Coin) IPALNode {
 	return IPALNode{
 		OperatorAddress: operator,
@@ -82,6 +144,7 @@ func NewIPALNode(operator sdk.AccAddress, moniker, website, details, extension s
 		Extension:       extension,
 		Endpoints:       endpoints,
 		Bond:            amount,
+		DelegatedBond:   sdk.NewCoin(amount.Denom, sdk.ZeroInt()),
 	}
 }
 
@@ -94,6 +157,10 @@ func (obj IPALNode) MarshalYAML() (interface{}, error) {
 		Details         string
 		Extension       string
 		Bond            sdk.Coin
+		DelegatedBond   sdk.Coin
+		JailedUntil     time.Time
+		LastHeartbeat   time.Time
+		Inactive        bool
 	}{
 		OperatorAddress: obj.OperatorAddress,
 		Moniker:         obj.Moniker,
@@ -102,6 +169,10 @@ func (obj IPALNode) MarshalYAML() (interface{}, error) {
 		Details:         obj.Details,
 		Extension:       obj.Extension,
 		Bond:            obj.Bond,
+		DelegatedBond:   obj.DelegatedBond,
+		JailedUntil:     obj.JailedUntil,
+		LastHeartbeat:   obj.LastHeartbeat,
+		Inactive:        obj.Inactive,
 	})
 
 	if err != nil {
@@ -20,14 +20,24 @@ var (
 	IPALNodeByBondKey    = []byte{0x11}
 	IPALNodeByMonikerKey = []byte{0x12}
 	UnBondingKey         = []byte{0x13}
+	UnBondingByAccKey    = []byte{0x14}
+	ReportKey            = []byte{0x15}
+	NextReportIDKey      = []byte{0x16}
+	DelegationKey        = []byte{0x17}
+	DelegationByDelKey   = []byte{0x18}
+	HeartbeatQueueKey    = []byte{0x19}
+	PendingReportKey     = []byte{0x1a}
+	UnBondingIndexedKey  = []byte{0x1b} // set once the unbondings queued before the index by account are indexed
+	HeartbeatIndexedKey  = []byte{0x1c} // set once the nodes claimed before the heartbeats are queued
 )
 
 func GetIPALNodeKey(addr sdk.AccAddress) []byte {
 	return append(IPALNodeKey, addr...)
 }
 
+// GetIPALNodeByBondKey returns the key of a node ranked by its total bond, the delegations included
 func GetIPALNodeByBondKey(obj IPALNode) []byte {
-	bond := obj.Bond.Amount.Int64()
+	bond := obj.TotalBond().Amount.Int64()
 	bondBytes := make([]byte, 8)
 	binary.BigEndian.PutUint64(bondBytes[:], uint64(bond))
 
@@ -54,3 +64,58 @@ func GetUnBondingKey(timestamp time.Time) []byte {
 	v := sdk.FormatTimeBytes(timestamp)
 	return append(UnBondingKey, v...)
 }
+
+// GetUnBondingByAccKey returns the key of the unbondings of an account ending at timestamp
+func GetUnBondingByAccKey(addr sdk.AccAddress, timestamp time.Time) []byte {
+	return append(GetUnBondingsByAccKey(addr), sdk.FormatTimeBytes(timestamp)...)
+}
+
+// GetUnBondingsByAccKey returns the prefix of the unbondings of an account
+func GetUnBondingsByAccKey(addr sdk.AccAddress) []byte {
+	return append(UnBondingByAccKey, addr...)
+}
+
+// GetReportKey returns the key of a misbehaviour report
+func GetReportKey(id uint64) []byte {
+	return append(ReportKey, sdk.Uint64ToBigEndian(id)...)
+}
+
+// GetDelegationKey returns the key of the delegation of a delegator to a node
+func GetDelegationKey(operator, delegator sdk.AccAddress) []byte {
+	return append(GetDelegationsKey(operator), delegator...)
+}
+
+// GetDelegationsKey returns the prefix of the delegations to a node
+func GetDelegationsKey(operator sdk.AccAddress) []byte {
+	return append(DelegationKey, operator...)
+}
+
+// GetDelegationByDelKey returns the key of the index of the delegation of a delegator to a node
+func GetDelegationByDelKey(delegator, operator sdk.AccAddress) []byte {
+	return append(GetDelegationsByDelKey(delegator), operator...)
+}
+
+// GetDelegationsByDelKey returns the prefix of the index of the delegations of a delegator
+func GetDelegationsByDelKey(delegator sdk.AccAddress) []byte {
+	return append(DelegationByDelKey, delegator...)
+}
+
+// GetHeartbeatQueueKey returns the key of an active node in the queue of the nodes by last heartbeat
+func GetHeartbeatQueueKey(lastHeartbeat time.Time, operator sdk.AccAddress) []byte {
+	return append(GetHeartbeatQueueTimeKey(lastHeartbeat), operator...)
+}
+
+// GetHeartbeatQueueTimeKey returns the prefix of the nodes in the heartbeat queue with the last heartbeat
+func GetHeartbeatQueueTimeKey(lastHeartbeat time.Time) []byte {
+	return append(HeartbeatQueueKey, sdk.FormatTimeBytes(lastHeartbeat)...)
+}
+
+// GetPendingReportKey returns the key of the index of a pending report of a node
+func GetPendingReportKey(operator sdk.AccAddress, id uint64) []byte {
+	return append(GetPendingReportsKey(operator), sdk.Uint64ToBigEndian(id)...)
+}
+
+// GetPendingReportsKey returns the prefix of the index of the pending reports of a node
+func GetPendingReportsKey(operator sdk.AccAddress) []byte {
+	return append(PendingReportKey, operator...)
+}
@@ -0,0 +1,90 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+
+	sdk "github.com/netcloth/netcloth-chain/types"
+	sdkerrors "github.com/netcloth/netcloth-chain/types/errors"
+)
+
+const (
+	// misbehaviour types of the IPAL nodes
+	MisbehaviourUnavailable = "unavailable" // the endpoint doesn't respond
+	MisbehaviourTampering   = "tampering"   // the endpoint tampered with the data it served
+
+	// status of the misbehaviour reports
+	ReportStatusPending  = "pending"
+	ReportStatusAccepted = "accepted"
+	ReportStatusRejected = "rejected"
+
+	MaxEvidenceLength = 1024
+)
+
+// MisbehaviourReport - a report of a misbehaving IPAL node, pending until it is accepted or
+// rejected by an adjudicator or a governance proposal
+type MisbehaviourReport struct {
+	ID               uint64         `json:"id" yaml:"id"`
+	Reporter         sdk.AccAddress `json:"reporter" yaml:"reporter"`
+	Operator         sdk.AccAddress `json:"operator" yaml:"operator"`
+	MisbehaviourType string         `json:"misbehaviour_type" yaml:"misbehaviour_type"`
+	Endpoint         string         `json:"endpoint" yaml:"endpoint"`
+	Evidence         string         `json:"evidence" yaml:"evidence"`
+	SubmitTime       time.Time      `json:"submit_time" yaml:"submit_time"`
+	Status           string         `json:"status" yaml:"status"`
+}
+
+type MisbehaviourReports []MisbehaviourReport
+
+func NewMisbehaviourReport(id uint64, reporter, operator sdk.AccAddress, misbehaviourType, endpoint, evidence string, submitTime time.Time) MisbehaviourReport {
+	return MisbehaviourReport{
+		ID:               id,
+		Reporter:         reporter,
+		Operator:         operator,
+		MisbehaviourType: misbehaviourType,
+		Endpoint:         endpoint,
+		Evidence:         evidence,
+		SubmitTime:       submitTime,
+		Status:           ReportStatusPending,
+	}
+}
+
+func (r MisbehaviourReport) IsPending() bool {
+	return r.Status == ReportStatusPending
+}
+
+func (r MisbehaviourReport) String() string {
+	out, _ := yaml.Marshal(r)
+	return string(out)
+}
+
+func (v MisbehaviourReports) String() (out string) {
+	for _, val := range v {
+		out += val.String() + "\n"
+	}
+	return strings.TrimSpace(out)
+}
+
+// ValidateMisbehaviour validates the misbehaviour type, the endpoint and the evidence of a report
+func ValidateMisbehaviour(misbehaviourType, endpoint, evidence string) error {
+	if misbehaviourType != MisbehaviourUnavailable && misbehaviourType != MisbehaviourTampering {
+		return sdkerrors.Wrapf(ErrInvalidMisbehaviour, "unknown misbehaviour type: %s", misbehaviourType)
+	}
+
+	if len(endpoint) == 0 {
+		return sdkerrors.Wrap(ErrInvalidMisbehaviour, "endpoint empty")
+	}
+
+	if len(evidence) == 0 {
+		return sdkerrors.Wrap(ErrInvalidMisbehaviour, "evidence empty")
+	}
+
+	if len(evidence) > MaxEvidenceLength {
+		return sdkerrors.Wrap(ErrInvalidMisbehaviour, fmt.Sprintf("evidence too long, max length: %d", MaxEvidenceLength))
+	}
+
+	return nil
+}
@@ -11,9 +11,28 @@ import (
 
 var (
 	_ sdk.Msg = MsgIPALNodeClaim{}
+	_ sdk.Msg = MsgIPALReport{}
+	_ sdk.Msg = MsgIPALAdjudicate{}
+	_ sdk.Msg = MsgIPALNodeUpdate{}
+	_ sdk.Msg = MsgIPALNodeUnclaim{}
+	_ sdk.Msg = MsgIPALDelegate{}
+	_ sdk.Msg = MsgIPALUndelegate{}
+	_ sdk.Msg = MsgIPALHeartbeat{}
 )
 
-const TypeMsgIPALNodeClaim = "ipalNodeClaim"
+const (
+	TypeMsgIPALNodeClaim  = "ipalNodeClaim"
+	TypeMsgIPALReport     = "ipalReport"
+	TypeMsgIPALAdjudicate = "ipalAdjudicate"
+	TypeMsgIPALNodeUpdate = "ipalNodeUpdate"
+	TypeMsgIPALUnclaim    = "ipalNodeUnclaim"
+	TypeMsgIPALDelegate   = "ipalDelegate"
+	TypeMsgIPALUndelegate = "ipalUndelegate"
+	TypeMsgIPALHeartbeat  = "ipalHeartbeat"
+)
+
+// DoNotModifyDesc - the description fields of a MsgIPALNodeUpdate not modified
+const DoNotModifyDesc = "[do-not-modify]"
 
 type Endpoint struct {
 	Type     uint64 `json:"type" yaml:"type"`
@@ -94,7 +113,7 @@ func (msg MsgIPALNodeClaim) ValidateBasic() error {
 		return ErrEndpointsEmpty
 	}
 
-	if err := EndpointsDupCheck(msg.Endpoints); err != nil {
+	if err := ValidateEndpoints(msg.Endpoints); err != nil {
 		return err
 	}
 
@@ -109,3 +128,286 @@ func (msg MsgIPALNodeClaim) GetSignBytes() []byte {
 	bz := ModuleCdc.MustMarshalJSON(msg)
 	return sdk.MustSortJSON(bz)
 }
+
+// MsgIPALReport - report a misbehaving endpoint of an IPAL node, signed by the reporting client
+type MsgIPALReport struct {
+	Reporter         sdk.AccAddress `json:"reporter" yaml:"reporter"`
+	Operator         sdk.AccAddress `json:"operator" yaml:"operator"`                   // address of the reported IPALNode's operator
+	MisbehaviourType string         `json:"misbehaviour_type" yaml:"misbehaviour_type"` // unavailable or tampering
+	Endpoint         string         `json:"endpoint" yaml:"endpoint"`                   // the misbehaving endpoint
+	Evidence         string         `json:"evidence" yaml:"evidence"`                   // evidence of the misbehaviour, e.g. the request and the tampered response
+}
+
+func NewMsgIPALReport(reporter, operator sdk.AccAddress, misbehaviourType, endpoint, evidence string) MsgIPALReport {
+	return MsgIPALReport{
+		Reporter:         reporter,
+		Operator:         operator,
+		MisbehaviourType: misbehaviourType,
+		Endpoint:         endpoint,
+		Evidence:         evidence,
+	}
+}
+
+func (msg MsgIPALReport) Route() string { return RouterKey }
+
+func (msg MsgIPALReport) Type() string { return TypeMsgIPALReport }
+
+func (msg MsgIPALReport) ValidateBasic() error {
+	if msg.Reporter.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing reporter address")
+	}
+
+	if msg.Operator.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing operator address")
+	}
+
+	return ValidateMisbehaviour(msg.MisbehaviourType, msg.Endpoint, msg.Evidence)
+}
+
+func (msg MsgIPALReport) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Reporter}
+}
+
+func (msg MsgIPALReport) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// MsgIPALAdjudicate - accept or reject a pending misbehaviour report, by a member of the adjudicator panel
+type MsgIPALAdjudicate struct {
+	Adjudicator sdk.AccAddress `json:"adjudicator" yaml:"adjudicator"`
+	ReportID    uint64         `json:"report_id" yaml:"report_id"`
+	Accept      bool           `json:"accept" yaml:"accept"` // slash and jail the reported node if true
+}
+
+func NewMsgIPALAdjudicate(adjudicator sdk.AccAddress, reportID uint64, accept bool) MsgIPALAdjudicate {
+	return MsgIPALAdjudicate{
+		Adjudicator: adjudicator,
+		ReportID:    reportID,
+		Accept:      accept,
+	}
+}
+
+func (msg MsgIPALAdjudicate) Route() string { return RouterKey }
+
+func (msg MsgIPALAdjudicate) Type() string { return TypeMsgIPALAdjudicate }
+
+func (msg MsgIPALAdjudicate) ValidateBasic() error {
+	if msg.Adjudicator.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing adjudicator address")
+	}
+
+	return nil
+}
+
+func (msg MsgIPALAdjudicate) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Adjudicator}
+}
+
+func (msg MsgIPALAdjudicate) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// MsgIPALNodeUpdate - update the description and the endpoints of an IPAL node, its bond unchanged.
+// The fields set to DoNotModifyDesc and empty endpoints are not modified
+type MsgIPALNodeUpdate struct {
+	OperatorAddress sdk.AccAddress `json:"operator_address" yaml:"operator_address"`
+	Moniker         string         `json:"moniker" yaml:"moniker"`
+	Website         string         `json:"website" yaml:"website"`
+	Details         string         `json:"details" yaml:"details"`
+	Endpoints       Endpoints      `json:"endpoints" yaml:"endpoints"`
+}
+
+func NewMsgIPALNodeUpdate(operator sdk.AccAddress, moniker, website, details string, endpoints Endpoints) MsgIPALNodeUpdate {
+	return MsgIPALNodeUpdate{
+		OperatorAddress: operator,
+		Moniker:         moniker,
+		Website:         website,
+		Details:         details,
+		Endpoints:       endpoints,
+	}
+}
+
+func (msg MsgIPALNodeUpdate) Route() string { return RouterKey }
+
+func (msg MsgIPALNodeUpdate) Type() string { return TypeMsgIPALNodeUpdate }
+
+func (msg *MsgIPALNodeUpdate) TrimSpace() {
+	msg.Moniker = strings.TrimSpace(msg.Moniker)
+	msg.Website = strings.TrimSpace(msg.Website)
+	msg.Details = strings.TrimSpace(msg.Details)
+}
+
+func (msg MsgIPALNodeUpdate) ValidateBasic() error {
+	if msg.OperatorAddress.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing operator address")
+	}
+
+	if msg.Moniker == "" {
+		return sdkerrors.Wrap(ErrEmptyInputs, "moniker empty")
+	}
+
+	if msg.Moniker == DoNotModifyDesc && msg.Website == DoNotModifyDesc && msg.Details == DoNotModifyDesc && len(msg.Endpoints) == 0 {
+		return sdkerrors.Wrap(ErrEmptyInputs, "nothing to update")
+	}
+
+	return ValidateEndpoints(msg.Endpoints)
+}
+
+func (msg MsgIPALNodeUpdate) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.OperatorAddress}
+}
+
+func (msg MsgIPALNodeUpdate) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// MsgIPALNodeUnclaim - remove an IPAL node from the directory, its whole bond unbonding
+type MsgIPALNodeUnclaim struct {
+	OperatorAddress sdk.AccAddress `json:"operator_address" yaml:"operator_address"`
+}
+
+func NewMsgIPALNodeUnclaim(operator sdk.AccAddress) MsgIPALNodeUnclaim {
+	return MsgIPALNodeUnclaim{
+		OperatorAddress: operator,
+	}
+}
+
+func (msg MsgIPALNodeUnclaim) Route() string { return RouterKey }
+
+func (msg MsgIPALNodeUnclaim) Type() string { return TypeMsgIPALUnclaim }
+
+func (msg MsgIPALNodeUnclaim) ValidateBasic() error {
+	if msg.OperatorAddress.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing operator address")
+	}
+
+	return nil
+}
+
+func (msg MsgIPALNodeUnclaim) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.OperatorAddress}
+}
+
+func (msg MsgIPALNodeUnclaim) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// MsgIPALDelegate - add bond of any account to an IPAL node
+type MsgIPALDelegate struct {
+	DelegatorAddress sdk.AccAddress `json:"delegator_address" yaml:"delegator_address"`
+	OperatorAddress  sdk.AccAddress `json:"operator_address" yaml:"operator_address"` // address of the backed IPALNode's operator
+	Amount           sdk.Coin       `json:"amount" yaml:"amount"`
+}
+
+func NewMsgIPALDelegate(delegator, operator sdk.AccAddress, amount sdk.Coin) MsgIPALDelegate {
+	return MsgIPALDelegate{
+		DelegatorAddress: delegator,
+		OperatorAddress:  operator,
+		Amount:           amount,
+	}
+}
+
+func (msg MsgIPALDelegate) Route() string { return RouterKey }
+
+func (msg MsgIPALDelegate) Type() string { return TypeMsgIPALDelegate }
+
+func (msg MsgIPALDelegate) ValidateBasic() error {
+	return validateDelegation(msg.DelegatorAddress, msg.OperatorAddress, msg.Amount)
+}
+
+func (msg MsgIPALDelegate) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.DelegatorAddress}
+}
+
+func (msg MsgIPALDelegate) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// MsgIPALUndelegate - remove bond of a delegator from an IPAL node, the amount unbonding
+type MsgIPALUndelegate struct {
+	DelegatorAddress sdk.AccAddress `json:"delegator_address" yaml:"delegator_address"`
+	OperatorAddress  sdk.AccAddress `json:"operator_address" yaml:"operator_address"`
+	Amount           sdk.Coin       `json:"amount" yaml:"amount"`
+}
+
+func NewMsgIPALUndelegate(delegator, operator sdk.AccAddress, amount sdk.Coin) MsgIPALUndelegate {
+	return MsgIPALUndelegate{
+		DelegatorAddress: delegator,
+		OperatorAddress:  operator,
+		Amount:           amount,
+	}
+}
+
+func (msg MsgIPALUndelegate) Route() string { return RouterKey }
+
+func (msg MsgIPALUndelegate) Type() string { return TypeMsgIPALUndelegate }
+
+func (msg MsgIPALUndelegate) ValidateBasic() error {
+	return validateDelegation(msg.DelegatorAddress, msg.OperatorAddress, msg.Amount)
+}
+
+func (msg MsgIPALUndelegate) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.DelegatorAddress}
+}
+
+func (msg MsgIPALUndelegate) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// MsgIPALHeartbeat - liveness signal of an IPAL node, required every heartbeat interval
+type MsgIPALHeartbeat struct {
+	OperatorAddress sdk.AccAddress `json:"operator_address" yaml:"operator_address"`
+}
+
+func NewMsgIPALHeartbeat(operator sdk.AccAddress) MsgIPALHeartbeat {
+	return MsgIPALHeartbeat{
+		OperatorAddress: operator,
+	}
+}
+
+func (msg MsgIPALHeartbeat) Route() string { return RouterKey }
+
+func (msg MsgIPALHeartbeat) Type() string { return TypeMsgIPALHeartbeat }
+
+func (msg MsgIPALHeartbeat) ValidateBasic() error {
+	if msg.OperatorAddress.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing operator address")
+	}
+
+	return nil
+}
+
+func (msg MsgIPALHeartbeat) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.OperatorAddress}
+}
+
+func (msg MsgIPALHeartbeat) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+func validateDelegation(delegator, operator sdk.AccAddress, amount sdk.Coin) error {
+	if delegator.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing delegator address")
+	}
+
+	if operator.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing operator address")
+	}
+
+	if amount.Denom != sdk.NativeTokenName {
+		return sdkerrors.Wrapf(ErrBadDenom, "amount denom must be %s", sdk.NativeTokenName)
+	}
+
+	if !amount.IsPositive() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "amount must > 0")
+	}
+
+	return nil
+}
@@ -10,28 +10,55 @@ import (
 
 const (
 	DefaultUnbondingTime = time.Hour * 24 * 7
+	DefaultJailDuration  = time.Hour * 24 * 7
+
+	DefaultHeartbeatInterval   = time.Hour
+	DefaultMaxMissedHeartbeats = uint64(3)
 )
 
 var (
-	DefaultMinBond = sdk.NewCoin(sdk.NativeTokenName, sdk.NewInt(sdk.NativeTokenFraction))
+	DefaultMinBond       = sdk.NewCoin(sdk.NativeTokenName, sdk.NewInt(sdk.NativeTokenFraction))
+	DefaultSlashFraction = sdk.NewDecWithPrec(1, 2)
 )
 
 var (
 	KeyUnbondingTime = []byte("UnbondingTime")
 	KeyMinBond       = []byte("MinBond")
+	KeySlashFraction = []byte("SlashFraction")
+	KeyJailDuration  = []byte("JailDuration")
+	KeyAdjudicators  = []byte("Adjudicators")
+	KeyServiceTypes  = []byte("ServiceTypes")
+
+	KeyHeartbeatInterval   = []byte("HeartbeatInterval")
+	KeyMaxMissedHeartbeats = []byte("MaxMissedHeartbeats")
 )
 
 type Params struct {
-	UnbondingTime time.Duration `json:"unbonding_time" yaml:"unbonding_time"`
-	MinBond       sdk.Coin      `json:"min_bond" yaml:"min_bond"`
+	UnbondingTime time.Duration    `json:"unbonding_time" yaml:"unbonding_time"`
+	MinBond       sdk.Coin         `json:"min_bond" yaml:"min_bond"`
+	SlashFraction sdk.Dec          `json:"slash_fraction" yaml:"slash_fraction"` // fraction of the bond slashed for a misbehaviour
+	JailDuration  time.Duration    `json:"jail_duration" yaml:"jail_duration"`   // duration a misbehaving node is hidden from the node list
+	Adjudicators  []sdk.AccAddress `json:"adjudicators" yaml:"adjudicators"`     // panel adjudicating the misbehaviour reports, besides governance
+	ServiceTypes  ServiceTypeInfos `json:"service_types" yaml:"service_types"`   // registry of the service types of the endpoints
+
+	HeartbeatInterval   time.Duration `json:"heartbeat_interval" yaml:"heartbeat_interval"`       // interval of the heartbeats required from the nodes
+	MaxMissedHeartbeats uint64        `json:"max_missed_heartbeats" yaml:"max_missed_heartbeats"` // heartbeats missed before a node is flagged inactive
 }
 
 var _ params.ParamSet = (*Params)(nil)
 
-func NewParams(unbondingTime time.Duration, minBond sdk.Coin) Params {
+func NewParams(unbondingTime time.Duration, minBond sdk.Coin, slashFraction sdk.Dec, jailDuration time.Duration, adjudicators []sdk.AccAddress, serviceTypes ServiceTypeInfos,
+	heartbeatInterval time.Duration, maxMissedHeartbeats uint64) Params {
 	return Params{
 		UnbondingTime: unbondingTime,
 		MinBond:       minBond,
+		SlashFraction: slashFraction,
+		JailDuration:  jailDuration,
+		Adjudicators:  adjudicators,
+		ServiceTypes:  serviceTypes,
+
+		HeartbeatInterval:   heartbeatInterval,
+		MaxMissedHeartbeats: maxMissedHeartbeats,
 	}
 }
 
@@ -39,6 +66,12 @@ func (p *Params) ParamSetPairs() params.ParamSetPairs {
 	return params.ParamSetPairs{
 		params.NewParamSetPair(KeyUnbondingTime, &p.UnbondingTime, validateUnbondingTime),
 		params.NewParamSetPair(KeyMinBond, &p.MinBond, validateMinBond),
+		params.NewParamSetPair(KeySlashFraction, &p.SlashFraction, validateSlashFraction),
+		params.NewParamSetPair(KeyJailDuration, &p.JailDuration, validateJailDuration),
+		params.NewParamSetPair(KeyAdjudicators, &p.Adjudicators, validateAdjudicators),
+		params.NewParamSetPair(KeyServiceTypes, &p.ServiceTypes, validateServiceTypes),
+		params.NewParamSetPair(KeyHeartbeatInterval, &p.HeartbeatInterval, validateHeartbeatInterval),
+		params.NewParamSetPair(KeyMaxMissedHeartbeats, &p.MaxMissedHeartbeats, validateMaxMissedHeartbeats),
 	}
 }
 
@@ -46,15 +79,34 @@ func DefaultParams() Params {
 	return NewParams(
 		DefaultUnbondingTime,
 		DefaultMinBond,
+		DefaultSlashFraction,
+		DefaultJailDuration,
+		nil,
+		DefaultServiceTypes(),
+		DefaultHeartbeatInterval,
+		DefaultMaxMissedHeartbeats,
 	)
 }
 
 func (p Params) String() string {
 	return fmt.Sprintf(`Params:
   Unbonding Time    : %s
-  Min Bond   : %v`,
+  Min Bond   : %v
+  Slash Fraction    : %s
+  Jail Duration     : %s
+  Adjudicators      : %v
+  Heartbeat Interval    : %s
+  Max Missed Heartbeats : %d
+  Service Types     :
+%s`,
 		p.UnbondingTime,
-		p.MinBond)
+		p.MinBond,
+		p.SlashFraction,
+		p.JailDuration,
+		p.Adjudicators,
+		p.HeartbeatInterval,
+		p.MaxMissedHeartbeats,
+		p.ServiceTypes)
 }
 
 func validateUnbondingTime(i interface{}) error {
@@ -74,3 +126,84 @@ func validateMinBond(i interface{}) error {
 	// TODO
 	return nil
 }
+
+func validateSlashFraction(i interface{}) error {
+	v, ok := i.(sdk.Dec)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v.IsNil() || v.IsNegative() || v.GT(sdk.OneDec()) {
+		return fmt.Errorf("slash fraction must be in [0, 1]: %s", v)
+	}
+
+	return nil
+}
+
+func validateJailDuration(i interface{}) error {
+	v, ok := i.(time.Duration)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v < 0 {
+		return fmt.Errorf("jail duration must not be negative: %d", v)
+	}
+
+	return nil
+}
+
+func validateAdjudicators(i interface{}) error {
+	v, ok := i.([]sdk.AccAddress)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	seen := make(map[string]bool)
+	for _, addr := range v {
+		if addr.Empty() {
+			return fmt.Errorf("empty adjudicator address")
+		}
+		if seen[addr.String()] {
+			return fmt.Errorf("duplicate adjudicator: %s", addr)
+		}
+		seen[addr.String()] = true
+	}
+
+	return nil
+}
+
+func validateServiceTypes(i interface{}) error {
+	v, ok := i.(ServiceTypeInfos)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	return v.Validate()
+}
+
+func validateHeartbeatInterval(i interface{}) error {
+	v, ok := i.(time.Duration)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v <= 0 {
+		return fmt.Errorf("heartbeat interval must be positive: %d", v)
+	}
+
+	return nil
+}
+
+func validateMaxMissedHeartbeats(i interface{}) error {
+	v, ok := i.(uint64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v == 0 {
+		return fmt.Errorf("max missed heartbeats must be positive: %d", v)
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package types
+
+import (
+	"fmt"
+
+	govtypes "github.com/netcloth/netcloth-chain/app/v0/gov/types"
+)
+
+const (
+	// ProposalTypeIPALSlash defines the type for an IPALSlashProposal
+	ProposalTypeIPALSlash = "IPALSlash"
+)
+
+// Assert IPALSlashProposal implements govtypes.Content at compile-time
+var _ govtypes.Content = IPALSlashProposal{}
+
+func init() {
+	govtypes.RegisterProposalType(ProposalTypeIPALSlash)
+	govtypes.RegisterProposalTypeCodec(IPALSlashProposal{}, "nch/IPALSlashProposal")
+}
+
+// IPALSlashProposal accepts a pending misbehaviour report, slashing and jailing the reported node
+type IPALSlashProposal struct {
+	Title       string `json:"title" yaml:"title"`
+	Description string `json:"description" yaml:"description"`
+	ReportID    uint64 `json:"report_id" yaml:"report_id"`
+}
+
+// NewIPALSlashProposal creates a new ipal slash proposal.
+func NewIPALSlashProposal(title, description string, reportID uint64) IPALSlashProposal {
+	return IPALSlashProposal{title, description, reportID}
+}
+
+// GetTitle returns the title of an ipal slash proposal.
+func (p IPALSlashProposal) GetTitle() string { return p.Title }
+
+// GetDescription returns the description of an ipal slash proposal.
+func (p IPALSlashProposal) GetDescription() string { return p.Description }
+
+// ProposalRoute returns the routing key of an ipal slash proposal.
+func (p IPALSlashProposal) ProposalRoute() string { return RouterKey }
+
+// ProposalType returns the type of an ipal slash proposal.
+func (p IPALSlashProposal) ProposalType() string { return ProposalTypeIPALSlash }
+
+// ValidateBasic runs basic stateless validity checks
+func (p IPALSlashProposal) ValidateBasic() error {
+	return govtypes.ValidateAbstract(p)
+}
+
+// String implements the Stringer interface.
+func (p IPALSlashProposal) String() string {
+	return fmt.Sprintf(`IPAL Slash Proposal:
+  Title:       %s
+  Description: %s
+  Report ID:   %d
+`, p.Title, p.Description, p.ReportID)
+}
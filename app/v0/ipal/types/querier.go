@@ -1,6 +1,8 @@
 package types
 
 import (
+	"gopkg.in/yaml.v2"
+
 	sdk "github.com/netcloth/netcloth-chain/types"
 )
 
@@ -9,8 +11,41 @@ const (
 	QueryIPALNode     = "node"
 	QueryIPALNodes    = "nodes"
 	QueryParameters   = "params"
+	QueryUnBondings   = "unbondings"
+	QueryReport       = "report"
+	QueryReports      = "reports"
+	QueryDelegations  = "delegations"
 )
 
+// QueryIPALNodeListParams - filters and pagination of the node list. The page starts after the
+// cursor if given, otherwise it's the page-th of limit nodes, with all the nodes if limit is 0
+type QueryIPALNodeListParams struct {
+	IPALNodeFilter
+	Page   int    `json:"page"`
+	Limit  int    `json:"limit"`
+	Cursor string `json:"cursor"` // hex encoded
+}
+
+func NewQueryIPALNodeListParams(filter IPALNodeFilter, page, limit int, cursor string) QueryIPALNodeListParams {
+	return QueryIPALNodeListParams{
+		IPALNodeFilter: filter,
+		Page:           page,
+		Limit:          limit,
+		Cursor:         cursor,
+	}
+}
+
+// IPALNodeListResult - a page of the node list, NextCursor is empty on the last page
+type IPALNodeListResult struct {
+	Nodes      IPALNodes `json:"nodes" yaml:"nodes"`
+	NextCursor string    `json:"next_cursor" yaml:"next_cursor"`
+}
+
+func (r IPALNodeListResult) String() string {
+	out, _ := yaml.Marshal(r)
+	return string(out)
+}
+
 type QueryIPALNodeParams struct {
 	AccAddr sdk.AccAddress
 }
@@ -30,3 +65,49 @@ func NewQueryIPALNodesParams(AccAddrs []sdk.AccAddress) QueryIPALNodesParams {
 		AccAddrs: AccAddrs,
 	}
 }
+
+type QueryUnBondingsParams struct {
+	AccAddr sdk.AccAddress `json:"acc_addr"`
+}
+
+func NewQueryUnBondingsParams(accAddr sdk.AccAddress) QueryUnBondingsParams {
+	return QueryUnBondingsParams{
+		AccAddr: accAddr,
+	}
+}
+
+type QueryReportParams struct {
+	ReportID uint64 `json:"report_id"`
+}
+
+func NewQueryReportParams(reportID uint64) QueryReportParams {
+	return QueryReportParams{
+		ReportID: reportID,
+	}
+}
+
+// QueryReportsParams - filters of the misbehaviour reports, ignored if empty
+type QueryReportsParams struct {
+	Operator sdk.AccAddress `json:"operator"`
+	Status   string         `json:"status"`
+}
+
+func NewQueryReportsParams(operator sdk.AccAddress, status string) QueryReportsParams {
+	return QueryReportsParams{
+		Operator: operator,
+		Status:   status,
+	}
+}
+
+// QueryDelegationsParams - filters of the delegations, by node and by delegator, ignored if empty
+type QueryDelegationsParams struct {
+	Operator  sdk.AccAddress `json:"operator"`
+	Delegator sdk.AccAddress `json:"delegator"`
+}
+
+func NewQueryDelegationsParams(operator, delegator sdk.AccAddress) QueryDelegationsParams {
+	return QueryDelegationsParams{
+		Operator:  operator,
+		Delegator: delegator,
+	}
+}
@@ -0,0 +1,230 @@
+package types
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	sdkerrors "github.com/netcloth/netcloth-chain/types/errors"
+)
+
+type ServiceType uint64
+
+const (
+	Chatting ServiceType = 1
+	Storage  ServiceType = 2
+)
+
+const (
+	SchemeTCP = "tcp" // scheme of the host:port endpoints
+
+	MaxEndpointLength = 256
+)
+
+// ServiceTypeInfo - a service type of the registry, the endpoints of the type must have one of the schemes
+type ServiceTypeInfo struct {
+	ID           uint64   `json:"id" yaml:"id"`
+	Name         string   `json:"name" yaml:"name"`
+	Schemes      []string `json:"schemes" yaml:"schemes"`             // allowed URL schemes, tcp for host:port
+	MaxEndpoints uint64   `json:"max_endpoints" yaml:"max_endpoints"` // max endpoints of the type per node
+}
+
+func NewServiceTypeInfo(id uint64, name string, schemes []string, maxEndpoints uint64) ServiceTypeInfo {
+	return ServiceTypeInfo{
+		ID:           id,
+		Name:         name,
+		Schemes:      schemes,
+		MaxEndpoints: maxEndpoints,
+	}
+}
+
+func (s ServiceTypeInfo) String() string {
+	out, _ := yaml.Marshal(s)
+	return string(out)
+}
+
+// AllowsScheme returns whether the endpoints of the type may have the scheme
+func (s ServiceTypeInfo) AllowsScheme(scheme string) bool {
+	for _, v := range s.Schemes {
+		if v == scheme {
+			return true
+		}
+	}
+	return false
+}
+
+type ServiceTypeInfos []ServiceTypeInfo
+
+// DefaultServiceTypes returns the chatting and storage service types
+func DefaultServiceTypes() ServiceTypeInfos {
+	return ServiceTypeInfos{
+		NewServiceTypeInfo(uint64(Chatting), "chatting", []string{SchemeTCP, "http", "https", "ws", "wss"}, 1),
+		NewServiceTypeInfo(uint64(Storage), "storage", []string{SchemeTCP, "http", "https"}, 1),
+	}
+}
+
+func (s ServiceTypeInfos) String() (out string) {
+	for _, val := range s {
+		out += val.String() + "\n"
+	}
+	return strings.TrimSpace(out)
+}
+
+// Get returns the service type of the id
+func (s ServiceTypeInfos) Get(id uint64) (ServiceTypeInfo, bool) {
+	for _, v := range s {
+		if v.ID == id {
+			return v, true
+		}
+	}
+	return ServiceTypeInfo{}, false
+}
+
+// Validate checks the ids and names are unique and the schemes are lower case
+func (s ServiceTypeInfos) Validate() error {
+	ids := make(map[uint64]bool)
+	names := make(map[string]bool)
+	for _, v := range s {
+		if v.ID == 0 {
+			return fmt.Errorf("service type id must be positive")
+		}
+		if ids[v.ID] {
+			return fmt.Errorf("duplicate service type id: %d", v.ID)
+		}
+		ids[v.ID] = true
+
+		if strings.TrimSpace(v.Name) == "" {
+			return fmt.Errorf("empty name of service type %d", v.ID)
+		}
+		if names[v.Name] {
+			return fmt.Errorf("duplicate service type name: %s", v.Name)
+		}
+		names[v.Name] = true
+
+		if len(v.Schemes) == 0 {
+			return fmt.Errorf("no schemes of service type %d", v.ID)
+		}
+		for _, scheme := range v.Schemes {
+			if scheme == "" || scheme != strings.ToLower(scheme) {
+				return fmt.Errorf("invalid scheme of service type %d: %q", v.ID, scheme)
+			}
+		}
+
+		if v.MaxEndpoints == 0 {
+			return fmt.Errorf("max endpoints of service type %d must be positive", v.ID)
+		}
+	}
+	return nil
+}
+
+// ValidateEndpoints checks the endpoints against the registry: known types, allowed schemes and
+// the max endpoints per type
+func (s ServiceTypeInfos) ValidateEndpoints(endpoints Endpoints) error {
+	counts := make(map[uint64]uint64)
+	for _, e := range endpoints {
+		serviceType, found := s.Get(e.Type)
+		if !found {
+			return sdkerrors.Wrapf(ErrUnknownServiceType, "service type: %d", e.Type)
+		}
+
+		scheme, err := ParseEndpoint(e.Endpoint)
+		if err != nil {
+			return err
+		}
+		if !serviceType.AllowsScheme(scheme) {
+			return sdkerrors.Wrapf(ErrEndpointsFormat, "scheme %s not allowed for service type %s, allowed: %s", scheme, serviceType.Name, strings.Join(serviceType.Schemes, ", "))
+		}
+
+		counts[e.Type]++
+		if counts[e.Type] > serviceType.MaxEndpoints {
+			return sdkerrors.Wrapf(ErrEndpointsDuplicate, "at most %d endpoints of service type %s", serviceType.MaxEndpoints, serviceType.Name)
+		}
+	}
+	return nil
+}
+
+// ValidateEndpoints checks the endpoints are well formed and not duplicate, regardless of the registry
+func ValidateEndpoints(endpoints Endpoints) error {
+	for _, e := range endpoints {
+		if e.Type == 0 {
+			return sdkerrors.Wrap(ErrUnknownServiceType, "service type: 0")
+		}
+		if _, err := ParseEndpoint(e.Endpoint); err != nil {
+			return err
+		}
+	}
+
+	return EndpointsDupCheck(endpoints)
+}
+
+// ParseEndpoint checks the endpoint is a host:port or a URL with a host, and returns its scheme
+func ParseEndpoint(endpoint string) (scheme string, err error) {
+	if len(endpoint) > MaxEndpointLength {
+		return "", sdkerrors.Wrapf(ErrEndpointsFormat, "endpoint longer than %d", MaxEndpointLength)
+	}
+
+	if !strings.Contains(endpoint, "://") {
+		host, port, err := net.SplitHostPort(endpoint)
+		if err != nil {
+			return "", sdkerrors.Wrapf(ErrEndpointsFormat, "invalid host:port %s: %s", endpoint, err)
+		}
+		if err := validateHost(host); err != nil {
+			return "", sdkerrors.Wrapf(ErrEndpointsFormat, "invalid host:port %s: %s", endpoint, err)
+		}
+		if err := validatePort(port); err != nil {
+			return "", sdkerrors.Wrapf(ErrEndpointsFormat, "invalid host:port %s: %s", endpoint, err)
+		}
+		return SchemeTCP, nil
+	}
+
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", sdkerrors.Wrapf(ErrEndpointsFormat, "invalid url %s: %s", endpoint, err)
+	}
+	if u.Scheme == "" || u.Host == "" || u.User != nil {
+		return "", sdkerrors.Wrapf(ErrEndpointsFormat, "invalid url %s: scheme and host required, user info not allowed", endpoint)
+	}
+	if err := validateHost(u.Hostname()); err != nil {
+		return "", sdkerrors.Wrapf(ErrEndpointsFormat, "invalid url %s: %s", endpoint, err)
+	}
+	if port := u.Port(); port != "" || strings.HasSuffix(u.Host, ":") {
+		if err := validatePort(port); err != nil {
+			return "", sdkerrors.Wrapf(ErrEndpointsFormat, "invalid url %s: %s", endpoint, err)
+		}
+	}
+	return u.Scheme, nil
+}
+
+// validateHost checks the host is an IP or a domain name
+func validateHost(host string) error {
+	if net.ParseIP(host) != nil {
+		return nil
+	}
+
+	if host == "" || len(host) > 253 {
+		return fmt.Errorf("invalid host length")
+	}
+	for _, label := range strings.Split(host, ".") {
+		if label == "" || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return fmt.Errorf("invalid host: %s", host)
+		}
+		for _, c := range label {
+			if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-') {
+				return fmt.Errorf("invalid host: %s", host)
+			}
+		}
+	}
+	return nil
+}
+
+func validatePort(port string) error {
+	p, err := strconv.ParseUint(port, 10, 16)
+	if err != nil || p == 0 {
+		return fmt.Errorf("invalid port: %s", port)
+	}
+	return nil
+}
@@ -1,13 +1,23 @@
 package types
 
 import (
+	"strings"
 	"time"
 
+	"gopkg.in/yaml.v2"
+
 	sdk "github.com/netcloth/netcloth-chain/types"
 )
 
 type UnBondings []UnBonding
 
+func (v UnBondings) String() (out string) {
+	for _, val := range v {
+		out += val.String() + "\n"
+	}
+	return strings.TrimSpace(out)
+}
+
 type UnBonding struct {
 	AccountAddress sdk.AccAddress `json:"account_address" yaml:"account_address"`
 	Amount         sdk.Coin       `json:"amount" yaml:"amount"`
@@ -25,3 +35,8 @@ func NewUnBonding(aa sdk.AccAddress, amt sdk.Coin, endTime time.Time) UnBonding
 func (ub UnBonding) IsMature(now time.Time) bool {
 	return !ub.EndTime.After(now)
 }
+
+func (ub UnBonding) String() string {
+	out, _ := yaml.Marshal(ub)
+	return string(out)
+}
@@ -36,7 +36,15 @@ func EndBlocker(ctx sdk.Context, keeper Keeper) {
 		}
 
 		curHeight := uint64(ctx.BlockHeight())
-		if curHeight == upgradeConfig.Protocol.Height {
+		switchNow := curHeight == upgradeConfig.Protocol.Height
+		switchMissed := curHeight > upgradeConfig.Protocol.Height
+		if upgradeConfig.Protocol.SwitchByTime() {
+			// tally at the first block whose time passes the switch time
+			switchNow = !ctx.BlockHeader().Time.Before(upgradeConfig.Protocol.SwitchTime)
+			switchMissed = false
+		}
+
+		if switchNow {
 			success := tally(ctx, upgradeConfig.Protocol.Version, keeper, upgradeConfig.Protocol.Threshold)
 			if success {
 				ctx.Logger().Info("Software Upgrade is successful, ", "version", upgradeConfig.Protocol.Version)
@@ -55,7 +63,7 @@ func EndBlocker(ctx sdk.Context, keeper Keeper) {
 			keeper.protocolKeeper.ClearUpgradeConfig(ctx)
 		}
 
-		if curHeight > upgradeConfig.Protocol.Height {
+		if switchMissed {
 			ctx.Logger().Info(fmt.Sprintf("current height[%d] is big than switch height[%d], failed to switch", ctx.BlockHeight(), upgradeConfig.Protocol.Height))
 			keeper.AddNewVersionInfo(ctx, upgtypes.NewVersionInfo(upgradeConfig, false))
 			keeper.protocolKeeper.ClearUpgradeConfig(ctx)
@@ -2,6 +2,7 @@ package upgrade
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -98,3 +99,44 @@ func TestEndBlockerTallySuccess(t *testing.T) {
 	// log "Software Upgrade is successful"
 	require.Equal(t, uint64(1), keeper.GetCurrentVersion(ctx))
 }
+
+func TestEndBlockerSwitchTime(t *testing.T) {
+	ctx, keeper, stakingKeeper, _ := CreateTestInput(t, 1000)
+
+	switchTime := time.Unix(1000, 0).UTC()
+	upgradeConfig := sdk.NewUpgradeConfig(1, sdk.NewTimedProtocolDefinition(1, "software1", switchTime, sdk.NewDecWithPrec(7, 1)))
+	keeper.protocolKeeper.SetUpgradeConfig(ctx, upgradeConfig)
+
+	stored, found := keeper.protocolKeeper.GetUpgradeConfig(ctx)
+	require.True(t, found)
+	require.True(t, stored.Protocol.SwitchByTime())
+	require.True(t, switchTime.Equal(stored.Protocol.SwitchTime))
+
+	description := staking.NewDescription("moniker3", "identity3", "website3", "details3")
+	validator := staking.NewValidator(sdk.ValAddress(Addrs[0]), PKs[0], description)
+	validator.Status = sdk.Bonded
+	validator.Tokens = sdk.TokensFromConsensusPower(1)
+	stakingKeeper.SetValidator(ctx, validator)
+	stakingKeeper.SetValidatorByPowerIndex(ctx, validator)
+	stakingKeeper.SetValidatorByConsAddr(ctx, validator)
+
+	// the height is not the switch height of a timed upgrade
+	ctx = ctx.WithBlockHeader(abci.Header{Version: abci.Version{Block: 1, App: 1}, ProposerAddress: validator.GetConsAddr(), Time: switchTime.Add(-time.Second)})
+	ctx = ctx.WithBlockHeight(5000)
+	EndBlocker(ctx, keeper)
+	_, found = keeper.protocolKeeper.GetUpgradeConfig(ctx)
+	require.True(t, found)
+	require.Equal(t, uint64(0), keeper.GetCurrentVersion(ctx))
+
+	// tally at the first block after the switch time
+	ctx = ctx.WithBlockHeader(abci.Header{Version: abci.Version{Block: 1, App: 1}, ProposerAddress: validator.GetConsAddr(), Time: switchTime.Add(time.Second)})
+	ctx = ctx.WithBlockHeight(5001)
+	EndBlocker(ctx, keeper)
+	_, found = keeper.protocolKeeper.GetUpgradeConfig(ctx)
+	require.False(t, found)
+	require.Equal(t, uint64(1), keeper.GetCurrentVersion(ctx))
+
+	versionInfo, found := keeper.GetVersionInfo(ctx, 1)
+	require.True(t, found)
+	require.True(t, versionInfo.Success)
+}
@@ -7,4 +7,20 @@ import (
 const (
 	StoreKey   = types.StoreKey
 	ModuleName = types.ModuleName
+	RouterKey  = types.RouterKey
+
+	ProposalTypeCancelSoftwareUpgrade = types.ProposalTypeCancelSoftwareUpgrade
+	QueryReadiness                    = types.QueryReadiness
+)
+
+var (
+	NewCancelSoftwareUpgradeProposal      = types.NewCancelSoftwareUpgradeProposal
+	NewTimedCancelSoftwareUpgradeProposal = types.NewTimedCancelSoftwareUpgradeProposal
+	NewMsgSignalUpgrade                   = types.NewMsgSignalUpgrade
+)
+
+type (
+	CancelSoftwareUpgradeProposal = types.CancelSoftwareUpgradeProposal
+	MsgSignalUpgrade              = types.MsgSignalUpgrade
+	Readiness                     = types.Readiness
 )
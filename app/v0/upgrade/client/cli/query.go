@@ -8,7 +8,7 @@ import (
 
 	"github.com/netcloth/netcloth-chain/app/v0/staking"
 	"github.com/netcloth/netcloth-chain/app/v0/staking/types"
-	upgcli "github.com/netcloth/netcloth-chain/app/v0/upgrade/client"
+	upgutils "github.com/netcloth/netcloth-chain/app/v0/upgrade/client/utils"
 	upgtypes "github.com/netcloth/netcloth-chain/app/v0/upgrade/types"
 	"github.com/netcloth/netcloth-chain/client"
 	"github.com/netcloth/netcloth-chain/client/context"
@@ -32,6 +32,7 @@ func GetQueryCmd(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	queryCmd.AddCommand(client.GetCommands(
 		GetInfoCmd(queryRoute, cdc),
 		GetCmdQuerySignals(queryRoute, cdc),
+		GetCmdQueryReadiness(queryRoute, cdc),
 	)...)
 
 	return queryCmd
@@ -72,7 +73,7 @@ func GetInfoCmd(storeName string, cdc *codec.Codec) *cobra.Command {
 				lastFailedVersion = 0
 			}
 
-			upgradeInfoOutput := upgcli.NewUpgradeInfoOutput(currentVersionInfo, lastFailedVersion, upgradeInProgress)
+			upgradeInfoOutput := upgutils.NewUpgradeInfoOutput(currentVersionInfo, lastFailedVersion, upgradeInProgress)
 
 			return cliCtx.PrintOutput(upgradeInfoOutput)
 		},
@@ -146,3 +147,25 @@ func GetCmdQuerySignals(storeName string, cdc *codec.Codec) *cobra.Command {
 	cmd.Flags().Bool(flagDetail, false, "details of siganls")
 	return cmd
 }
+
+// GetCmdQueryReadiness implements the command to query the signals of the bonded validators for the software upgrade in switch period
+func GetCmdQueryReadiness(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:     "readiness",
+		Short:   "Query the signals of the bonded validators and their voting power ratio for the software upgrade in switch period",
+		Example: "nchcli query upgrade readiness",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, upgtypes.QueryReadiness), nil)
+			if err != nil {
+				return err
+			}
+
+			var readiness upgtypes.Readiness
+			cdc.MustUnmarshalJSON(res, &readiness)
+			return cliCtx.PrintOutput(readiness)
+		},
+	}
+}
@@ -0,0 +1,129 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/netcloth/netcloth-chain/app/v0/auth"
+	"github.com/netcloth/netcloth-chain/app/v0/auth/client/utils"
+	"github.com/netcloth/netcloth-chain/app/v0/gov"
+	upgtypes "github.com/netcloth/netcloth-chain/app/v0/upgrade/types"
+	"github.com/netcloth/netcloth-chain/client"
+	"github.com/netcloth/netcloth-chain/client/context"
+	"github.com/netcloth/netcloth-chain/codec"
+	sdk "github.com/netcloth/netcloth-chain/types"
+	"github.com/netcloth/netcloth-chain/version"
+)
+
+func GetTxCmd(cdc *codec.Codec) *cobra.Command {
+	txCmd := &cobra.Command{
+		Use:                        upgtypes.ModuleName,
+		Short:                      "Upgrade transactions subcommands",
+		DisableFlagParsing:         true,
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+
+	txCmd.AddCommand(client.PostCommands(
+		GetCmdSignalUpgrade(cdc),
+	)...)
+
+	return txCmd
+}
+
+// GetCmdSignalUpgrade implements the command to signal the readiness of a validator for the software upgrade in switch period
+func GetCmdSignalUpgrade(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "signal",
+		Short:   "Signal the validator is ready to run the version of the software upgrade in switch period",
+		Example: "nchcli tx upgrade signal --from=<validator key name>",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, _, err := cliCtx.QueryStore(sdk.UpgradeConfigKey, sdk.MainStore)
+			if err != nil {
+				return err
+			}
+			if len(res) == 0 {
+				return fmt.Errorf("no software upgrade switch period is in process")
+			}
+
+			var upgradeConfig sdk.UpgradeConfig
+			if err = cdc.UnmarshalBinaryLengthPrefixed(res, &upgradeConfig); err != nil {
+				return err
+			}
+
+			msg := upgtypes.NewMsgSignalUpgrade(sdk.ValAddress(cliCtx.GetFromAddress()), upgradeConfig.Protocol.Version)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	return cmd
+}
+
+// GetCmdSubmitProposal implements the command to submit a cancel-software-upgrade proposal
+func GetCmdSubmitProposal(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cancel-software-upgrade [proposal-file]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Submit a proposal to cancel or reschedule the software upgrade in switch period",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Submit a proposal cancelling the software upgrade in switch period, along with an initial deposit.
+If switch_height is not zero, or switch_time is set, the software upgrade is rescheduled to the switch height
+or to the switch time instead of cancelled, a timed upgrade rescheduled to a height switching by height and
+the other way around.
+The proposal details must be supplied via a JSON file.
+
+Example:
+$ %s tx gov submit-proposal cancel-software-upgrade <path/to/proposal.json> --from=<key_or_address>
+
+Where proposal.json contains:
+
+{
+  "title": "Cancel testnet-v1.1.0 upgrade",
+  "description": "The release breaks the state sync",
+  "proposal_id": "1",
+  "switch_height": "0",
+  "deposit": [
+    {
+      "denom": "pnch",
+      "amount": "1000000000000"
+    }
+  ]
+}
+`,
+				version.ClientName,
+			),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			proposal, err := ParseCancelSoftwareUpgradeProposalJSON(cdc, args[0])
+			if err != nil {
+				return err
+			}
+
+			from := cliCtx.GetFromAddress()
+			content := upgtypes.NewCancelSoftwareUpgradeProposal(proposal.Title, proposal.Description, proposal.ProposalID, proposal.SwitchHeight)
+			content.SwitchTime = proposal.SwitchTime
+
+			msg := gov.NewMsgSubmitProposal(content, proposal.Deposit, from)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,37 @@
+package cli
+
+import (
+	"io/ioutil"
+	"time"
+
+	"github.com/netcloth/netcloth-chain/codec"
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+type (
+	// CancelSoftwareUpgradeProposalJSON defines a CancelSoftwareUpgradeProposal with a deposit
+	CancelSoftwareUpgradeProposalJSON struct {
+		Title        string    `json:"title" yaml:"title"`
+		Description  string    `json:"description" yaml:"description"`
+		ProposalID   uint64    `json:"proposal_id" yaml:"proposal_id"`
+		SwitchHeight uint64    `json:"switch_height" yaml:"switch_height"`
+		SwitchTime   time.Time `json:"switch_time" yaml:"switch_time"`
+		Deposit      sdk.Coins `json:"deposit" yaml:"deposit"`
+	}
+)
+
+// ParseCancelSoftwareUpgradeProposalJSON reads and parses a CancelSoftwareUpgradeProposalJSON from a file.
+func ParseCancelSoftwareUpgradeProposalJSON(cdc *codec.Codec, proposalFile string) (CancelSoftwareUpgradeProposalJSON, error) {
+	proposal := CancelSoftwareUpgradeProposalJSON{}
+
+	contents, err := ioutil.ReadFile(proposalFile)
+	if err != nil {
+		return proposal, err
+	}
+
+	if err := cdc.UnmarshalJSON(contents, &proposal); err != nil {
+		return proposal, err
+	}
+
+	return proposal, nil
+}
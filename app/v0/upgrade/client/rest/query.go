@@ -1,14 +1,16 @@
 package rest
 
 import (
+	"fmt"
 	"net/http"
 
 	"github.com/gorilla/mux"
 
-	upgcli "github.com/netcloth/netcloth-chain/app/v0/upgrade/client"
+	upgutils "github.com/netcloth/netcloth-chain/app/v0/upgrade/client/utils"
 	"github.com/netcloth/netcloth-chain/app/v0/upgrade/types"
 	"github.com/netcloth/netcloth-chain/client/context"
 	sdk "github.com/netcloth/netcloth-chain/types"
+	"github.com/netcloth/netcloth-chain/types/rest"
 )
 
 func registerQueryRoutes(cliCtx context.CLIContext, r *mux.Router) {
@@ -16,6 +18,11 @@ func registerQueryRoutes(cliCtx context.CLIContext, r *mux.Router) {
 		"/upgrade/info",
 		InfoHandlerFn(cliCtx),
 	).Methods("GET")
+
+	r.HandleFunc(
+		"/upgrade/readiness",
+		readinessHandlerFn(cliCtx),
+	).Methods("GET")
 }
 
 // VersionInfo is the struct of version info
@@ -56,7 +63,7 @@ func InfoHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
 			lastFailedVersion = 0
 		}
 
-		upgradeInfoOutput := upgcli.NewUpgradeInfoOutput(currentVersionInfo, lastFailedVersion, upgradeInProgress)
+		upgradeInfoOutput := upgutils.NewUpgradeInfoOutput(currentVersionInfo, lastFailedVersion, upgradeInProgress)
 
 		output, err := cdc.MarshalJSONIndent(upgradeInfoOutput, "", "  ")
 		if err != nil {
@@ -67,3 +74,22 @@ func InfoHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
 		w.Write(output)
 	}
 }
+
+// readinessHandlerFn - HTTP request handler to query the signals of the bonded validators for the software upgrade in switch period
+func readinessHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		res, height, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryReadiness), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
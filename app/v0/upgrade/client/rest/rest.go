@@ -1,10 +1,69 @@
 package rest
 
 import (
+	"net/http"
+	"time"
+
 	"github.com/gorilla/mux"
+
+	"github.com/netcloth/netcloth-chain/app/v0/auth/client/utils"
+	"github.com/netcloth/netcloth-chain/app/v0/gov"
+	govrest "github.com/netcloth/netcloth-chain/app/v0/gov/client/rest"
+	"github.com/netcloth/netcloth-chain/app/v0/upgrade/types"
 	"github.com/netcloth/netcloth-chain/client/context"
+	sdk "github.com/netcloth/netcloth-chain/types"
+	"github.com/netcloth/netcloth-chain/types/rest"
 )
 
 func RegisterRoutes(cliCtx context.CLIContext, r *mux.Router) {
 	registerQueryRoutes(cliCtx, r)
+	registerTxRoutes(cliCtx, r)
+}
+
+type (
+	// CancelSoftwareUpgradeProposalReq defines a cancel software upgrade proposal request body.
+	CancelSoftwareUpgradeProposalReq struct {
+		BaseReq rest.BaseReq `json:"base_req" yaml:"base_req"`
+
+		Title        string         `json:"title" yaml:"title"`
+		Description  string         `json:"description" yaml:"description"`
+		ProposalID   uint64         `json:"proposal_id" yaml:"proposal_id"`
+		SwitchHeight uint64         `json:"switch_height" yaml:"switch_height"`
+		SwitchTime   time.Time      `json:"switch_time" yaml:"switch_time"`
+		Proposer     sdk.AccAddress `json:"proposer" yaml:"proposer"`
+		Deposit      sdk.Coins      `json:"deposit" yaml:"deposit"`
+	}
+)
+
+// ProposalRESTHandler returns a ProposalRESTHandler that exposes the cancel software upgrade REST handler with a given sub-route.
+func ProposalRESTHandler(cliCtx context.CLIContext) govrest.ProposalRESTHandler {
+	return govrest.ProposalRESTHandler{
+		SubRoute: "cancel_software_upgrade",
+		Handler:  postProposalHandlerFn(cliCtx),
+	}
+}
+
+func postProposalHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req CancelSoftwareUpgradeProposalReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		content := types.NewCancelSoftwareUpgradeProposal(req.Title, req.Description, req.ProposalID, req.SwitchHeight)
+		content.SwitchTime = req.SwitchTime
+
+		msg := gov.NewMsgSubmitProposal(content, req.Deposit, req.Proposer)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
 }
@@ -1,4 +1,4 @@
-package client
+package utils
 
 import (
 	"fmt"
@@ -25,6 +25,8 @@ func (p UpgradeInfoOutput) String() string {
 	success := "fail"
 	if p.CurrentVersion.Success {
 		success = "success"
+	} else if p.CurrentVersion.Cancelled {
+		success = "cancelled"
 	}
 	return fmt.Sprintf(`Upgrade Info:
   Current Version[%v]:  %s     
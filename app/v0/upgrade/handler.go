@@ -0,0 +1,54 @@
+package upgrade
+
+import (
+	"fmt"
+
+	"github.com/netcloth/netcloth-chain/app/v0/upgrade/types"
+	sdk "github.com/netcloth/netcloth-chain/types"
+	sdkerrors "github.com/netcloth/netcloth-chain/types/errors"
+)
+
+func NewHandler(k Keeper) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) (*sdk.Result, error) {
+		ctx = ctx.WithEventManager(sdk.NewEventManager())
+
+		switch msg := msg.(type) {
+		case types.MsgSignalUpgrade:
+			return handleMsgSignalUpgrade(ctx, k, msg)
+		default:
+			return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unrecognized %s message type: %T", ModuleName, msg)
+		}
+	}
+}
+
+func handleMsgSignalUpgrade(ctx sdk.Context, k Keeper, msg types.MsgSignalUpgrade) (*sdk.Result, error) {
+	upgradeConfig, found := k.protocolKeeper.GetUpgradeConfig(ctx)
+	if !found {
+		return nil, types.ErrNoUpgradeInProcess
+	}
+	if msg.Version != upgradeConfig.Protocol.Version {
+		return nil, sdkerrors.Wrapf(types.ErrInvalidVersion, "switch period of version %d, got %d", upgradeConfig.Protocol.Version, msg.Version)
+	}
+
+	validator, found := k.sk.GetValidator(ctx, msg.ValidatorAddress)
+	if !found {
+		return nil, sdkerrors.Wrapf(types.ErrValidatorNotFound, "%s", msg.ValidatorAddress)
+	}
+
+	k.SetSignal(ctx, msg.Version, validator.ConsAddress().String())
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeSignalUpgrade,
+			sdk.NewAttribute(types.AttributeKeyValidator, msg.ValidatorAddress.String()),
+			sdk.NewAttribute(types.AttributeKeyVersion, fmt.Sprintf("%d", msg.Version)),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, sdk.AccAddress(msg.ValidatorAddress).String()),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
@@ -0,0 +1,69 @@
+package upgrade
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/netcloth/netcloth-chain/app/v0/staking"
+	"github.com/netcloth/netcloth-chain/app/v0/upgrade/types"
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+func TestMsgSignalUpgradeAndReadiness(t *testing.T) {
+	ctx, keeper, stakingKeeper, _ := CreateTestInput(t, 1000)
+	handler := NewHandler(keeper)
+	querier := NewQuerier(keeper)
+
+	description := staking.NewDescription("moniker", "identity", "website", "details")
+	for i := 0; i < 2; i++ {
+		validator := staking.NewValidator(sdk.ValAddress(Addrs[i]), PKs[i], description)
+		validator.Status = sdk.Bonded
+		validator.Tokens = sdk.TokensFromConsensusPower(int64(i + 1))
+		stakingKeeper.SetValidator(ctx, validator)
+		stakingKeeper.SetValidatorByPowerIndex(ctx, validator)
+	}
+
+	// no software upgrade in switch period
+	_, err := handler(ctx, NewMsgSignalUpgrade(sdk.ValAddress(Addrs[0]), 1))
+	require.True(t, types.ErrNoUpgradeInProcess.Is(err))
+	_, err = querier(ctx, []string{types.QueryReadiness}, abci.RequestQuery{})
+	require.Error(t, err)
+
+	require.NoError(t, keeper.SetAppUpgradeConfig(ctx, 1, 1, 1024, "software1"))
+
+	_, err = handler(ctx, NewMsgSignalUpgrade(sdk.ValAddress(Addrs[0]), 2))
+	require.True(t, types.ErrInvalidVersion.Is(err))
+
+	_, err = handler(ctx, NewMsgSignalUpgrade(sdk.ValAddress(Addrs[2]), 1))
+	require.True(t, types.ErrValidatorNotFound.Is(err))
+
+	ctx = ctx.WithBlockHeight(10)
+	_, err = handler(ctx, NewMsgSignalUpgrade(sdk.ValAddress(Addrs[1]), 1))
+	require.NoError(t, err)
+
+	bz, err := querier(ctx, []string{types.QueryReadiness}, abci.RequestQuery{})
+	require.NoError(t, err)
+
+	var readiness types.Readiness
+	keeper.cdc.MustUnmarshalJSON(bz, &readiness)
+	require.Equal(t, uint64(1), readiness.UpgradeConfig.ProposalID)
+	require.Equal(t, int64(2), readiness.SignalsVotingPower)
+	require.Equal(t, int64(3), readiness.TotalVotingPower)
+	require.Equal(t, sdk.NewDec(2).Quo(sdk.NewDec(3)), readiness.Ratio)
+	require.Equal(t, sdk.NewDecWithPrec(7, 1), readiness.Threshold)
+	require.False(t, readiness.Passes)
+
+	require.Len(t, readiness.Validators, 2)
+	require.Equal(t, sdk.ValAddress(Addrs[1]), readiness.Validators[0].OperatorAddress)
+	require.True(t, readiness.Validators[0].Signalled)
+	require.Equal(t, uint64(10), readiness.Validators[0].LastSignalHeight)
+	require.False(t, readiness.Validators[1].Signalled)
+
+	// the tally counts the explicit signals
+	require.False(t, tally(ctx, 1, keeper, readiness.Threshold))
+	_, err = handler(ctx, NewMsgSignalUpgrade(sdk.ValAddress(Addrs[0]), 1))
+	require.NoError(t, err)
+	require.True(t, tally(ctx, 1, keeper, readiness.Threshold))
+}
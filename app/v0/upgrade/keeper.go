@@ -1,6 +1,9 @@
 package upgrade
 
 import (
+	"time"
+
+	"github.com/netcloth/netcloth-chain/app/v0/guardian"
 	"github.com/netcloth/netcloth-chain/app/v0/staking"
 	"github.com/netcloth/netcloth-chain/app/v0/staking/exported"
 	"github.com/netcloth/netcloth-chain/app/v0/upgrade/types"
@@ -14,14 +17,16 @@ type Keeper struct {
 	cdc            *codec.Codec
 	protocolKeeper sdk.ProtocolKeeper
 	sk             staking.Keeper
+	gk             guardian.Keeper
 }
 
-func NewKeeper(cdc *codec.Codec, key sdk.StoreKey, protocolKeeper sdk.ProtocolKeeper, sk staking.Keeper) Keeper {
+func NewKeeper(cdc *codec.Codec, key sdk.StoreKey, protocolKeeper sdk.ProtocolKeeper, sk staking.Keeper, gk guardian.Keeper) Keeper {
 	keeper := Keeper{
 		key,
 		cdc,
 		protocolKeeper,
 		sk,
+		gk,
 	}
 	return keeper
 }
@@ -47,9 +52,21 @@ func (k Keeper) AddNewVersionInfo(ctx sdk.Context, versionInfo types.VersionInfo
 	}
 }
 
+// GetVersionInfo returns the version info of the software upgrade proposal
+func (k Keeper) GetVersionInfo(ctx sdk.Context, proposalID uint64) (versionInfo types.VersionInfo, found bool) {
+	kvStore := ctx.KVStore(k.storeKey)
+	bz := kvStore.Get(types.GetProposalIDKey(proposalID))
+	if bz == nil {
+		return versionInfo, false
+	}
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &versionInfo)
+	return versionInfo, true
+}
+
+// SetSignal records the signal of the validator for the protocol version at the current height
 func (k Keeper) SetSignal(ctx sdk.Context, protocol uint64, address string) {
 	kvStore := ctx.KVStore(k.storeKey)
-	cmsgBytes, err := k.cdc.MarshalBinaryLengthPrefixed(true)
+	cmsgBytes, err := k.cdc.MarshalBinaryLengthPrefixed(uint64(ctx.BlockHeight()))
 	if err != nil {
 		panic(err)
 	}
@@ -57,17 +74,19 @@ func (k Keeper) SetSignal(ctx sdk.Context, protocol uint64, address string) {
 }
 
 func (k Keeper) GetSignal(ctx sdk.Context, protocol uint64, address string) bool {
+	_, found := k.GetSignalHeight(ctx, protocol, address)
+	return found
+}
+
+// GetSignalHeight returns the height of the last signal of the validator for the protocol version
+func (k Keeper) GetSignalHeight(ctx sdk.Context, protocol uint64, address string) (height uint64, found bool) {
 	kvStore := ctx.KVStore(k.storeKey)
-	flagBytes := kvStore.Get(types.GetSignalKey(protocol, address))
-	if flagBytes != nil {
-		var flag bool
-		err := k.cdc.UnmarshalBinaryLengthPrefixed(flagBytes, &flag)
-		if err != nil {
-			panic(err)
-		}
-		return true
+	bz := kvStore.Get(types.GetSignalKey(protocol, address))
+	if bz == nil {
+		return 0, false
 	}
-	return false
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &height)
+	return height, true
 }
 
 func (k Keeper) DeleteSignal(ctx sdk.Context, protocol uint64, address string) bool {
@@ -79,6 +98,85 @@ func (k Keeper) DeleteSignal(ctx sdk.Context, protocol uint64, address string) b
 	return false
 }
 
+// DeleteSignals deletes all the signals of the protocol version
+func (k Keeper) DeleteSignals(ctx sdk.Context, protocol uint64) {
+	kvStore := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(kvStore, types.GetSignalPrefixKey(protocol))
+	defer iterator.Close()
+
+	var keys [][]byte
+	for ; iterator.Valid(); iterator.Next() {
+		keys = append(keys, iterator.Key())
+	}
+	for _, key := range keys {
+		kvStore.Delete(key)
+	}
+}
+
+// GetUpgradeConfig returns the software upgrade in switch period of the proposal
+func (k Keeper) GetUpgradeConfig(ctx sdk.Context, proposalID uint64) (sdk.UpgradeConfig, error) {
+	upgradeConfig, found := k.protocolKeeper.GetUpgradeConfig(ctx)
+	if !found {
+		return upgradeConfig, types.ErrNoUpgradeInProcess
+	}
+	if upgradeConfig.ProposalID != proposalID {
+		return upgradeConfig, sdkerrors.Wrapf(types.ErrInvalidProposalID, "switch period of proposal %d, got %d", upgradeConfig.ProposalID, proposalID)
+	}
+	return upgradeConfig, nil
+}
+
+// CancelUpgrade clears the software upgrade in switch period and records it as cancelled
+func (k Keeper) CancelUpgrade(ctx sdk.Context, proposalID uint64) (sdk.UpgradeConfig, error) {
+	upgradeConfig, err := k.GetUpgradeConfig(ctx, proposalID)
+	if err != nil {
+		return upgradeConfig, err
+	}
+
+	k.AddNewVersionInfo(ctx, types.NewCancelledVersionInfo(upgradeConfig))
+	k.DeleteSignals(ctx, upgradeConfig.Protocol.Version)
+	k.protocolKeeper.ClearUpgradeConfig(ctx)
+	return upgradeConfig, nil
+}
+
+// RescheduleUpgrade moves the software upgrade in switch period to the switch height, or to the
+// switch time if set, the upgrade then switching by height or by time accordingly
+func (k Keeper) RescheduleUpgrade(ctx sdk.Context, proposalID, switchHeight uint64, switchTime time.Time) error {
+	upgradeConfig, err := k.GetUpgradeConfig(ctx, proposalID)
+	if err != nil {
+		return err
+	}
+
+	if !switchTime.IsZero() {
+		if !switchTime.After(ctx.BlockHeader().Time) {
+			return sdkerrors.Wrapf(types.ErrInvalidSwitchTime, "switch time %s not after block time %s", switchTime, ctx.BlockHeader().Time)
+		}
+		switchHeight = 0
+	} else if switchHeight <= uint64(ctx.BlockHeight()) {
+		return sdkerrors.Wrapf(types.ErrInvalidSwitchHeight, "switch height %d not after current height %d", switchHeight, ctx.BlockHeight())
+	}
+
+	upgradeConfig.Protocol.Height = switchHeight
+	upgradeConfig.Protocol.SwitchTime = switchTime
+	k.protocolKeeper.SetUpgradeConfig(ctx, upgradeConfig)
+	return nil
+}
+
+// GetReadiness returns the signals of the bonded validators for the software upgrade in switch period
+func (k Keeper) GetReadiness(ctx sdk.Context) (types.Readiness, error) {
+	upgradeConfig, found := k.protocolKeeper.GetUpgradeConfig(ctx)
+	if !found {
+		return types.Readiness{}, types.ErrNoUpgradeInProcess
+	}
+
+	readiness := types.NewReadiness(upgradeConfig)
+	k.IterateBondedValidatorsByPower(ctx, func(index int64, validator exported.ValidatorI) (stop bool) {
+		height, signalled := k.GetSignalHeight(ctx, upgradeConfig.Protocol.Version, validator.GetConsAddr().String())
+		readiness.AddValidator(types.NewValidatorReadiness(validator.GetOperator(), validator.GetConsAddr(), validator.GetConsensusPower(), signalled, height))
+		return false
+	})
+	return readiness, nil
+}
+
 // IterateBondedValidatorsByPower iterates bonded validators by power
 func (k Keeper) IterateBondedValidatorsByPower(ctx sdk.Context,
 	fn func(index int64, validator exported.ValidatorI) (stop bool)) {
@@ -27,7 +27,8 @@ func (a AppModuleBasic) Name() string {
 	return upgtypes.ModuleName
 }
 
-func (a AppModuleBasic) RegisterCodec(*codec.Codec) {
+func (a AppModuleBasic) RegisterCodec(cdc *codec.Codec) {
+	upgtypes.RegisterCodec(cdc)
 }
 
 func (a AppModuleBasic) DefaultGenesis() json.RawMessage {
@@ -44,8 +45,8 @@ func (a AppModuleBasic) RegisterRESTRoutes(ctx context.CLIContext, rtr *mux.Rout
 	rest.RegisterRoutes(ctx, rtr)
 }
 
-func (a AppModuleBasic) GetTxCmd(*codec.Codec) *cobra.Command {
-	return nil
+func (a AppModuleBasic) GetTxCmd(cdc *codec.Codec) *cobra.Command {
+	return cli.GetTxCmd(cdc)
 }
 
 func (a AppModuleBasic) GetQueryCmd(cdc *codec.Codec) *cobra.Command {
@@ -88,7 +89,7 @@ func (a AppModule) Route() string {
 }
 
 func (a AppModule) NewHandler() sdk.Handler {
-	return nil
+	return NewHandler(a.keeper)
 }
 
 func (a AppModule) QuerierRoute() string {
@@ -96,7 +97,7 @@ func (a AppModule) QuerierRoute() string {
 }
 
 func (a AppModule) NewQuerierHandler() sdk.Querier {
-	return nil
+	return NewQuerier(a.keeper)
 }
 
 func (a AppModule) BeginBlock(sdk.Context, types.RequestBeginBlock) {
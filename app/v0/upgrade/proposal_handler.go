@@ -0,0 +1,70 @@
+package upgrade
+
+import (
+	"fmt"
+	"time"
+
+	govtypes "github.com/netcloth/netcloth-chain/app/v0/gov/types"
+	"github.com/netcloth/netcloth-chain/app/v0/upgrade/types"
+	sdk "github.com/netcloth/netcloth-chain/types"
+	sdkerrors "github.com/netcloth/netcloth-chain/types/errors"
+)
+
+// NewUpgradeProposalHandler returns the handler of the upgrade governance proposals
+func NewUpgradeProposalHandler(k Keeper) govtypes.Handler {
+	return func(ctx sdk.Context, content govtypes.Content, pid uint64, proposer sdk.AccAddress) error {
+		switch c := content.(type) {
+		case types.CancelSoftwareUpgradeProposal:
+			return handleCancelSoftwareUpgradeProposal(ctx, k, c, pid, proposer)
+
+		default:
+			return sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unrecognized upgrade proposal content type: %T", c)
+		}
+	}
+}
+
+// handleCancelSoftwareUpgradeProposal cancels or reschedules the software upgrade in switch period,
+// the proposer must be a profiler, as for the software upgrade proposals
+func handleCancelSoftwareUpgradeProposal(ctx sdk.Context, k Keeper, p types.CancelSoftwareUpgradeProposal, pid uint64, proposer sdk.AccAddress) error {
+	if err := p.ValidateBasic(); err != nil {
+		return err
+	}
+
+	if _, found := k.gk.GetProfiler(ctx, proposer); !found {
+		return sdkerrors.Wrapf(types.ErrInvalidProfiler, "proposer %s is not a profiler", proposer)
+	}
+
+	if p.IsReschedule() {
+		if err := k.RescheduleUpgrade(ctx, p.ProposalID, p.SwitchHeight, p.SwitchTime); err != nil {
+			return err
+		}
+
+		switchAttribute := sdk.NewAttribute(types.AttributeKeySwitchHeight, fmt.Sprintf("%d", p.SwitchHeight))
+		if !p.SwitchTime.IsZero() {
+			switchAttribute = sdk.NewAttribute(types.AttributeKeySwitchTime, p.SwitchTime.UTC().Format(time.RFC3339))
+		}
+
+		ctx.Logger().Info("Software Upgrade is rescheduled, ", "proposal", p.ProposalID, "switch height", p.SwitchHeight, "switch time", p.SwitchTime)
+		ctx.EventManager().EmitEvent(sdk.NewEvent(
+			types.EventTypeRescheduleSoftwareUpgrade,
+			sdk.NewAttribute(types.AttributeKeyProposalID, fmt.Sprintf("%d", p.ProposalID)),
+			sdk.NewAttribute(types.AttributeKeyCancelProposalID, fmt.Sprintf("%d", pid)),
+			switchAttribute,
+		))
+		return nil
+	}
+
+	upgradeConfig, err := k.CancelUpgrade(ctx, p.ProposalID)
+	if err != nil {
+		return err
+	}
+
+	ctx.Logger().Info("Software Upgrade is cancelled, ", "proposal", p.ProposalID, "version", upgradeConfig.Protocol.Version)
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeCancelSoftwareUpgrade,
+		sdk.NewAttribute(types.AttributeKeyProposalID, fmt.Sprintf("%d", p.ProposalID)),
+		sdk.NewAttribute(types.AttributeKeyCancelProposalID, fmt.Sprintf("%d", pid)),
+		sdk.NewAttribute(types.AttributeKeyVersion, fmt.Sprintf("%d", upgradeConfig.Protocol.Version)),
+	))
+	return nil
+}
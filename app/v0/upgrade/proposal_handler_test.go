@@ -0,0 +1,88 @@
+package upgrade
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	govtypes "github.com/netcloth/netcloth-chain/app/v0/gov/types"
+	"github.com/netcloth/netcloth-chain/app/v0/guardian"
+	"github.com/netcloth/netcloth-chain/app/v0/upgrade/types"
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+func TestCancelSoftwareUpgradeProposal(t *testing.T) {
+	ctx, keeper, _, _ := CreateTestInput(t, 1000)
+	ctx = ctx.WithBlockHeight(100)
+	profiler := Addrs[0]
+	require.NoError(t, keeper.gk.AddProfiler(ctx, guardian.NewGuardian("profiler", guardian.Genesis, profiler, profiler)))
+	proposalHandler := NewUpgradeProposalHandler(keeper)
+	handler := func(ctx sdk.Context, content govtypes.Content, pid uint64, _ sdk.AccAddress) error {
+		return proposalHandler(ctx, content, pid, profiler)
+	}
+
+	// no software upgrade in switch period
+	err := handler(ctx, NewCancelSoftwareUpgradeProposal("title", "desc", 1, 0), 2, nil)
+	require.True(t, types.ErrNoUpgradeInProcess.Is(err))
+
+	require.NoError(t, keeper.SetAppUpgradeConfig(ctx, 1, 1, 1024, "software1"))
+	keeper.SetSignal(ctx, 1, "validator1")
+
+	// the proposer must be a profiler, to cancel as to reschedule
+	err = proposalHandler(ctx, NewCancelSoftwareUpgradeProposal("title", "desc", 1, 0), 2, Addrs[1])
+	require.True(t, types.ErrInvalidProfiler.Is(err))
+	err = proposalHandler(ctx, NewCancelSoftwareUpgradeProposal("title", "desc", 1, 2048), 2, Addrs[1])
+	require.True(t, types.ErrInvalidProfiler.Is(err))
+
+	// the proposal id must match the software upgrade in switch period
+	err = handler(ctx, NewCancelSoftwareUpgradeProposal("title", "desc", 3, 0), 2, nil)
+	require.True(t, types.ErrInvalidProposalID.Is(err))
+
+	// reschedule to a past height
+	err = handler(ctx, NewCancelSoftwareUpgradeProposal("title", "desc", 1, 100), 2, nil)
+	require.True(t, types.ErrInvalidSwitchHeight.Is(err))
+
+	// reschedule
+	require.NoError(t, handler(ctx, NewCancelSoftwareUpgradeProposal("title", "desc", 1, 2048), 2, nil))
+	upgradeConfig, found := keeper.protocolKeeper.GetUpgradeConfig(ctx)
+	require.True(t, found)
+	require.Equal(t, uint64(2048), upgradeConfig.Protocol.Height)
+	require.Equal(t, types.EventTypeRescheduleSoftwareUpgrade, ctx.EventManager().Events()[0].Type)
+
+	// reschedule by time, then back by height
+	switchTime := ctx.BlockTime().Add(time.Hour)
+	both := NewTimedCancelSoftwareUpgradeProposal("title", "desc", 1, switchTime)
+	both.SwitchHeight = 2048
+	require.True(t, types.ErrInvalidSwitchTime.Is(handler(ctx, both, 2, nil)))
+	err = handler(ctx, NewTimedCancelSoftwareUpgradeProposal("title", "desc", 1, ctx.BlockTime()), 2, nil)
+	require.True(t, types.ErrInvalidSwitchTime.Is(err))
+
+	require.NoError(t, handler(ctx, NewTimedCancelSoftwareUpgradeProposal("title", "desc", 1, switchTime), 2, nil))
+	upgradeConfig, _ = keeper.protocolKeeper.GetUpgradeConfig(ctx)
+	require.True(t, upgradeConfig.Protocol.SwitchByTime())
+	require.True(t, switchTime.Equal(upgradeConfig.Protocol.SwitchTime))
+	require.Equal(t, uint64(0), upgradeConfig.Protocol.Height)
+
+	require.NoError(t, handler(ctx, NewCancelSoftwareUpgradeProposal("title", "desc", 1, 2048), 2, nil))
+	upgradeConfig, _ = keeper.protocolKeeper.GetUpgradeConfig(ctx)
+	require.False(t, upgradeConfig.Protocol.SwitchByTime())
+	require.Equal(t, uint64(2048), upgradeConfig.Protocol.Height)
+
+	// cancel
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	require.NoError(t, handler(ctx, NewCancelSoftwareUpgradeProposal("title", "desc", 1, 0), 3, nil))
+	_, found = keeper.protocolKeeper.GetUpgradeConfig(ctx)
+	require.False(t, found)
+	require.False(t, keeper.GetSignal(ctx, 1, "validator1"))
+	require.Equal(t, types.EventTypeCancelSoftwareUpgrade, ctx.EventManager().Events()[0].Type)
+
+	versionInfo, found := keeper.GetVersionInfo(ctx, 1)
+	require.True(t, found)
+	require.True(t, versionInfo.Cancelled)
+	require.False(t, versionInfo.Success)
+	require.Equal(t, uint64(2048), versionInfo.UpgradeInfo.Protocol.Height)
+
+	// a new software upgrade may be set after the cancel
+	require.NoError(t, keeper.SetAppUpgradeConfig(ctx, 4, 1, 4096, "software2"))
+}
@@ -0,0 +1,35 @@
+package upgrade
+
+import (
+	"errors"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/netcloth/netcloth-chain/app/v0/upgrade/types"
+	"github.com/netcloth/netcloth-chain/codec"
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+func NewQuerier(k Keeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, error) {
+		switch path[0] {
+		case types.QueryReadiness:
+			return queryReadiness(ctx, k)
+		default:
+			return nil, errors.New("unknown upgrade query endpoint")
+		}
+	}
+}
+
+func queryReadiness(ctx sdk.Context, k Keeper) ([]byte, error) {
+	readiness, err := k.GetReadiness(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	bz, err := codec.MarshalJSONIndent(k.cdc, readiness)
+	if err != nil {
+		return nil, err
+	}
+	return bz, nil
+}
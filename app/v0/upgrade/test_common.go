@@ -14,6 +14,7 @@ import (
 	"github.com/netcloth/netcloth-chain/app/v0/cipal"
 	distr "github.com/netcloth/netcloth-chain/app/v0/distribution"
 	"github.com/netcloth/netcloth-chain/app/v0/gov"
+	"github.com/netcloth/netcloth-chain/app/v0/guardian"
 	"github.com/netcloth/netcloth-chain/app/v0/ipal"
 	"github.com/netcloth/netcloth-chain/app/v0/mint"
 	"github.com/netcloth/netcloth-chain/app/v0/params"
@@ -78,6 +79,7 @@ func CreateTestInput(t *testing.T, initPower int64) (ctx sdk.Context, keeper Kee
 		params.StoreKey,
 		cipal.StoreKey,
 		ipal.StoreKey,
+		guardian.StoreKey,
 		types.StoreKey,
 		types.CodeKey,
 		types.LogKey,
@@ -140,6 +142,7 @@ func CreateTestInput(t *testing.T, initPower int64) (ctx sdk.Context, keeper Kee
 		keys[types.StoreKey],
 		protocolKeeper,
 		stakingKeeper,
+		guardian.NewKeeper(cdc, keys[guardian.StoreKey]),
 	)
 
 	supplyKeeper.SetModuleAccount(ctx, feeCollectorAcc)
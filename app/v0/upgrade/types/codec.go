@@ -0,0 +1,21 @@
+package types
+
+import (
+	"github.com/netcloth/netcloth-chain/codec"
+)
+
+// RegisterCodec - register the sdk message type
+func RegisterCodec(cdc *codec.Codec) {
+	cdc.RegisterConcrete(CancelSoftwareUpgradeProposal{}, "nch/CancelSoftwareUpgradeProposal", nil)
+	cdc.RegisterConcrete(MsgSignalUpgrade{}, "nch/MsgSignalUpgrade", nil)
+}
+
+// ModuleCdc generic sealed codec to be used throughout module
+var ModuleCdc *codec.Codec
+
+func init() {
+	ModuleCdc = codec.New()
+	RegisterCodec(ModuleCdc)
+	codec.RegisterCrypto(ModuleCdc)
+	ModuleCdc.Seal()
+}
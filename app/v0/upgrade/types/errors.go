@@ -0,0 +1,15 @@
+package types
+
+import (
+	sdkerrors "github.com/netcloth/netcloth-chain/types/errors"
+)
+
+var (
+	ErrNoUpgradeInProcess  = sdkerrors.New(ModuleName, 1, "no software upgrade in switch period")
+	ErrInvalidProposalID   = sdkerrors.New(ModuleName, 2, "proposal id not match the software upgrade in switch period")
+	ErrInvalidSwitchHeight = sdkerrors.New(ModuleName, 3, "invalid software upgrade switch height")
+	ErrInvalidVersion      = sdkerrors.New(ModuleName, 4, "version not match the software upgrade in switch period")
+	ErrValidatorNotFound   = sdkerrors.New(ModuleName, 5, "validator not found")
+	ErrInvalidSwitchTime   = sdkerrors.New(ModuleName, 6, "invalid software upgrade switch time")
+	ErrInvalidProfiler     = sdkerrors.New(ModuleName, 7, "invalid software upgrade profiler")
+)
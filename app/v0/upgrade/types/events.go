@@ -0,0 +1,16 @@
+package types
+
+const (
+	EventTypeCancelSoftwareUpgrade     = "cancel_software_upgrade"
+	EventTypeRescheduleSoftwareUpgrade = "reschedule_software_upgrade"
+	EventTypeSignalUpgrade             = "signal_upgrade"
+
+	AttributeKeyProposalID       = "proposal_id"
+	AttributeKeyCancelProposalID = "cancel_proposal_id"
+	AttributeKeyVersion          = "version"
+	AttributeKeySwitchHeight     = "switch_height"
+	AttributeKeySwitchTime       = "switch_time"
+	AttributeKeyValidator        = "validator"
+
+	AttributeValueCategory = ModuleName
+)
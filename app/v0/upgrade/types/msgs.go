@@ -0,0 +1,47 @@
+package types
+
+import (
+	sdk "github.com/netcloth/netcloth-chain/types"
+	sdkerrors "github.com/netcloth/netcloth-chain/types/errors"
+)
+
+const (
+	TypeMsgSignalUpgrade = "signal_upgrade"
+)
+
+var _ sdk.Msg = MsgSignalUpgrade{}
+
+// MsgSignalUpgrade - a validator signals it is ready to run the version of the software upgrade in switch period
+type MsgSignalUpgrade struct {
+	ValidatorAddress sdk.ValAddress `json:"validator_address" yaml:"validator_address"`
+	Version          uint64         `json:"version" yaml:"version"`
+}
+
+func NewMsgSignalUpgrade(validator sdk.ValAddress, version uint64) MsgSignalUpgrade {
+	return MsgSignalUpgrade{
+		ValidatorAddress: validator,
+		Version:          version,
+	}
+}
+
+func (m MsgSignalUpgrade) Route() string { return RouterKey }
+
+func (m MsgSignalUpgrade) Type() string { return TypeMsgSignalUpgrade }
+
+func (m MsgSignalUpgrade) ValidateBasic() error {
+	if m.ValidatorAddress.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing validator address")
+	}
+	if m.Version == 0 {
+		return sdkerrors.Wrap(ErrInvalidVersion, "version must be positive")
+	}
+	return nil
+}
+
+func (m MsgSignalUpgrade) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(m))
+}
+
+func (m MsgSignalUpgrade) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{sdk.AccAddress(m.ValidatorAddress)}
+}
@@ -0,0 +1,93 @@
+package types
+
+import (
+	"fmt"
+	"time"
+
+	govtypes "github.com/netcloth/netcloth-chain/app/v0/gov/types"
+	sdkerrors "github.com/netcloth/netcloth-chain/types/errors"
+)
+
+const (
+	// ProposalTypeCancelSoftwareUpgrade defines the type for a CancelSoftwareUpgradeProposal
+	ProposalTypeCancelSoftwareUpgrade = "CancelSoftwareUpgrade"
+)
+
+// Assert CancelSoftwareUpgradeProposal implements govtypes.Content at compile-time
+var _ govtypes.Content = CancelSoftwareUpgradeProposal{}
+
+func init() {
+	govtypes.RegisterProposalType(ProposalTypeCancelSoftwareUpgrade)
+	govtypes.RegisterProposalTypeCodec(CancelSoftwareUpgradeProposal{}, "nch/CancelSoftwareUpgradeProposal")
+}
+
+// CancelSoftwareUpgradeProposal cancels the software upgrade in switch period, or reschedules it
+// to a new switch height or switch time if SwitchHeight or SwitchTime is set
+type CancelSoftwareUpgradeProposal struct {
+	Title        string    `json:"title" yaml:"title"`
+	Description  string    `json:"description" yaml:"description"`
+	ProposalID   uint64    `json:"proposal_id" yaml:"proposal_id"`     // id of the software upgrade proposal
+	SwitchHeight uint64    `json:"switch_height" yaml:"switch_height"` // new switch height, 0 to cancel
+	SwitchTime   time.Time `json:"switch_time" yaml:"switch_time"`     // alternative to SwitchHeight, the new switch time
+}
+
+// NewCancelSoftwareUpgradeProposal creates a new cancel software upgrade proposal.
+func NewCancelSoftwareUpgradeProposal(title, description string, proposalID, switchHeight uint64) CancelSoftwareUpgradeProposal {
+	return CancelSoftwareUpgradeProposal{
+		Title:        title,
+		Description:  description,
+		ProposalID:   proposalID,
+		SwitchHeight: switchHeight,
+	}
+}
+
+// NewTimedCancelSoftwareUpgradeProposal creates a proposal rescheduling the software upgrade to a switch time
+func NewTimedCancelSoftwareUpgradeProposal(title, description string, proposalID uint64, switchTime time.Time) CancelSoftwareUpgradeProposal {
+	return CancelSoftwareUpgradeProposal{
+		Title:       title,
+		Description: description,
+		ProposalID:  proposalID,
+		SwitchTime:  switchTime,
+	}
+}
+
+// GetTitle returns the title of a cancel software upgrade proposal.
+func (p CancelSoftwareUpgradeProposal) GetTitle() string { return p.Title }
+
+// GetDescription returns the description of a cancel software upgrade proposal.
+func (p CancelSoftwareUpgradeProposal) GetDescription() string { return p.Description }
+
+// ProposalRoute returns the routing key of a cancel software upgrade proposal.
+func (p CancelSoftwareUpgradeProposal) ProposalRoute() string { return RouterKey }
+
+// ProposalType returns the type of a cancel software upgrade proposal.
+func (p CancelSoftwareUpgradeProposal) ProposalType() string {
+	return ProposalTypeCancelSoftwareUpgrade
+}
+
+// IsReschedule returns whether the proposal moves the switch height or time instead of cancelling the upgrade
+func (p CancelSoftwareUpgradeProposal) IsReschedule() bool {
+	return p.SwitchHeight != 0 || !p.SwitchTime.IsZero()
+}
+
+// ValidateBasic runs basic stateless validity checks
+func (p CancelSoftwareUpgradeProposal) ValidateBasic() error {
+	if p.ProposalID == 0 {
+		return ErrInvalidProposalID
+	}
+	if p.SwitchHeight != 0 && !p.SwitchTime.IsZero() {
+		return sdkerrors.Wrap(ErrInvalidSwitchTime, "only one of switch height and switch time can be set")
+	}
+	return govtypes.ValidateAbstract(p)
+}
+
+// String implements the Stringer interface.
+func (p CancelSoftwareUpgradeProposal) String() string {
+	return fmt.Sprintf(`Cancel Software Upgrade Proposal:
+  Title:         %s
+  Description:   %s
+  Proposal ID:   %d
+  Switch Height: %d
+  Switch Time:   %s
+`, p.Title, p.Description, p.ProposalID, p.SwitchHeight, p.SwitchTime.UTC().Format(time.RFC3339))
+}
@@ -0,0 +1,5 @@
+package types
+
+const (
+	QueryReadiness = "readiness"
+)
@@ -0,0 +1,81 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+// ValidatorReadiness - the signal of a bonded validator for the software upgrade in switch period
+type ValidatorReadiness struct {
+	OperatorAddress  sdk.ValAddress  `json:"operator_address" yaml:"operator_address"`
+	ConsAddress      sdk.ConsAddress `json:"cons_address" yaml:"cons_address"`
+	VotingPower      int64           `json:"voting_power" yaml:"voting_power"`
+	Signalled        bool            `json:"signalled" yaml:"signalled"`
+	LastSignalHeight uint64          `json:"last_signal_height" yaml:"last_signal_height"`
+}
+
+func NewValidatorReadiness(operator sdk.ValAddress, consAddr sdk.ConsAddress, power int64, signalled bool, height uint64) ValidatorReadiness {
+	return ValidatorReadiness{
+		OperatorAddress:  operator,
+		ConsAddress:      consAddr,
+		VotingPower:      power,
+		Signalled:        signalled,
+		LastSignalHeight: height,
+	}
+}
+
+func (v ValidatorReadiness) String() string {
+	return fmt.Sprintf("%s  power: %d  signalled: %v  last signal height: %d", v.OperatorAddress, v.VotingPower, v.Signalled, v.LastSignalHeight)
+}
+
+// Readiness - the signals of the bonded validators for the software upgrade in switch period,
+// the ratio is weighted by voting power and the upgrade switches if it is greater than the threshold
+type Readiness struct {
+	UpgradeConfig      sdk.UpgradeConfig    `json:"upgrade_config" yaml:"upgrade_config"`
+	SignalsVotingPower int64                `json:"signals_voting_power" yaml:"signals_voting_power"`
+	TotalVotingPower   int64                `json:"total_voting_power" yaml:"total_voting_power"`
+	Ratio              sdk.Dec              `json:"ratio" yaml:"ratio"`
+	Threshold          sdk.Dec              `json:"threshold" yaml:"threshold"`
+	Passes             bool                 `json:"passes" yaml:"passes"`
+	Validators         []ValidatorReadiness `json:"validators" yaml:"validators"`
+}
+
+func NewReadiness(upgradeConfig sdk.UpgradeConfig) Readiness {
+	return Readiness{
+		UpgradeConfig: upgradeConfig,
+		Ratio:         sdk.ZeroDec(),
+		Threshold:     upgradeConfig.Protocol.Threshold,
+		Validators:    []ValidatorReadiness{},
+	}
+}
+
+// AddValidator adds the signal of a bonded validator
+func (r *Readiness) AddValidator(v ValidatorReadiness) {
+	r.TotalVotingPower += v.VotingPower
+	if v.Signalled {
+		r.SignalsVotingPower += v.VotingPower
+	}
+	r.Validators = append(r.Validators, v)
+
+	if r.TotalVotingPower > 0 {
+		r.Ratio = sdk.NewDec(r.SignalsVotingPower).Quo(sdk.NewDec(r.TotalVotingPower))
+	}
+	r.Passes = r.Ratio.GT(r.Threshold)
+}
+
+func (r Readiness) String() string {
+	var validators []string
+	for _, v := range r.Validators {
+		validators = append(validators, "  "+v.String())
+	}
+	return fmt.Sprintf(`Upgrade Readiness:
+  Upgrade:    %s
+  Signals:    %d/%d
+  Ratio:      %s
+  Threshold:  %s
+  Passes:     %v
+Validators:
+%s`, r.UpgradeConfig, r.SignalsVotingPower, r.TotalVotingPower, r.Ratio, r.Threshold, r.Passes, strings.Join(validators, "\n"))
+}
@@ -7,11 +7,19 @@ import (
 type VersionInfo struct {
 	UpgradeInfo sdk.UpgradeConfig
 	Success     bool
+	Cancelled   bool // cancelled by governance before the switch height
 }
 
 func NewVersionInfo(upgradeConfig sdk.UpgradeConfig, success bool) VersionInfo {
 	return VersionInfo{
-		upgradeConfig,
-		success,
+		UpgradeInfo: upgradeConfig,
+		Success:     success,
+	}
+}
+
+func NewCancelledVersionInfo(upgradeConfig sdk.UpgradeConfig) VersionInfo {
+	return VersionInfo{
+		UpgradeInfo: upgradeConfig,
+		Cancelled:   true,
 	}
 }
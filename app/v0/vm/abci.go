@@ -7,6 +7,22 @@ import (
 	abci "github.com/tendermint/tendermint/abci/types"
 )
 
+// BeginBlocker records the hash of the last block, so that the hashes of the
+// last NumBlockHashes blocks are available to the BLOCKHASH opcode. On the chains
+// started before the log indexes, it records the height the logs are indexed from.
+func BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock, keeper keeper.Keeper) {
+	if _, found := keeper.GetLogsIndexStartHeight(ctx); !found {
+		keeper.SetLogsIndexStartHeight(ctx, uint64(ctx.BlockHeight()))
+	}
+
+	lastBlockHash := req.Header.LastBlockId.Hash
+	if len(lastBlockHash) == 0 || req.Header.Height <= 1 {
+		return
+	}
+
+	keeper.SetBlockHash(ctx, uint64(req.Header.Height-1), sdk.BytesToHash(lastBlockHash))
+}
+
 func EndBlocker(ctx sdk.Context, keeper keeper.Keeper) []abci.ValidatorUpdate {
 	// Gas costs are handled within msg handler so costs should be ignored
 	ctx = ctx.WithBlockGasMeter(sdk.NewInfiniteGasMeter())
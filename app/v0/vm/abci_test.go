@@ -0,0 +1,36 @@
+package vm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	keep "github.com/netcloth/netcloth-chain/app/v0/vm/keeper"
+	"github.com/netcloth/netcloth-chain/app/v0/vm/types"
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+func TestBeginBlockerBlockHashes(t *testing.T) {
+	ctx, _, vmKeeper, _ := keep.CreateTestInput(t, false, int64(1000000))
+
+	hashOf := func(height int64) []byte {
+		return sdk.BigToHash(sdk.NewInt(height).BigInt()).Bytes()
+	}
+
+	last := int64(types.NumBlockHashes + 10)
+	for height := int64(1); height <= last; height++ {
+		header := abci.Header{Height: height}
+		if height > 1 {
+			header.LastBlockId = abci.BlockID{Hash: hashOf(height - 1)}
+		}
+		BeginBlocker(ctx.WithBlockHeader(header), abci.RequestBeginBlock{Header: header}, vmKeeper)
+	}
+
+	// only the hashes of the last NumBlockHashes blocks are kept
+	for height := last - types.NumBlockHashes; height < last; height++ {
+		require.Equal(t, hashOf(height), vmKeeper.GetBlockHash(ctx, uint64(height)).Bytes(), height)
+	}
+	require.Equal(t, sdk.Hash{}, vmKeeper.GetBlockHash(ctx, uint64(last-types.NumBlockHashes-1)))
+	require.Equal(t, sdk.Hash{}, vmKeeper.GetBlockHash(ctx, uint64(last)))
+}
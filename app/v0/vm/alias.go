@@ -18,17 +18,20 @@ const (
 )
 
 type (
-	Keeper        = keeper.Keeper
-	MsgContract   = types.MsgContract
-	CommitStateDB = types.CommitStateDB
-	Log           = types.Log
+	Keeper              = keeper.Keeper
+	MsgContract         = types.MsgContract
+	MsgContractRegister = types.MsgContractRegister
+	ContractInfo        = types.ContractInfo
+	CommitStateDB       = types.CommitStateDB
+	Log                 = types.Log
 
 	GenesisState = types.GenesisState
 )
 
 var (
-	NewKeeper        = keeper.NewKeeper
-	NewCommitStateDB = types.NewCommitStateDB
+	NewKeeper              = keeper.NewKeeper
+	NewMsgContractRegister = types.NewMsgContractRegister
+	NewCommitStateDB       = types.NewCommitStateDB
 
 	CreateAddress  = common.CreateAddress
 	CreateAddress2 = common.CreateAddress2
@@ -52,4 +55,9 @@ var (
 	ErrGasUintOverflow          = types.ErrGasUintOverflow
 	ErrNoPayload                = types.ErrNoPayload
 	ErrWrongCtx                 = types.ErrWrongCtx
+	ErrInvalidNativeInput       = types.ErrInvalidNativeInput
+	ErrInvalidNativeCall        = types.ErrInvalidNativeCall
+	ErrInvalidABI               = types.ErrInvalidABI
+	ErrInvalidMetadataHash      = types.ErrInvalidMetadataHash
+	ErrUnauthorizedRegistration = types.ErrUnauthorizedRegistration
 )
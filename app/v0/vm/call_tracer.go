@@ -0,0 +1,181 @@
+package vm
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/netcloth/netcloth-chain/app/v0/vm/types"
+	"github.com/netcloth/netcloth-chain/hexutil"
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+// CallTracer is a Tracer recording the call tree of a transaction. The inner calls
+// are followed by the CALL, CALLCODE, DELEGATECALL, STATICCALL, CREATE and CREATE2
+// instructions, a call is done when the execution is back to the depth of its caller.
+type CallTracer struct {
+	root  *types.CallFrame
+	calls []*tracedCall // inner calls being executed, the innermost last
+}
+
+type tracedCall struct {
+	frame *types.CallFrame
+	depth int    // depth of the caller
+	base  uint64 // gas left to the caller after the call instruction, the forwarded gas excluded
+
+	// last instruction executed in the callee, for the gas used by a contract creation
+	lastOp  OpCode
+	lastGas uint64
+}
+
+var _ Tracer = (*CallTracer)(nil)
+
+// NewCallTracer returns a new call tracer
+func NewCallTracer() *CallTracer {
+	return &CallTracer{}
+}
+
+// CaptureStart implements the Tracer interface, it records the outermost call
+func (t *CallTracer) CaptureStart(from sdk.AccAddress, to sdk.AccAddress, create bool, input []byte, gas uint64, value *big.Int) error {
+	t.root = &types.CallFrame{
+		Type:  CALL.String(),
+		From:  from.String(),
+		To:    to.String(),
+		Value: value.String(),
+		Gas:   gas,
+		Input: hexutil.Encode(input),
+	}
+	if create {
+		t.root.Type = CREATE.String()
+	}
+
+	return nil
+}
+
+// CaptureState implements the Tracer interface, it closes the calls returned to the
+// current depth and opens the call of a call instruction
+func (t *CallTracer) CaptureState(env *EVM, pc uint64, op OpCode, gas, cost uint64, memory *Memory, stack *Stack, contract *Contract, depth int, err error) error {
+	for len(t.calls) > 0 && t.calls[len(t.calls)-1].depth >= depth {
+		t.exit(env, gas, stack)
+	}
+
+	if n := len(t.calls); n > 0 && t.calls[n-1].depth == depth-1 {
+		t.calls[n-1].lastOp, t.calls[n-1].lastGas = op, gas-cost
+	}
+
+	// the instruction failed before being executed
+	if err != nil {
+		return nil
+	}
+
+	var (
+		frame = &types.CallFrame{Type: op.String(), From: contract.Address().String()}
+		base  = gas - cost
+	)
+	switch op {
+	case CALL, CALLCODE:
+		frame.To = sdk.BigToAddress(stack.Back(1)).String()
+		frame.Value = stack.Back(2).String()
+		frame.Input = hexutil.Encode(memory.GetCopy(stack.Back(3).Int64(), stack.Back(4).Int64()))
+		frame.Gas = env.callGasTemp
+		if stack.Back(2).Sign() != 0 {
+			frame.Gas += CallStipend
+		}
+	case DELEGATECALL, STATICCALL:
+		frame.To = sdk.BigToAddress(stack.Back(1)).String()
+		frame.Input = hexutil.Encode(memory.GetCopy(stack.Back(2).Int64(), stack.Back(3).Int64()))
+		frame.Gas = env.callGasTemp
+	case CREATE, CREATE2:
+		frame.Value = stack.Back(0).String()
+		frame.Input = hexutil.Encode(memory.GetCopy(stack.Back(1).Int64(), stack.Back(2).Int64()))
+		frame.Gas = base - base/64
+	default:
+		return nil
+	}
+
+	t.calls = append(t.calls, &tracedCall{frame: frame, depth: depth, base: base})
+	return nil
+}
+
+// exit closes the innermost call, the stack and the gas are the ones of its caller
+// after the call instruction
+func (t *CallTracer) exit(env *EVM, gas uint64, stack *Stack) {
+	call := t.calls[len(t.calls)-1]
+	t.calls = t.calls[:len(t.calls)-1]
+
+	frame := call.frame
+	success := stack.len() > 0 && stack.peek().Sign() != 0
+
+	if in, ok := env.interpreter.(*EVMInterpreter); ok && len(in.returnData) > 0 {
+		frame.Output = hexutil.Encode(in.returnData)
+	}
+
+	switch frame.Type {
+	case CREATE.String(), CREATE2.String():
+		if success {
+			frame.To = sdk.BigToAddress(stack.peek()).String()
+		}
+
+		frame.GasUsed = frame.Gas
+		if success || call.lastOp == REVERT {
+			frame.GasUsed = frame.Gas - call.lastGas
+		}
+	default:
+		frame.GasUsed = frame.Gas
+		if returned := gas - call.base; gas >= call.base && returned <= frame.Gas {
+			frame.GasUsed = frame.Gas - returned
+		}
+	}
+
+	if !success {
+		frame.Error = ErrExecutionReverted.Error()
+		if call.lastOp != REVERT && frame.GasUsed == frame.Gas {
+			frame.Error = "execution failed"
+		}
+	}
+
+	t.addFrame(frame)
+}
+
+func (t *CallTracer) addFrame(frame *types.CallFrame) {
+	parent := t.root
+	if n := len(t.calls); n > 0 {
+		parent = t.calls[n-1].frame
+	}
+
+	if parent != nil {
+		parent.Calls = append(parent.Calls, frame)
+	}
+}
+
+// CaptureFault implements the Tracer interface
+func (t *CallTracer) CaptureFault(env *EVM, pc uint64, op OpCode, gas, cost uint64, memory *Memory, stack *Stack, contract *Contract, depth int, err error) error {
+	return nil
+}
+
+// CaptureEnd implements the Tracer interface, the calls not closed yet failed with the transaction
+func (t *CallTracer) CaptureEnd(output []byte, gasUsed uint64, d time.Duration, err error) error {
+	for len(t.calls) > 0 {
+		call := t.calls[len(t.calls)-1]
+		t.calls = t.calls[:len(t.calls)-1]
+
+		call.frame.GasUsed = call.frame.Gas
+		call.frame.Error = "execution failed"
+		t.addFrame(call.frame)
+	}
+
+	if t.root == nil {
+		return nil
+	}
+
+	t.root.GasUsed = gasUsed
+	t.root.Output = hexutil.Encode(output)
+	if err != nil {
+		t.root.Error = err.Error()
+	}
+	return nil
+}
+
+// CallFrame returns the call tree of the traced transaction
+func (t *CallTracer) CallFrame() *types.CallFrame {
+	return t.root
+}
@@ -1,12 +1,25 @@
 package cli
 
 const (
-	flagCodeFile     = "code_file"
-	flagAmount       = "amount"
-	flagArgs         = "args"
-	flagMethod       = "method"
-	flagContractAddr = "contract_addr"
-	flagAbiFile      = "abi_file"
-	flagShowCode     = "show_code"
-	flagAll          = "all"
+	flagCodeFile       = "code_file"
+	flagAmount         = "amount"
+	flagArgs           = "args"
+	flagMethod         = "method"
+	flagContractAddr   = "contract_addr"
+	flagAbiFile        = "abi_file"
+	flagShowCode       = "show_code"
+	flagAll            = "all"
+	flagAddress        = "address"
+	flagTopic          = "topic"
+	flagFromHeight     = "from-height"
+	flagToHeight       = "to-height"
+	flagTracer         = "tracer"
+	flagDisableMemory  = "disable-memory"
+	flagDisableStack   = "disable-stack"
+	flagDisableStorage = "disable-storage"
+	flagLimit          = "limit"
+	flagMsgIndex       = "msg-index"
+	flagMetadataHash   = "metadata_hash"
+	flagMetadataFile   = "metadata_file"
+	flagDecode         = "decode"
 )
@@ -11,8 +11,10 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 
+	"github.com/netcloth/netcloth-chain/app/v0/auth"
 	"github.com/netcloth/netcloth-chain/app/v0/vm/types"
 	"github.com/netcloth/netcloth-chain/client"
 	"github.com/netcloth/netcloth-chain/client/context"
@@ -37,8 +39,11 @@ func GetQueryCmd(queryRoute string, cdc *codec.Codec) *cobra.Command {
 		GetCmdQueryParams(cdc),
 		GetCmdQueryDBState(cdc),
 		GetCmdQueryCode(cdc),
+		GetCmdQueryContractInfo(cdc),
 		GetCmdGetStorage(cdc),
 		GetCmdGetLogs(cdc),
+		GetCmdFilterLogs(cdc),
+		GetCmdQueryTrace(cdc),
 		GetCmdQueryCreateFee(cdc),
 		GetCmdQueryCallFee(cdc),
 		GetCmdQueryCall(cdc),
@@ -159,6 +164,32 @@ $ %s query vm code [address]`, version.ClientName)),
 	}
 }
 
+func GetCmdQueryContractInfo(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "contract-info [address]",
+		Short: "Querying the abi and metadata hash registered for a contract",
+		Long: strings.TrimSpace(fmt.Sprintf(`Query the abi and metadata hash registered for a contract.
+Example:
+$ %s query vm contract-info [address]`, version.ClientName)),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			addr, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			info, err := QueryContractInfo(cliCtx, addr)
+			if err != nil {
+				return err
+			}
+
+			return cliCtx.PrintOutput(info)
+		},
+	}
+}
+
 func GetCmdGetStorage(cdc *codec.Codec) *cobra.Command {
 	return &cobra.Command{
 		Use:   "storage [account] [key]",
@@ -190,12 +221,12 @@ $ %s query vm code [address]`, version.ClientName)),
 }
 
 func GetCmdGetLogs(cdc *codec.Codec) *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "logs [txhash]",
 		Short: "Querying logs by txHash",
-		Long: strings.TrimSpace(fmt.Sprintf(`Query logs by txHash.
+		Long: strings.TrimSpace(fmt.Sprintf(`Query logs by txHash, with --decode the logs are decoded with the abi registered for the contracts which emitted them.
 Example:
-$ %s query vm logs [txHash]`, version.ClientName)),
+$ %s query vm logs [txHash] [--decode]`, version.ClientName)),
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
@@ -206,11 +237,239 @@ $ %s query vm logs [txHash]`, version.ClientName)),
 				return err
 			}
 
+			var out types.QueryLogsResult
+			cdc.MustUnmarshalJSON(res, &out)
+			if !viper.GetBool(flagDecode) {
+				return cliCtx.PrintOutput(out)
+			}
+
+			bz, err := json.MarshalIndent(decodeLogs(cliCtx, out.Logs), "", "  ")
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(bz))
+			return nil
+		},
+	}
+
+	cmd.Flags().Bool(flagDecode, false, "decode the logs with the abi registered for the contracts")
+
+	return cmd
+}
+
+// decodeLogs decodes the logs with the abi registered for each contract, the logs of the
+// contracts without a registered abi, or of unknown events, are left undecoded
+func decodeLogs(cliCtx context.CLIContext, logs []*types.Log) []interface{} {
+	abis := make(map[string]*abi.ABI)
+	decoded := make([]interface{}, 0, len(logs))
+	for _, log := range logs {
+		addr := log.Address.String()
+		abiObj, ok := abis[addr]
+		if !ok {
+			if info, err := QueryContractInfo(cliCtx, log.Address); err == nil {
+				if parsed, err := info.ParseABI(); err == nil {
+					abiObj = &parsed
+				}
+			}
+			abis[addr] = abiObj
+		}
+
+		if abiObj != nil {
+			if d, err := DecodeLog(*abiObj, log); err == nil {
+				decoded = append(decoded, d)
+				continue
+			}
+		}
+		decoded = append(decoded, log)
+	}
+
+	return decoded
+}
+
+func GetCmdFilterLogs(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "filter-logs",
+		Short: "Querying logs by contract address, topics and height range",
+		Long: strings.TrimSpace(fmt.Sprintf(`Query logs by contract address, topics and height range.
+Each of --topic0 ... --topic3 accepts a comma separated list of topics, a log matches if its topic at the same position is any of them.
+Example:
+$ %s query vm filter-logs --address=nch1rk47h83x4nz4745d63dtnpl8uwsramfgz8snr5 --topic0=ddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef --from-height=100 --to-height=200`, version.ClientName)),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			var addresses []sdk.AccAddress
+			if addrStr := viper.GetString(flagAddress); addrStr != "" {
+				addr, err := sdk.AccAddressFromBech32(addrStr)
+				if err != nil {
+					return err
+				}
+				addresses = append(addresses, addr)
+			}
+
+			topics := make([][]sdk.Hash, types.MaxTopics)
+			for i := 0; i < types.MaxTopics; i++ {
+				topicsStr := viper.GetString(fmt.Sprintf("%s%d", flagTopic, i))
+				if topicsStr == "" {
+					continue
+				}
+
+				for _, topicStr := range strings.Split(topicsStr, ",") {
+					topic, err := hexutil.Decode(strings.TrimSpace(topicStr))
+					if err != nil || len(topic) != sdk.HashLength {
+						return fmt.Errorf("invalid topic%d: %s", i, topicStr)
+					}
+					topics[i] = append(topics[i], sdk.BytesToHash(topic))
+				}
+			}
+
+			filter := types.NewLogFilter(viper.GetUint64(flagFromHeight), viper.GetUint64(flagToHeight), addresses, topics)
+			data, err := cliCtx.Codec.MarshalJSON(filter)
+			if err != nil {
+				return err
+			}
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/vm/%s", types.QueryFilterLogs), data)
+			if err != nil {
+				return err
+			}
+
 			var out types.QueryLogsResult
 			cdc.MustUnmarshalJSON(res, &out)
 			return cliCtx.PrintOutput(out)
 		},
 	}
+
+	cmd.Flags().String(flagAddress, "", "contract address which emitted the logs")
+	for i := 0; i < types.MaxTopics; i++ {
+		cmd.Flags().String(fmt.Sprintf("%s%d", flagTopic, i), "", fmt.Sprintf("comma separated list of topics at position %d", i))
+	}
+	cmd.Flags().Uint64(flagFromHeight, 1, "first height to query logs from")
+	cmd.Flags().Uint64(flagToHeight, 0, "last height to query logs from, the latest height if 0")
+
+	return cmd
+}
+
+func GetCmdQueryTrace(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "trace [txhash]",
+		Short: "Tracing the execution of a vm transaction",
+		Long: strings.TrimSpace(fmt.Sprintf(`Re-execute a committed vm transaction on the state of its height, and print the opcode by opcode trace, or the call tree with --tracer=call.
+The ante handlers and the vm messages of the transactions preceding it in its block are replayed first, the state changes of the other messages of the block are missing.
+The first vm message of the transaction is traced, or the one at --msg-index among its vm messages.
+Example:
+$ %s query vm trace [txHash] --disable-memory --limit=1000
+$ %s query vm trace [txHash] --tracer=call --msg-index=1`, version.ClientName, version.ClientName)),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			hash, err := hex.DecodeString(args[0])
+			if err != nil {
+				return err
+			}
+
+			node, err := cliCtx.GetNode()
+			if err != nil {
+				return err
+			}
+
+			resTx, err := node.Tx(hash, false)
+			if err != nil {
+				return err
+			}
+
+			block, err := node.Block(&resTx.Height)
+			if err != nil {
+				return err
+			}
+
+			blockResults, err := node.BlockResults(&resTx.Height)
+			if err != nil {
+				return err
+			}
+
+			params := types.QueryTraceParams{
+				Height: resTx.Height,
+				Time:   block.Block.Time,
+				TxHash: sdk.BytesToHash(hash),
+				Config: types.TraceConfig{
+					Tracer:         viper.GetString(flagTracer),
+					DisableMemory:  viper.GetBool(flagDisableMemory),
+					DisableStack:   viper.GetBool(flagDisableStack),
+					DisableStorage: viper.GetBool(flagDisableStorage),
+					Limit:          viper.GetInt(flagLimit),
+				},
+			}
+
+			// the messages of the failed transactions didn't change the state, their ante handlers did
+			for i, txBytes := range block.Block.Txs[:resTx.Index] {
+				var tx auth.StdTx
+				if err := cdc.UnmarshalBinaryLengthPrefixed(txBytes, &tx); err != nil {
+					continue
+				}
+
+				traceTx := newTraceTx(tx)
+				if !blockResults.Results.DeliverTx[i].IsOK() {
+					traceTx.Msgs = nil
+				}
+				params.Preceding = append(params.Preceding, traceTx)
+			}
+
+			var tx auth.StdTx
+			if err := cdc.UnmarshalBinaryLengthPrefixed(resTx.Tx, &tx); err != nil {
+				return err
+			}
+
+			params.Tx, params.Gas = newTraceTx(tx), tx.Fee.Gas
+			if len(params.Tx.Msgs) == 0 {
+				return fmt.Errorf("tx %s has no vm message", args[0])
+			}
+
+			params.MsgIndex = viper.GetInt(flagMsgIndex)
+			if params.MsgIndex < 0 || params.MsgIndex >= len(params.Tx.Msgs) {
+				return fmt.Errorf("invalid %s %d, tx %s has %d vm messages", flagMsgIndex, params.MsgIndex, args[0], len(params.Tx.Msgs))
+			}
+
+			data, err := cliCtx.Codec.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+
+			bz, _, err := cliCtx.WithHeight(resTx.Height-1).QueryWithData(fmt.Sprintf("custom/vm/%s", types.QueryTrace), data)
+			if err != nil {
+				return err
+			}
+
+			var out bytes.Buffer
+			if err = json.Indent(&out, bz, "", "  "); err != nil {
+				return err
+			}
+
+			fmt.Println(out.String())
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flagTracer, types.TracerStruct, fmt.Sprintf("tracer to use, %s for the opcode by opcode trace or %s for the call tree", types.TracerStruct, types.TracerCall))
+	cmd.Flags().Bool(flagDisableMemory, false, "disable the memory capture of the struct logs")
+	cmd.Flags().Bool(flagDisableStack, false, "disable the stack capture of the struct logs")
+	cmd.Flags().Bool(flagDisableStorage, false, "disable the storage capture of the struct logs")
+	cmd.Flags().Int(flagLimit, 0, "maximum number of struct logs, unlimited if 0")
+	cmd.Flags().Int(flagMsgIndex, 0, "index of the traced message among the vm messages of the tx")
+
+	return cmd
+}
+
+func newTraceTx(tx auth.StdTx) (traceTx types.TraceTx) {
+	traceTx.Signers, traceTx.Fee = tx.GetSigners(), tx.Fee.Amount
+	for _, msg := range tx.GetMsgs() {
+		if msg, ok := msg.(types.MsgContract); ok {
+			traceTx.Msgs = append(traceTx.Msgs, msg)
+		}
+	}
+	return
 }
 
 func GetCmdQueryCreateFee(cdc *codec.Codec) *cobra.Command {
@@ -252,10 +511,10 @@ func GetCmdQueryCallFee(cdc *codec.Codec) *cobra.Command {
 	return &cobra.Command{
 		Use:   "feecall [from] [to] [method] [args] [amount] [abi_file]",
 		Short: "Querying fee to call contract",
-		Long: strings.TrimSpace(fmt.Sprintf(`Querying fee to call contract.
+		Long: strings.TrimSpace(fmt.Sprintf(`Querying fee to call contract, the abi registered for the contract is used if abi_file is omitted.
 Example:
 $ %s query vm feecall nch1mfztsv6eq5rhtaz2l6jjp3yup3q80agsqra9qe nch1rk47h83x4nz4745d63dtnpl8uwsramfgz8snr5 balanceOf 0000000000000000000000000000000000000000000000000000000000000001 0pnch ./demo.abi`, version.ClientName)),
-		Args: cobra.ExactArgs(6),
+		Args: cobra.RangeArgs(5, 6),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
 
@@ -269,7 +528,11 @@ $ %s query vm feecall nch1mfztsv6eq5rhtaz2l6jjp3yup3q80agsqra9qe nch1rk47h83x4nz
 				return err
 			}
 
-			abiObj, err := AbiFromFile(args[5])
+			var abiFile string
+			if len(args) > 5 {
+				abiFile = args[5]
+			}
+			abiObj, err := LoadABI(cliCtx, abiFile, toAddr)
 			if err != nil {
 				return err
 			}
@@ -320,12 +583,12 @@ $ %s query vm feecall nch1mfztsv6eq5rhtaz2l6jjp3yup3q80agsqra9qe nch1rk47h83x4nz
 
 func GetCmdQueryCall(cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "call [from] [to] [method] [args] [amount] [abi_file]",
+		Use:   "call [from] [to] [method] [abi_file]",
 		Short: "Querying fee to call contract",
-		Long: strings.TrimSpace(fmt.Sprintf(`call contract for query, don't create a transaction.
+		Long: strings.TrimSpace(fmt.Sprintf(`call contract for query, don't create a transaction. The abi registered for the contract is used if abi_file is omitted.
 Example:
 $ %s query vm call nch1mfztsv6eq5rhtaz2l6jjp3yup3q80agsqra9qe nch1rk47h83x4nz4745d63dtnpl8uwsramfgz8snr5 balanceOf ./demo.abi --amount=0pnch --args="arg1 arg2"`, version.ClientName)),
-		Args: cobra.ExactArgs(4),
+		Args: cobra.RangeArgs(3, 4),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
 
@@ -339,8 +602,17 @@ $ %s query vm call nch1mfztsv6eq5rhtaz2l6jjp3yup3q80agsqra9qe nch1rk47h83x4nz474
 				return err
 			}
 
+			var abiFile string
+			if len(args) > 3 {
+				abiFile = args[3]
+			}
+			abiObj, err := LoadABI(cliCtx, abiFile, toAddr)
+			if err != nil {
+				return err
+			}
+
 			argList := viper.GetStringSlice(flagArgs)
-			payload, m, err := GenPayload(args[3], args[2], argList)
+			payload, m, err := GenPayloadFromABI(abiObj, args[2], argList)
 			if err != nil {
 				return err
 			}
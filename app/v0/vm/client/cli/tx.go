@@ -4,6 +4,7 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"io/ioutil"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -25,6 +26,7 @@ func VMCmd(cdc *codec.Codec) *cobra.Command {
 	txCmd.AddCommand(
 		ContractCreateCmd(cdc),
 		ContractCallCmd(cdc),
+		ContractRegisterCmd(cdc),
 	)
 	return txCmd
 }
@@ -93,7 +95,7 @@ func ContractCallCmd(cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "call",
 		Short:   "Create and sign a call contract tx",
-		Example: `nchcli vm call --from=<user key name> --contract_addr=<contract_addr> --method=<method> --abi_file=<abi_file>  --args='arg1 arg2 arg3' --amount=<amount> `,
+		Example: `nchcli vm call --from=<user key name> --contract_addr=<contract_addr> --method=<method> [--abi_file=<abi_file>] --args='arg1 arg2 arg3' --amount=<amount> `,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
@@ -108,10 +110,19 @@ func ContractCallCmd(cdc *codec.Codec) *cobra.Command {
 				coin = coinInput
 			}
 
-			abiFile := viper.GetString(flagAbiFile)
+			contractAddr, err := sdk.AccAddressFromBech32(viper.GetString(flagContractAddr))
+			if err != nil {
+				return err
+			}
+
+			abiObj, err := LoadABI(cliCtx, viper.GetString(flagAbiFile), contractAddr)
+			if err != nil {
+				return err
+			}
+
 			method := viper.GetString(flagMethod)
 			argList := viper.GetStringSlice(flagArgs)
-			payload, _, err := GenPayload(abiFile, method, argList)
+			payload, _, err := GenPayloadFromABI(abiObj, method, argList)
 			if err != nil {
 				return err
 			}
@@ -120,12 +131,68 @@ func ContractCallCmd(cdc *codec.Codec) *cobra.Command {
 			hex.Encode(dump[:], payload)
 			//fmt.Fprintf(os.Stderr, fmt.Sprintf("paylaod = %s\n", string(dump)))
 
+			msg := types.NewMsgContract(cliCtx.GetFromAddress(), contractAddr, payload, coin)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().String(flagContractAddr, "", "contract bech32 addr")
+	cmd.Flags().String(flagAmount, "0pnch", "amount of coins to send (e.g. 1000000pnch)")
+	cmd.Flags().String(flagMethod, "", "contract method")
+	cmd.Flags().String(flagArgs, "", "contract method arg list")
+	cmd.Flags().String(flagAbiFile, "", "contract abi file path, the abi registered for the contract if not set")
+
+	cmd.MarkFlagRequired(flagContractAddr)
+	cmd.MarkFlagRequired(flagMethod)
+
+	cmd = client.PostCommands(cmd)[0]
+
+	return cmd
+}
+
+func ContractRegisterCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "register",
+		Short: "Create and sign a register contract abi tx",
+		Long: `Register the abi and the metadata hash of a contract, for the clients to encode the calls and decode the results and logs without an abi file.
+The creator of the contract can always register, the others must give the solc metadata file of the contract, whose hash is embedded in the contract code and whose abi is registered, and can't overwrite the registration of the creator.`,
+		Example: `nchcli vm register --from=<user key name> --contract_addr=<contract_addr> --abi_file=<abi_file> --metadata_hash=<metadata hash>
+nchcli vm register --from=<user key name> --contract_addr=<contract_addr> --metadata_file=<solc metadata file>`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
 			contractAddr, err := sdk.AccAddressFromBech32(viper.GetString(flagContractAddr))
 			if err != nil {
 				return err
 			}
 
-			msg := types.NewMsgContract(cliCtx.GetFromAddress(), contractAddr, payload, coin)
+			var msg types.MsgContractRegister
+			if metadataFile := viper.GetString(flagMetadataFile); metadataFile != "" {
+				metadata, err := ioutil.ReadFile(metadataFile)
+				if err != nil {
+					return err
+				}
+
+				msg = types.NewMsgContractRegisterWithMetadata(cliCtx.GetFromAddress(), contractAddr, string(metadata))
+			} else {
+				abiJSON, err := ioutil.ReadFile(viper.GetString(flagAbiFile))
+				if err != nil {
+					return err
+				}
+
+				metadataHash, err := hex.DecodeString(viper.GetString(flagMetadataHash))
+				if err != nil {
+					return err
+				}
+
+				msg = types.NewMsgContractRegister(cliCtx.GetFromAddress(), contractAddr, string(abiJSON), metadataHash)
+			}
+
 			if err := msg.ValidateBasic(); err != nil {
 				return err
 			}
@@ -135,14 +202,11 @@ func ContractCallCmd(cdc *codec.Codec) *cobra.Command {
 	}
 
 	cmd.Flags().String(flagContractAddr, "", "contract bech32 addr")
-	cmd.Flags().String(flagAmount, "0pnch", "amount of coins to send (e.g. 1000000pnch)")
-	cmd.Flags().String(flagMethod, "", "contract method")
-	cmd.Flags().String(flagArgs, "", "contract method arg list")
 	cmd.Flags().String(flagAbiFile, "", "contract abi file path")
+	cmd.Flags().String(flagMetadataHash, "", "hex encoded metadata hash of the solc metadata trailer of the contract code")
+	cmd.Flags().String(flagMetadataFile, "", "solc metadata file of the contract, instead of the abi file and the metadata hash")
 
 	cmd.MarkFlagRequired(flagContractAddr)
-	cmd.MarkFlagRequired(flagMethod)
-	cmd.MarkFlagRequired(flagAbiFile)
 
 	cmd = client.PostCommands(cmd)[0]
 
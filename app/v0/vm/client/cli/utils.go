@@ -13,8 +13,11 @@ import (
 	"strings"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
 
 	"github.com/netcloth/netcloth-chain/app/v0/vm/common/math"
+	"github.com/netcloth/netcloth-chain/app/v0/vm/types"
+	"github.com/netcloth/netcloth-chain/client/context"
 	"github.com/netcloth/netcloth-chain/hexutil"
 	sdk "github.com/netcloth/netcloth-chain/types"
 )
@@ -69,12 +72,18 @@ func AbiFromFile(abiFile string) (abiObj abi.ABI, err error) {
 func GenPayload(abiFile, method string, args []string) (payload []byte, m abi.Method, err error) {
 	//fmt.Fprintf(os.Stderr, fmt.Sprintf("abiFile = %s, method = %s, args = %v, len=%d\n", abiFile, method, args, len(args)))
 
-	emptyMethod := abi.Method{}
 	abiObj, err := AbiFromFile(abiFile)
 	if err != nil {
-		return nil, emptyMethod, err
+		return nil, abi.Method{}, err
 	}
 
+	return GenPayloadFromABI(abiObj, method, args)
+}
+
+// GenPayloadFromABI packs the call of method with args, the constructor args if method is empty
+func GenPayloadFromABI(abiObj abi.ABI, method string, args []string) (payload []byte, m abi.Method, err error) {
+	emptyMethod := abi.Method{}
+
 	if len(method) == 0 { //constructor
 		m = abiObj.Constructor
 	} else if v, ok := abiObj.Methods[method]; ok {
@@ -235,3 +244,75 @@ func GenPayload(abiFile, method string, args []string) (payload []byte, m abi.Me
 
 	return payload, m, err
 }
+
+// QueryContractInfo queries the info registered for a contract
+func QueryContractInfo(cliCtx context.CLIContext, contractAddr sdk.AccAddress) (info types.ContractInfo, err error) {
+	res, _, err := cliCtx.Query(fmt.Sprintf("custom/%s/%s/%s", types.QuerierRoute, types.QueryContractInfo, contractAddr))
+	if err != nil {
+		return
+	}
+
+	err = cliCtx.Codec.UnmarshalJSON(res, &info)
+	return
+}
+
+// LoadABI loads the ABI from abiFile, or the ABI registered for the contract if abiFile is empty
+func LoadABI(cliCtx context.CLIContext, abiFile string, contractAddr sdk.AccAddress) (abi.ABI, error) {
+	if len(abiFile) != 0 {
+		return AbiFromFile(abiFile)
+	}
+
+	info, err := QueryContractInfo(cliCtx, contractAddr)
+	if err != nil {
+		return abi.ABI{}, fmt.Errorf("no abi file given, and no abi registered for contract %s: %v", contractAddr, err)
+	}
+
+	return info.ParseABI()
+}
+
+// DecodedLog - a log decoded with the ABI of the contract which emitted it
+type DecodedLog struct {
+	Address string                 `json:"address"`
+	Event   string                 `json:"event"`
+	Args    map[string]interface{} `json:"args"`
+}
+
+// DecodeLog decodes the event of a log, the addresses are converted to bech32
+func DecodeLog(abiObj abi.ABI, log *types.Log) (DecodedLog, error) {
+	if len(log.Topics) == 0 {
+		return DecodedLog{}, errors.New("anonymous events are not supported")
+	}
+
+	event, err := abiObj.EventByID(common.BytesToHash(log.Topics[0].Bytes()))
+	if err != nil {
+		return DecodedLog{}, err
+	}
+
+	args := make(map[string]interface{})
+	if err := event.Inputs.UnpackIntoMap(args, log.Data); err != nil {
+		return DecodedLog{}, err
+	}
+
+	var indexed abi.Arguments
+	for _, arg := range event.Inputs {
+		if arg.Indexed {
+			indexed = append(indexed, arg)
+		}
+	}
+
+	topics := make([]common.Hash, 0, len(log.Topics)-1)
+	for _, topic := range log.Topics[1:] {
+		topics = append(topics, common.BytesToHash(topic.Bytes()))
+	}
+	if err := abi.ParseTopicsIntoMap(args, indexed, topics); err != nil {
+		return DecodedLog{}, err
+	}
+
+	for name, arg := range args {
+		if addr, ok := arg.(common.Address); ok {
+			args[name] = sdk.AccAddress(addr.Bytes()).String()
+		}
+	}
+
+	return DecodedLog{Address: log.Address.String(), Event: event.Name, Args: args}, nil
+}
@@ -0,0 +1,393 @@
+package jsonrpc
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/netcloth/netcloth-chain/app/v0/auth"
+	"github.com/netcloth/netcloth-chain/app/v0/vm/types"
+	"github.com/netcloth/netcloth-chain/client/context"
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+// MaxLogsBlockRange - max number of blocks scanned by a single eth_getLogs request
+const MaxLogsBlockRange = types.MaxLogsBlockRange
+
+// PublicEthAPI - the eth_ namespace, backed by the vm querier and the tendermint rpc
+type PublicEthAPI struct {
+	cliCtx context.CLIContext
+}
+
+// NewPublicEthAPI creates a new PublicEthAPI
+func NewPublicEthAPI(cliCtx context.CLIContext) *PublicEthAPI {
+	return &PublicEthAPI{cliCtx: cliCtx}
+}
+
+// BlockNumber returns the latest block height
+func (api *PublicEthAPI) BlockNumber() (hexutil.Uint64, error) {
+	node, err := api.cliCtx.GetNode()
+	if err != nil {
+		return 0, err
+	}
+
+	status, err := node.Status()
+	if err != nil {
+		return 0, err
+	}
+
+	return hexutil.Uint64(status.SyncInfo.LatestBlockHeight), nil
+}
+
+// Call executes the given call on the state of the given block without creating a transaction
+func (api *PublicEthAPI) Call(args CallArgs, blockNr BlockNumber) (hexutil.Bytes, error) {
+	res, err := api.simulate(types.QueryCall, args, blockNr)
+	if err != nil {
+		return nil, err
+	}
+
+	return hexutil.Bytes(sdk.FromHex(res.Res)), nil
+}
+
+// EstimateGas returns the gas needed to execute the given call
+func (api *PublicEthAPI) EstimateGas(args CallArgs) (hexutil.Uint64, error) {
+	res, err := api.simulate(types.EstimateGas, args, LatestBlockNumber)
+	if err != nil {
+		return 0, err
+	}
+
+	return hexutil.Uint64(res.Gas), nil
+}
+
+// GetCode returns the contract code of the given address at the given block
+func (api *PublicEthAPI) GetCode(address common.Address, blockNr BlockNumber) (hexutil.Bytes, error) {
+	route := fmt.Sprintf("custom/%s/%s/%s", types.QuerierRoute, types.QueryCode, toAccAddress(address))
+	res, _, err := api.cliCtx.WithHeight(blockNr.Height()).Query(route)
+	if err != nil {
+		return nil, err
+	}
+
+	return hexutil.Bytes(res), nil
+}
+
+// GetStorageAt returns the storage value of the given address and key at the given block
+func (api *PublicEthAPI) GetStorageAt(address common.Address, key string, blockNr BlockNumber) (hexutil.Bytes, error) {
+	position, ok := new(big.Int).SetString(strings.TrimPrefix(key, "0x"), 16)
+	if !ok {
+		return nil, newError(errCodeInvalidParams, "invalid storage key %s", key)
+	}
+
+	route := fmt.Sprintf("custom/%s/%s/%s/%s", types.QuerierRoute, types.QueryStorage, toAccAddress(address), hex.EncodeToString(sdk.BigToHash(position).Bytes()))
+	res, _, err := api.cliCtx.WithHeight(blockNr.Height()).Query(route)
+	if err != nil {
+		return nil, err
+	}
+
+	var out types.QueryStorageResult
+	if err := api.cliCtx.Codec.UnmarshalJSON(res, &out); err != nil {
+		return nil, err
+	}
+
+	return hexutil.Bytes(out.Value.Bytes()), nil
+}
+
+// GetLogs returns the logs matching the given filter
+func (api *PublicEthAPI) GetLogs(query FilterQuery) ([]RPCLog, error) {
+	node, err := api.cliCtx.GetNode()
+	if err != nil {
+		return nil, err
+	}
+
+	var from, to int64
+	if query.BlockHash != nil {
+		return nil, newError(errCodeInvalidParams, "filtering logs by blockHash is not supported")
+	}
+
+	latest, err := api.BlockNumber()
+	if err != nil {
+		return nil, err
+	}
+
+	from, to = int64(latest), int64(latest)
+	if query.FromBlock != nil && *query.FromBlock != LatestBlockNumber {
+		from = query.FromBlock.Height()
+	}
+	if query.ToBlock != nil && *query.ToBlock != LatestBlockNumber {
+		to = query.ToBlock.Height()
+	}
+
+	if from > to {
+		return nil, newError(errCodeInvalidParams, "fromBlock %d is greater than toBlock %d", from, to)
+	}
+	if to-from >= MaxLogsBlockRange {
+		return nil, newError(errCodeInvalidParams, "block range exceeds the limit of %d blocks", MaxLogsBlockRange)
+	}
+
+	filter := types.NewLogFilter(uint64(from), uint64(to), nil, make([][]sdk.Hash, len(query.Topics)))
+	for _, addr := range query.Addresses {
+		filter.Addresses = append(filter.Addresses, toAccAddress(addr))
+	}
+	for i, sub := range query.Topics {
+		for _, topic := range sub {
+			filter.Topics[i] = append(filter.Topics[i], sdk.Hash(topic))
+		}
+	}
+
+	data, err := api.cliCtx.Codec.MarshalJSON(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	route := fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryFilterLogs)
+	res, _, err := api.cliCtx.QueryWithData(route, data)
+	if err != nil {
+		return nil, err
+	}
+
+	var out types.QueryLogsResult
+	if err := api.cliCtx.Codec.UnmarshalJSON(res, &out); err != nil {
+		return nil, err
+	}
+
+	// the logs are sorted by height, each block is fetched once to fill in the block hash and tx index
+	logs := []RPCLog{}
+	var (
+		blockHash  common.Hash
+		txIndexes  map[common.Hash]int
+		lastHeight int64 = -1
+	)
+	for _, log := range out.Logs {
+		height := int64(log.BlockNumber)
+		if height != lastHeight {
+			block, err := node.Block(&height)
+			if err != nil {
+				return nil, err
+			}
+
+			blockHash = common.BytesToHash(block.BlockMeta.BlockID.Hash)
+			txIndexes = make(map[common.Hash]int, len(block.Block.Data.Txs))
+			for i, tx := range block.Block.Data.Txs {
+				txIndexes[common.BytesToHash(tx.Hash())] = i
+			}
+			lastHeight = height
+		}
+
+		rpcLog := NewRPCLog(log)
+		rpcLog.BlockHash = blockHash
+		rpcLog.TxIndex = hexutil.Uint(txIndexes[rpcLog.TxHash])
+		logs = append(logs, rpcLog)
+	}
+
+	return logs, nil
+}
+
+// GetTransactionReceipt returns the receipt of the given transaction, nil if the transaction is not found
+func (api *PublicEthAPI) GetTransactionReceipt(hash common.Hash) (*Receipt, error) {
+	node, err := api.cliCtx.GetNode()
+	if err != nil {
+		return nil, err
+	}
+
+	resTx, err := node.Tx(hash.Bytes(), false)
+	if err != nil {
+		// the transaction is not found, or not yet included in a block
+		return nil, nil
+	}
+
+	block, err := node.Block(&resTx.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	receipt := &Receipt{
+		TxHash:            hash,
+		TxIndex:           hexutil.Uint(resTx.Index),
+		BlockHash:         common.BytesToHash(block.BlockMeta.BlockID.Hash),
+		BlockNumber:       hexutil.Uint64(resTx.Height),
+		GasUsed:           hexutil.Uint64(resTx.TxResult.GasUsed),
+		CumulativeGasUsed: hexutil.Uint64(resTx.TxResult.GasUsed),
+		Logs:              []RPCLog{},
+	}
+
+	if resTx.TxResult.IsOK() {
+		receipt.Status = 1
+	}
+
+	var tx auth.StdTx
+	if err := api.cliCtx.Codec.UnmarshalBinaryLengthPrefixed(resTx.Tx, &tx); err == nil {
+		for _, msg := range tx.GetMsgs() {
+			if msg, ok := msg.(types.MsgContract); ok {
+				from := toEthAddress(msg.From)
+				receipt.From = &from
+				if !msg.To.Empty() {
+					to := toEthAddress(msg.To)
+					receipt.To = &to
+				}
+				break
+			}
+		}
+	}
+
+	for _, event := range resTx.TxResult.Events {
+		if event.Type != types.EventTypeNewContract {
+			continue
+		}
+
+		for _, attr := range event.Attributes {
+			if string(attr.Key) != types.AttributeKeyAddress {
+				continue
+			}
+
+			if addr, err := sdk.AccAddressFromBech32(string(attr.Value)); err == nil {
+				contractAddr := toEthAddress(addr)
+				receipt.ContractAddress = &contractAddr
+			}
+		}
+	}
+
+	txLogs, err := api.getTxLogs(hash.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	for _, log := range txLogs {
+		rpcLog := NewRPCLog(log)
+		rpcLog.BlockNumber = receipt.BlockNumber
+		rpcLog.BlockHash = receipt.BlockHash
+		rpcLog.TxIndex = receipt.TxIndex
+		receipt.Logs = append(receipt.Logs, rpcLog)
+	}
+
+	return receipt, nil
+}
+
+// SendRawTransaction broadcasts an amino encoded signed StdTx and returns its hash
+//
+// NOTE: Ethereum RLP encoded transactions are not supported, the payload must be
+// a transaction signed by nchcli (e.g. with --generate-only and sign)
+func (api *PublicEthAPI) SendRawTransaction(data hexutil.Bytes) (common.Hash, error) {
+	res, err := api.cliCtx.BroadcastTxSync(data)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	if res.Code != 0 {
+		return common.Hash{}, errors.New(res.RawLog)
+	}
+
+	return common.HexToHash(res.TxHash), nil
+}
+
+func (api *PublicEthAPI) simulate(path string, args CallArgs, blockNr BlockNumber) (res types.SimulationResult, err error) {
+	data, err := api.cliCtx.Codec.MarshalJSON(args.ToMsg())
+	if err != nil {
+		return
+	}
+
+	route := fmt.Sprintf("custom/%s/%s", types.QuerierRoute, path)
+	bz, _, err := api.cliCtx.WithHeight(blockNr.Height()).QueryWithData(route, data)
+	if err != nil {
+		return
+	}
+
+	err = api.cliCtx.Codec.UnmarshalJSON(bz, &res)
+	return
+}
+
+func (api *PublicEthAPI) getTxLogs(hash []byte) ([]*types.Log, error) {
+	route := fmt.Sprintf("custom/%s/%s/%s", types.QuerierRoute, types.QueryTxLogs, hex.EncodeToString(hash))
+	res, _, err := api.cliCtx.Query(route)
+	if err != nil {
+		return nil, err
+	}
+
+	var out types.QueryLogsResult
+	if err := api.cliCtx.Codec.UnmarshalJSON(res, &out); err != nil {
+		return nil, err
+	}
+
+	return out.Logs, nil
+}
+
+func (api *PublicEthAPI) blockNumber(params json.RawMessage) (interface{}, error) {
+	return api.BlockNumber()
+}
+
+func (api *PublicEthAPI) call(params json.RawMessage) (interface{}, error) {
+	var (
+		args    CallArgs
+		blockNr BlockNumber
+	)
+	if err := parseParams(params, &args, &blockNr); err != nil {
+		return nil, err
+	}
+
+	return api.Call(args, blockNr)
+}
+
+func (api *PublicEthAPI) estimateGas(params json.RawMessage) (interface{}, error) {
+	var args CallArgs
+	if err := parseParams(params, &args); err != nil {
+		return nil, err
+	}
+
+	return api.EstimateGas(args)
+}
+
+func (api *PublicEthAPI) getCode(params json.RawMessage) (interface{}, error) {
+	var (
+		address common.Address
+		blockNr BlockNumber
+	)
+	if err := parseParams(params, &address, &blockNr); err != nil {
+		return nil, err
+	}
+
+	return api.GetCode(address, blockNr)
+}
+
+func (api *PublicEthAPI) getStorageAt(params json.RawMessage) (interface{}, error) {
+	var (
+		address common.Address
+		key     string
+		blockNr BlockNumber
+	)
+	if err := parseParams(params, &address, &key, &blockNr); err != nil {
+		return nil, err
+	}
+
+	return api.GetStorageAt(address, key, blockNr)
+}
+
+func (api *PublicEthAPI) getLogs(params json.RawMessage) (interface{}, error) {
+	var query FilterQuery
+	if err := parseParams(params, &query); err != nil {
+		return nil, err
+	}
+
+	return api.GetLogs(query)
+}
+
+func (api *PublicEthAPI) getTransactionReceipt(params json.RawMessage) (interface{}, error) {
+	var hash common.Hash
+	if err := parseParams(params, &hash); err != nil {
+		return nil, err
+	}
+
+	return api.GetTransactionReceipt(hash)
+}
+
+func (api *PublicEthAPI) sendRawTransaction(params json.RawMessage) (interface{}, error) {
+	var data hexutil.Bytes
+	if err := parseParams(params, &data); err != nil {
+		return nil, err
+	}
+
+	return api.SendRawTransaction(data)
+}
@@ -0,0 +1,178 @@
+package jsonrpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/netcloth/netcloth-chain/client/context"
+)
+
+const (
+	jsonrpcVersion = "2.0"
+
+	// standard JSON-RPC 2.0 error codes
+	errCodeParse          = -32700
+	errCodeInvalidRequest = -32600
+	errCodeMethodNotFound = -32601
+	errCodeInvalidParams  = -32602
+	errCodeServer         = -32000
+)
+
+type (
+	request struct {
+		Version string          `json:"jsonrpc"`
+		ID      json.RawMessage `json:"id"`
+		Method  string          `json:"method"`
+		Params  json.RawMessage `json:"params"`
+	}
+
+	response struct {
+		Version string          `json:"jsonrpc"`
+		ID      json.RawMessage `json:"id"`
+		Result  json.RawMessage `json:"result,omitempty"`
+		Error   *Error          `json:"error,omitempty"`
+	}
+
+	// Error - JSON-RPC error object
+	Error struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	}
+
+	methodFunc func(params json.RawMessage) (interface{}, error)
+)
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func newError(code int, format string, args ...interface{}) *Error {
+	return &Error{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+// Server - Ethereum compatible JSON-RPC server, serving the eth_ namespace on top of the vm querier
+type Server struct {
+	methods map[string]methodFunc
+}
+
+// NewServer creates a JSON-RPC server which queries the chain through the given CLIContext
+func NewServer(cliCtx context.CLIContext) *Server {
+	api := NewPublicEthAPI(cliCtx)
+
+	return &Server{
+		methods: map[string]methodFunc{
+			"eth_blockNumber":           api.blockNumber,
+			"eth_call":                  api.call,
+			"eth_estimateGas":           api.estimateGas,
+			"eth_getCode":               api.getCode,
+			"eth_getStorageAt":          api.getStorageAt,
+			"eth_getLogs":               api.getLogs,
+			"eth_getTransactionReceipt": api.getTransactionReceipt,
+			"eth_sendRawTransaction":    api.sendRawTransaction,
+		},
+	}
+}
+
+// ServeHTTP implements http.Handler, both single and batch requests are supported
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		writeResponse(w, response{Version: jsonrpcVersion, Error: newError(errCodeParse, err.Error())})
+		return
+	}
+
+	body = bytes.TrimSpace(body)
+	if len(body) > 0 && body[0] == '[' {
+		var reqs []request
+		if err := json.Unmarshal(body, &reqs); err != nil {
+			writeResponse(w, response{Version: jsonrpcVersion, Error: newError(errCodeParse, err.Error())})
+			return
+		}
+
+		resps := make([]response, 0, len(reqs))
+		for _, req := range reqs {
+			resps = append(resps, s.handle(req))
+		}
+		writeResponse(w, resps)
+		return
+	}
+
+	var req request
+	if err := json.Unmarshal(body, &req); err != nil {
+		writeResponse(w, response{Version: jsonrpcVersion, Error: newError(errCodeParse, err.Error())})
+		return
+	}
+
+	writeResponse(w, s.handle(req))
+}
+
+func (s *Server) handle(req request) response {
+	resp := response{Version: jsonrpcVersion, ID: req.ID}
+
+	if req.Version != jsonrpcVersion || req.Method == "" {
+		resp.Error = newError(errCodeInvalidRequest, "invalid request")
+		return resp
+	}
+
+	method, ok := s.methods[req.Method]
+	if !ok {
+		resp.Error = newError(errCodeMethodNotFound, "the method %s does not exist/is not available", req.Method)
+		return resp
+	}
+
+	result, err := method(req.Params)
+	if err != nil {
+		if rpcErr, ok := err.(*Error); ok {
+			resp.Error = rpcErr
+		} else {
+			resp.Error = newError(errCodeServer, err.Error())
+		}
+		return resp
+	}
+
+	bz, err := json.Marshal(result)
+	if err != nil {
+		resp.Error = newError(errCodeServer, err.Error())
+		return resp
+	}
+
+	resp.Result = bz
+	return resp
+}
+
+func writeResponse(w http.ResponseWriter, resp interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// parseParams decodes the positional params into args, trailing params are optional
+func parseParams(params json.RawMessage, args ...interface{}) error {
+	var raw []json.RawMessage
+	if len(params) > 0 && string(params) != "null" {
+		if err := json.Unmarshal(params, &raw); err != nil {
+			return newError(errCodeInvalidParams, "non-array args")
+		}
+	}
+
+	if len(raw) > len(args) {
+		return newError(errCodeInvalidParams, "too many arguments, want at most %d", len(args))
+	}
+
+	for i, param := range raw {
+		if err := json.Unmarshal(param, args[i]); err != nil {
+			return newError(errCodeInvalidParams, "invalid argument %d: %s", i, err.Error())
+		}
+	}
+
+	return nil
+}
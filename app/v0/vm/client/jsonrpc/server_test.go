@@ -0,0 +1,115 @@
+package jsonrpc
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/netcloth/netcloth-chain/app/v0/vm/types"
+	"github.com/netcloth/netcloth-chain/client/context"
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+func doRequest(t *testing.T, s *Server, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	return w
+}
+
+func TestServeHTTP(t *testing.T) {
+	s := NewServer(context.CLIContext{})
+	s.methods["test_echo"] = func(params json.RawMessage) (interface{}, error) {
+		var arg string
+		if err := parseParams(params, &arg); err != nil {
+			return nil, err
+		}
+		return arg, nil
+	}
+
+	var resp response
+	w := doRequest(t, s, `{"jsonrpc":"2.0","id":1,"method":"test_echo","params":["hello"]}`)
+	require.Nil(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Nil(t, resp.Error)
+	require.Equal(t, `"hello"`, string(resp.Result))
+	require.Equal(t, "1", string(resp.ID))
+
+	w = doRequest(t, s, `{"jsonrpc":"2.0","id":2,"method":"test_echo","params":["a","b"]}`)
+	require.Nil(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Equal(t, errCodeInvalidParams, resp.Error.Code)
+
+	w = doRequest(t, s, `{"jsonrpc":"2.0","id":3,"method":"eth_unknown"}`)
+	require.Nil(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Equal(t, errCodeMethodNotFound, resp.Error.Code)
+
+	w = doRequest(t, s, `{"jsonrpc":"2.0",`)
+	require.Nil(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Equal(t, errCodeParse, resp.Error.Code)
+
+	var resps []response
+	w = doRequest(t, s, `[{"jsonrpc":"2.0","id":1,"method":"test_echo","params":["a"]},{"jsonrpc":"1.0","id":2,"method":"test_echo"}]`)
+	require.Nil(t, json.Unmarshal(w.Body.Bytes(), &resps))
+	require.Equal(t, 2, len(resps))
+	require.Equal(t, `"a"`, string(resps[0].Result))
+	require.Equal(t, errCodeInvalidRequest, resps[1].Error.Code)
+}
+
+func TestBlockNumberUnmarshal(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected BlockNumber
+		expErr   bool
+	}{
+		{`"latest"`, LatestBlockNumber, false},
+		{`"pending"`, LatestBlockNumber, false},
+		{`"earliest"`, EarliestBlockNumber, false},
+		{`"0x10"`, BlockNumber(16), false},
+		{`"16"`, BlockNumber(0), true},
+		{`16`, BlockNumber(0), true},
+	}
+
+	for _, tc := range testCases {
+		var bn BlockNumber
+		err := json.Unmarshal([]byte(tc.input), &bn)
+		if tc.expErr {
+			require.NotNil(t, err, tc.input)
+			continue
+		}
+		require.Nil(t, err, tc.input)
+		require.Equal(t, tc.expected, bn, tc.input)
+	}
+}
+
+func TestFilterQuery(t *testing.T) {
+	addr := sdk.AccAddress(common.HexToAddress("0x8c9cd4e3b53bd6f2bf2f5ec9ab8a5f2e6f9d7c5a").Bytes())
+	topic0 := sdk.HexToHash("ddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+	topic1 := sdk.HexToHash("0000000000000000000000000000000000000000000000000000000000000001")
+	log := &types.Log{Address: addr, Topics: []sdk.Hash{topic0, topic1}}
+
+	testCases := []struct {
+		filter string
+		match  bool
+	}{
+		{`{}`, true},
+		{`{"address":"0x8c9cd4e3b53bd6f2bf2f5ec9ab8a5f2e6f9d7c5a"}`, true},
+		{`{"address":["0x0000000000000000000000000000000000000001","0x8c9cd4e3b53bd6f2bf2f5ec9ab8a5f2e6f9d7c5a"]}`, true},
+		{`{"address":"0x0000000000000000000000000000000000000001"}`, false},
+		{`{"topics":["0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"]}`, true},
+		{`{"topics":[null,"0x0000000000000000000000000000000000000000000000000000000000000001"]}`, true},
+		{`{"topics":[null,["0x0000000000000000000000000000000000000000000000000000000000000002","0x0000000000000000000000000000000000000000000000000000000000000001"]]}`, true},
+		{`{"topics":[null,"0x0000000000000000000000000000000000000000000000000000000000000002"]}`, false},
+		{`{"topics":[null,null,null]}`, false},
+	}
+
+	for _, tc := range testCases {
+		var q FilterQuery
+		require.Nil(t, json.Unmarshal([]byte(tc.filter), &q), tc.filter)
+		require.Equal(t, tc.match, q.Match(log), tc.filter)
+	}
+}
@@ -0,0 +1,227 @@
+package jsonrpc
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/netcloth/netcloth-chain/app/v0/vm/types"
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+// BlockNumber - block height param, supports the "latest", "pending" and "earliest" tags
+type BlockNumber int64
+
+const (
+	LatestBlockNumber   = BlockNumber(0)
+	EarliestBlockNumber = BlockNumber(1)
+)
+
+// UnmarshalJSON implements json.Unmarshaler
+func (bn *BlockNumber) UnmarshalJSON(data []byte) error {
+	var input string
+	if err := json.Unmarshal(data, &input); err != nil {
+		return err
+	}
+
+	switch strings.TrimSpace(input) {
+	case "latest", "pending", "":
+		*bn = LatestBlockNumber
+		return nil
+	case "earliest":
+		*bn = EarliestBlockNumber
+		return nil
+	}
+
+	height, err := hexutil.DecodeUint64(input)
+	if err != nil {
+		return err
+	}
+
+	*bn = BlockNumber(height)
+	return nil
+}
+
+// Height returns the height used to query the chain, 0 means the latest height
+func (bn BlockNumber) Height() int64 {
+	return int64(bn)
+}
+
+// CallArgs - arguments of eth_call and eth_estimateGas
+type CallArgs struct {
+	From  *common.Address `json:"from"`
+	To    *common.Address `json:"to"`
+	Gas   *hexutil.Uint64 `json:"gas"`
+	Value *hexutil.Big    `json:"value"`
+	Data  hexutil.Bytes   `json:"data"`
+}
+
+// ToMsg converts the call args to a MsgContractQuery
+func (args CallArgs) ToMsg() types.MsgContractQuery {
+	from := make(sdk.AccAddress, sdk.AddrLen)
+	if args.From != nil {
+		from = toAccAddress(*args.From)
+	}
+
+	var to sdk.AccAddress
+	if args.To != nil {
+		to = toAccAddress(*args.To)
+	}
+
+	amount := sdk.NewCoin(sdk.NativeTokenName, sdk.ZeroInt())
+	if args.Value != nil {
+		amount = sdk.NewCoin(sdk.NativeTokenName, sdk.NewIntFromBigInt(args.Value.ToInt()))
+	}
+
+	return types.NewMsgContractQuery(from, to, args.Data, amount)
+}
+
+// FilterQuery - arguments of eth_getLogs
+type FilterQuery struct {
+	BlockHash *common.Hash     `json:"blockHash"`
+	FromBlock *BlockNumber     `json:"fromBlock"`
+	ToBlock   *BlockNumber     `json:"toBlock"`
+	Addresses []common.Address `json:"-"`
+	Topics    [][]common.Hash  `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler, "address" may be a single address or a list,
+// each "topics" position may be null, a single topic or a list of topics
+func (q *FilterQuery) UnmarshalJSON(data []byte) error {
+	type filterQuery FilterQuery
+	var raw struct {
+		filterQuery
+		Address json.RawMessage   `json:"address"`
+		Topics  []json.RawMessage `json:"topics"`
+	}
+
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*q = FilterQuery(raw.filterQuery)
+
+	if len(raw.Address) > 0 && string(raw.Address) != "null" {
+		var addr common.Address
+		if err := json.Unmarshal(raw.Address, &addr); err == nil {
+			q.Addresses = []common.Address{addr}
+		} else if err := json.Unmarshal(raw.Address, &q.Addresses); err != nil {
+			return fmt.Errorf("invalid address: %s", err.Error())
+		}
+	}
+
+	q.Topics = make([][]common.Hash, len(raw.Topics))
+	for i, t := range raw.Topics {
+		if len(t) == 0 || string(t) == "null" {
+			continue
+		}
+
+		var topic common.Hash
+		if err := json.Unmarshal(t, &topic); err == nil {
+			q.Topics[i] = []common.Hash{topic}
+		} else if err := json.Unmarshal(t, &q.Topics[i]); err != nil {
+			return fmt.Errorf("invalid topic %d: %s", i, err.Error())
+		}
+	}
+
+	return nil
+}
+
+// Match reports whether the log matches the addresses and topics of the filter
+func (q FilterQuery) Match(log *types.Log) bool {
+	if len(q.Addresses) > 0 {
+		found := false
+		for _, addr := range q.Addresses {
+			if addr == toEthAddress(log.Address) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	if len(q.Topics) > len(log.Topics) {
+		return false
+	}
+
+	for i, sub := range q.Topics {
+		if len(sub) == 0 {
+			continue
+		}
+
+		found := false
+		for _, topic := range sub {
+			if topic == common.Hash(log.Topics[i]) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// RPCLog - Ethereum formatted contract log
+type RPCLog struct {
+	Address     common.Address `json:"address"`
+	Topics      []common.Hash  `json:"topics"`
+	Data        hexutil.Bytes  `json:"data"`
+	BlockNumber hexutil.Uint64 `json:"blockNumber"`
+	TxHash      common.Hash    `json:"transactionHash"`
+	TxIndex     hexutil.Uint   `json:"transactionIndex"`
+	BlockHash   common.Hash    `json:"blockHash"`
+	Index       hexutil.Uint   `json:"logIndex"`
+	Removed     bool           `json:"removed"`
+}
+
+// NewRPCLog converts a vm log to an Ethereum formatted log
+func NewRPCLog(log *types.Log) RPCLog {
+	topics := make([]common.Hash, len(log.Topics))
+	for i, topic := range log.Topics {
+		topics[i] = common.Hash(topic)
+	}
+
+	return RPCLog{
+		Address:     toEthAddress(log.Address),
+		Topics:      topics,
+		Data:        hexutil.Bytes(log.Data),
+		BlockNumber: hexutil.Uint64(log.BlockNumber),
+		TxHash:      common.Hash(log.TxHash),
+		TxIndex:     hexutil.Uint(log.TxIndex),
+		BlockHash:   common.Hash(log.BlockHash),
+		Index:       hexutil.Uint(log.Index),
+		Removed:     log.Removed,
+	}
+}
+
+// Receipt - Ethereum formatted transaction receipt
+type Receipt struct {
+	TxHash            common.Hash     `json:"transactionHash"`
+	TxIndex           hexutil.Uint    `json:"transactionIndex"`
+	BlockHash         common.Hash     `json:"blockHash"`
+	BlockNumber       hexutil.Uint64  `json:"blockNumber"`
+	From              *common.Address `json:"from"`
+	To                *common.Address `json:"to"`
+	GasUsed           hexutil.Uint64  `json:"gasUsed"`
+	CumulativeGasUsed hexutil.Uint64  `json:"cumulativeGasUsed"`
+	ContractAddress   *common.Address `json:"contractAddress"`
+	Logs              []RPCLog        `json:"logs"`
+	Status            hexutil.Uint64  `json:"status"`
+}
+
+func toAccAddress(addr common.Address) sdk.AccAddress {
+	return sdk.AccAddress(addr.Bytes())
+}
+
+func toEthAddress(addr sdk.AccAddress) common.Address {
+	return common.BytesToAddress(addr.Bytes())
+}
@@ -4,9 +4,13 @@ import (
 	"encoding/hex"
 	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/gorilla/mux"
 	"github.com/netcloth/netcloth-chain/app/v0/vm/types"
+	"github.com/netcloth/netcloth-chain/hexutil"
+	sdk "github.com/netcloth/netcloth-chain/types"
 	"github.com/netcloth/netcloth-chain/types/rest"
 
 	"github.com/netcloth/netcloth-chain/client/context"
@@ -28,10 +32,20 @@ func registerQueryRoutes(cliCtx context.CLIContext, r *mux.Router) {
 		getCodeFn(cliCtx),
 	).Methods("GET")
 
+	r.HandleFunc(
+		fmt.Sprintf("/vm/%s/{addr}", types.QueryContractInfo),
+		getContractInfoFn(cliCtx),
+	).Methods("GET")
+
 	r.HandleFunc(
 		"/vm/logs/{txId}",
 		getLogFn(cliCtx),
 	).Methods("GET")
+
+	r.HandleFunc(
+		fmt.Sprintf("/vm/%s", types.QueryFilterLogs),
+		filterLogsFn(cliCtx),
+	).Methods("GET")
 }
 
 func queryStorage(cliCtx context.CLIContext) http.HandlerFunc {
@@ -114,6 +128,28 @@ func getCode(cliCtx context.CLIContext) http.HandlerFunc {
 	}
 }
 
+func getContractInfo(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		addr := vars["addr"]
+
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		route := fmt.Sprintf("custom/vm/%s/%s", types.QueryContractInfo, addr)
+		res, height, err := cliCtx.Query(route)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
 func getLog(cliCtx context.CLIContext) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		vars := mux.Vars(r)
@@ -135,6 +171,77 @@ func getLog(cliCtx context.CLIContext) http.HandlerFunc {
 	}
 }
 
+// filterLogs - GET /vm/filter_logs?address=&topic0=&topic1=&topic2=&topic3=&from_height=&to_height=
+// each topicN is a comma separated list of topics
+func filterLogs(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		query := r.URL.Query()
+
+		var addresses []sdk.AccAddress
+		if addrStr := query.Get("address"); addrStr != "" {
+			addr, err := sdk.AccAddressFromBech32(addrStr)
+			if err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			addresses = append(addresses, addr)
+		}
+
+		topics := make([][]sdk.Hash, types.MaxTopics)
+		for i := 0; i < types.MaxTopics; i++ {
+			topicsStr := query.Get(fmt.Sprintf("topic%d", i))
+			if topicsStr == "" {
+				continue
+			}
+
+			for _, topicStr := range strings.Split(topicsStr, ",") {
+				topic, err := hexutil.Decode(strings.TrimSpace(topicStr))
+				if err != nil || len(topic) != sdk.HashLength {
+					rest.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("invalid topic%d: %s", i, topicStr))
+					return
+				}
+				topics[i] = append(topics[i], sdk.BytesToHash(topic))
+			}
+		}
+
+		var fromHeight, toHeight uint64 = 1, 0
+		var err error
+		if v := query.Get("from_height"); v != "" {
+			if fromHeight, err = strconv.ParseUint(v, 10, 64); err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+		if v := query.Get("to_height"); v != "" {
+			if toHeight, err = strconv.ParseUint(v, 10, 64); err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+
+		d, err := cliCtx.Codec.MarshalJSON(types.NewLogFilter(fromHeight, toHeight, addresses, topics))
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		route := fmt.Sprintf("custom/vm/%s", types.QueryFilterLogs)
+		res, height, err := cliCtx.QueryWithData(route, d)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
 func getStorageFn(cliCtx context.CLIContext) http.HandlerFunc {
 	return queryStorage(cliCtx)
 }
@@ -147,6 +254,14 @@ func getCodeFn(cliCtx context.CLIContext) http.HandlerFunc {
 	return getCode(cliCtx)
 }
 
+func getContractInfoFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return getContractInfo(cliCtx)
+}
+
 func getLogFn(cliCtx context.CLIContext) http.HandlerFunc {
 	return getLog(cliCtx)
 }
+
+func filterLogsFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return filterLogs(cliCtx)
+}
@@ -0,0 +1,120 @@
+package vm
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	keep "github.com/netcloth/netcloth-chain/app/v0/vm/keeper"
+	"github.com/netcloth/netcloth-chain/app/v0/vm/types"
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+// precompiledTest defines the input/output pairs for precompiled contract tests.
+type precompiledTest struct {
+	Input, Expected string
+	Name            string
+}
+
+// precompiledFailureTest defines the input/error pairs for precompiled contract failure tests.
+type precompiledFailureTest struct {
+	Input         string
+	ExpectedError string
+	Name          string
+}
+
+func loadJSON(t *testing.T, name string, v interface{}) {
+	data, err := ioutil.ReadFile(fmt.Sprintf("testdata/precompiles/%v.json", name))
+	require.Nil(t, err)
+	require.Nil(t, json.Unmarshal(data, v))
+}
+
+func runPrecompiled(addr byte, input []byte, gas uint64) ([]byte, uint64, error) {
+	p := PrecompiledContracts[sdk.BytesToAddress([]byte{addr}).String()]
+	contract := NewContract(&dummyContractRef{}, &dummyContractRef{}, new(big.Int), gas)
+	ret, err := RunPrecompiledContract(p, input, contract, &types.DefaultVMCommonGasParams)
+	return ret, gas - contract.Gas, err
+}
+
+func TestPrecompiledContracts(t *testing.T) {
+	testCases := []struct {
+		addr byte
+		name string
+	}{
+		{6, "bn256Add"},
+		{7, "bn256ScalarMul"},
+		{8, "bn256Pairing"},
+		{9, "blake2F"},
+	}
+
+	for _, tc := range testCases {
+		var tests []precompiledTest
+		loadJSON(t, tc.name, &tests)
+		require.NotEmpty(t, tests, tc.name)
+
+		for _, test := range tests {
+			ret, _, err := runPrecompiled(tc.addr, sdk.FromHex(test.Input), 10000000)
+			require.Nil(t, err, test.Name)
+			require.Equal(t, test.Expected, fmt.Sprintf("%x", ret), test.Name)
+		}
+	}
+}
+
+func TestPrecompiledContractsFailure(t *testing.T) {
+	var tests []precompiledFailureTest
+	loadJSON(t, "fail-blake2f", &tests)
+
+	for _, test := range tests {
+		_, _, err := runPrecompiled(9, sdk.FromHex(test.Input), 10000000)
+		require.NotNil(t, err, test.Name)
+		require.Equal(t, test.ExpectedError, err.Error(), test.Name)
+	}
+}
+
+func TestPrecompiledContractsGas(t *testing.T) {
+	gasParams := types.DefaultVMCommonGasParams
+
+	_, used, err := runPrecompiled(6, nil, 10000000)
+	require.Nil(t, err)
+	require.Equal(t, gasParams.Bn256AddGas, used)
+
+	_, used, err = runPrecompiled(7, nil, 10000000)
+	require.Nil(t, err)
+	require.Equal(t, gasParams.Bn256ScalarMulGas, used)
+
+	_, used, err = runPrecompiled(8, nil, 10000000)
+	require.Nil(t, err)
+	require.Equal(t, gasParams.Bn256PairingBaseGas, used)
+
+	var tests []precompiledTest
+	loadJSON(t, "blake2F", &tests)
+	input := sdk.FromHex(tests[0].Input)
+	rounds := uint64(input[0])<<24 | uint64(input[1])<<16 | uint64(input[2])<<8 | uint64(input[3])
+	_, used, err = runPrecompiled(9, input, 10000000)
+	require.Nil(t, err)
+	require.Equal(t, rounds*gasParams.Blake2FPerRoundGas, used)
+
+	// out of gas
+	_, _, err = runPrecompiled(8, nil, gasParams.Bn256PairingBaseGas-1)
+	require.Equal(t, ErrOutOfGas, err)
+}
+
+func TestPrecompiledContractsGasParamsDefaults(t *testing.T) {
+	ctx, _, vmKeeper, _ := keep.CreateTestInput(t, false, int64(1000000))
+
+	// the params stored before the precompiled contracts were enabled decode with zero gas
+	vmKeeper.SetVMCommonGasParams(ctx, types.VMCommonGasParams{
+		ContractCreationGas: types.DefaultContractCreationGas,
+		CreateDataGas:       types.DefaultCreateDataGas,
+	})
+	require.Equal(t, types.DefaultVMCommonGasParams, vmKeeper.GetVMCommonGasParams(ctx))
+
+	gasParams := types.DefaultVMCommonGasParams
+	gasParams.Blake2FPerRoundGas = 3
+	vmKeeper.SetVMCommonGasParams(ctx, gasParams)
+	require.Equal(t, gasParams, vmKeeper.GetVMCommonGasParams(ctx))
+}
@@ -18,8 +18,7 @@ type (
 	TransferFunc func(sdk.AccAddress, sdk.AccAddress, *big.Int)
 	// GetHashFunc returns the nth block hash in the blockchain
 	// and is used by the BLOCKHASH EVM op code.
-	//GetHashFunc func(sdk.Context) types.Hash
-	GetHashFunc func() sdk.Hash
+	GetHashFunc func(uint64) sdk.Hash
 )
 
 func run(evm *EVM, contract *Contract, input []byte, readOnly bool) ([]byte, error) {
@@ -27,7 +26,10 @@ func run(evm *EVM, contract *Contract, input []byte, readOnly bool) ([]byte, err
 		precompiles := PrecompiledContracts
 		if p := precompiles[(*contract.CodeAddr).String()]; p != nil {
 			fmt.Println("RunPrecompiledContract ...")
-			return RunPrecompiledContract(p, input, contract)
+			return RunPrecompiledContract(p, input, contract, evm.vmConfig.CommonGasConfig)
+		}
+		if p := evm.vmConfig.NativeContracts[(*contract.CodeAddr).String()]; p != nil {
+			return RunNativeContract(evm, p, input, contract, readOnly)
 		}
 	}
 	for _, interpreter := range evm.interpreters {
@@ -164,6 +166,9 @@ func (evm *EVM) create(caller ContractRef, codeAndHash *codeAndHash, gas uint64,
 		return nil, address, gas, nil
 	}
 
+	if evm.vmConfig.Debug && evm.depth == 0 {
+		evm.vmConfig.Tracer.CaptureStart(caller.Address(), address, true, codeAndHash.code, gas, value)
+	}
 	start := time.Now()
 	ret, err := run(evm, contract, nil, false)
 
@@ -227,7 +232,7 @@ func (evm *EVM) Call(caller ContractRef, addr sdk.AccAddress, input []byte, gas
 	if !evm.StateDB.Exist(addr) {
 		precompiles := PrecompiledContracts
 
-		if precompiles[addr.String()] == nil && value.Sign() == 0 {
+		if precompiles[addr.String()] == nil && evm.vmConfig.NativeContracts[addr.String()] == nil && value.Sign() == 0 {
 			// Calling a non existing account, don't do anything, but ping the tracer
 			if evm.vmConfig.Debug && evm.depth == 0 {
 				evm.vmConfig.Tracer.CaptureStart(caller.Address(), addr, false, input, gas, value)
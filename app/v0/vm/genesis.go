@@ -0,0 +1,87 @@
+package vm
+
+import (
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/netcloth/netcloth-chain/app/v0/auth"
+	"github.com/netcloth/netcloth-chain/app/v0/vm/types"
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+// InitGenesis initializes the vm params, contract code, contract storage, logs and contract infos from genesis
+func InitGenesis(ctx sdk.Context, k Keeper, data types.GenesisState) []abci.ValidatorUpdate {
+	k.SetParams(ctx, data.Params)
+
+	csdb := k.StateDB.WithContext(ctx)
+	for _, acc := range data.Accounts {
+		csdb.SetCode(acc.Address, acc.Code)
+		if !acc.Creator.Empty() {
+			k.SetContractCreator(ctx, acc.Address, acc.Creator)
+		}
+
+		for _, storage := range acc.Storage {
+			csdb.SetCommittedState(acc.Address, storage.Key, storage.Value)
+		}
+	}
+
+	for _, txLogs := range data.TxsLogs {
+		if err := csdb.SetLogs(txLogs.Hash, txLogs.Logs); err != nil {
+			panic(err)
+		}
+	}
+	csdb.SetLogIndex(data.LogIndex)
+	csdb.SetLogsIndexStartHeight(0) // the logs of the genesis are indexed
+
+	for _, info := range data.ContractInfos {
+		k.SetContractInfo(ctx, info)
+	}
+
+	// persist the contract accounts and code
+	csdb.Finalise(false)
+	if _, err := csdb.Commit(false); err != nil {
+		panic(err)
+	}
+	csdb.ClearStateObjects()
+
+	return []abci.ValidatorUpdate{}
+}
+
+// ExportGenesis exports the vm params, all the contract accounts with their code and storage, the logs and the contract infos
+func ExportGenesis(ctx sdk.Context, k Keeper) types.GenesisState {
+	csdb := k.StateDB.WithContext(ctx)
+
+	var accounts []types.GenesisAccount
+	k.IterateContracts(ctx, func(acc *auth.BaseAccount) bool {
+		genAcc := types.GenesisAccount{
+			Address:  acc.Address,
+			CodeHash: acc.CodeHash,
+			Code:     csdb.GetCode(acc.Address),
+			Creator:  k.GetContractCreator(ctx, acc.Address),
+		}
+
+		err := csdb.ForEachStorage(acc.Address, func(key, value sdk.Hash) bool {
+			genAcc.Storage = append(genAcc.Storage, types.NewGenesisStorage(key, value))
+			return true
+		})
+		if err != nil {
+			panic(err)
+		}
+
+		accounts = append(accounts, genAcc)
+		return false
+	})
+
+	var txsLogs []types.TransactionLogs
+	csdb.ForEachTxLogs(func(hash sdk.Hash, logs []*types.Log) bool {
+		txsLogs = append(txsLogs, types.TransactionLogs{Hash: hash, Logs: logs})
+		return true
+	})
+
+	var contractInfos []types.ContractInfo
+	k.IterateContractInfos(ctx, func(info types.ContractInfo) bool {
+		contractInfos = append(contractInfos, info)
+		return false
+	})
+
+	return types.NewGenesisState(k.GetParams(ctx), accounts, txsLogs, csdb.GetLogIndex(), contractInfos)
+}
@@ -0,0 +1,51 @@
+package vm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	keep "github.com/netcloth/netcloth-chain/app/v0/vm/keeper"
+	"github.com/netcloth/netcloth-chain/app/v0/vm/types"
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+func TestExportImportGenesis(t *testing.T) {
+	ctx, accountKeeper, vmKeeper, _ := keep.CreateTestInput(t, false, int64(1000000))
+
+	// init code: SSTORE(0, 0x2a), then return the 1 byte runtime code 0x00
+	code := sdk.FromHex("602a6000556001601160003960016000f300")
+
+	acc := accountKeeper.GetAccount(ctx, keep.Addrs[0])
+	contractAddr := CreateAddress(acc.GetAddress(), acc.GetSequence())
+
+	msgCreate := types.NewMsgContract(acc.GetAddress(), nil, code, sdk.NewInt64Coin(sdk.NativeTokenName, 0))
+	_, err := NewHandler(vmKeeper)(ctx, msgCreate)
+	require.Nil(t, err)
+	EndBlocker(ctx, vmKeeper)
+	require.Nil(t, vmKeeper.RegisterContract(ctx, types.NewContractInfo(contractAddr, acc.GetAddress(), "[]", nil)))
+
+	genesis := ExportGenesis(ctx, vmKeeper)
+	require.Nil(t, ValidateGenesis(genesis))
+	require.Equal(t, 1, len(genesis.Accounts))
+	require.Equal(t, contractAddr, genesis.Accounts[0].Address)
+	require.Equal(t, acc.GetAddress(), genesis.Accounts[0].Creator)
+	require.Equal(t, 1, len(genesis.Accounts[0].Storage))
+	require.Equal(t, 1, len(genesis.ContractInfos))
+
+	var decoded types.GenesisState
+	types.ModuleCdc.MustUnmarshalJSON(types.ModuleCdc.MustMarshalJSON(genesis), &decoded)
+	require.Equal(t, genesis, decoded)
+
+	// restore the exported state into a new chain
+	newCtx, _, newVMKeeper, _ := keep.CreateTestInput(t, false, int64(1000000))
+	InitGenesis(newCtx, newVMKeeper, genesis)
+
+	require.Equal(t, vmKeeper.GetCode(ctx, contractAddr), newVMKeeper.GetCode(newCtx, contractAddr))
+	require.Equal(t, sdk.BigToHash(sdk.NewInt(0x2a).BigInt()), newVMKeeper.GetState(newCtx, contractAddr, sdk.Hash{}))
+	require.Equal(t, genesis, ExportGenesis(newCtx, newVMKeeper))
+
+	// corrupted code must be rejected
+	genesis.Accounts[0].Code = sdk.Code{0x01}
+	require.NotNil(t, ValidateGenesis(genesis))
+}
@@ -13,6 +13,8 @@ func NewHandler(k Keeper) sdk.Handler {
 		switch msg := msg.(type) {
 		case MsgContract:
 			return handleMsgContract(ctx, msg, k)
+		case MsgContractRegister:
+			return handleMsgContractRegister(ctx, msg, k)
 		default:
 			return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unrecognized %s message type: %T", ModuleName, msg)
 		}
@@ -39,3 +41,29 @@ func handleMsgContract(ctx sdk.Context, msg MsgContract, k Keeper) (*sdk.Result,
 
 	return &sdk.Result{Data: res.Data, GasUsed: res.GasUsed, Events: ctx.EventManager().Events()}, nil
 }
+
+func handleMsgContractRegister(ctx sdk.Context, msg MsgContractRegister, k Keeper) (*sdk.Result, error) {
+	info, err := msg.ContractInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := k.RegisterContract(ctx, info); err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeRegisterContract,
+			sdk.NewAttribute(types.AttributeKeyAddress, msg.Contract.String()),
+			sdk.NewAttribute(types.AttributeKeyRegistrant, msg.From.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.From.String()),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
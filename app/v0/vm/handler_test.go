@@ -67,4 +67,85 @@ func TestMsgContractCreateAndCall(t *testing.T) {
 		fmt.Println("logs: ", resCall.Log)
 	}
 
+	// the Transfer log is emitted as an event
+	var logEvents sdk.Events
+	for _, event := range resCall.Events {
+		if event.Type == types.EventTypeContractLog {
+			logEvents = append(logEvents, event)
+		}
+	}
+	require.Equal(t, 1, len(logEvents))
+	attrs := logEvents[0].Attributes
+	require.Equal(t, 5, len(attrs))
+	require.Equal(t, contractAddr.String(), string(attrs[0].Value))
+	require.Equal(t, "topic0", string(attrs[1].Key))
+	require.Equal(t, "ddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef", string(attrs[1].Value))
+	require.Equal(t, "0000000000000000000000000000000000000000000000000000000000000064", string(attrs[4].Value))
+}
+
+func TestMsgContractRegister(t *testing.T) {
+	ctx, accountKeeper, vmKeeper, _ := keep.CreateTestInput(t, false, int64(1000000))
+	handler := NewHandler(vmKeeper)
+
+	// init code returning the runtime code 0xfe followed by a solc metadata trailer {"ipfs": metadataHash}
+	abiJSON := `[{"inputs":[],"name":"foo","outputs":[],"stateMutability":"nonpayable","type":"function"}]`
+	metadata := `{"compiler":{"version":"0.6.12"},"language":"Solidity","output":{"abi":` + abiJSON + `},"version":1}`
+	metadataHash := types.MetadataIPFSHash([]byte(metadata))
+	code := append(sdk.FromHex("602d600c600039602d6000f3fe"+"a164697066735822"), metadataHash...)
+	code = append(code, 0x00, 0x2a)
+
+	creator, stranger, other := keep.Addrs[0], keep.Addrs[1], keep.Addrs[2]
+	contractAddr := CreateAddress(creator, accountKeeper.GetAccount(ctx, creator).GetSequence())
+	_, err := handler(ctx, types.NewMsgContract(creator, nil, code, sdk.NewInt64Coin(sdk.NativeTokenName, 0)))
+	require.Nil(t, err)
+	require.Equal(t, creator, vmKeeper.GetContractCreator(ctx, contractAddr))
+
+	// invalid abi
+	msg := types.NewMsgContractRegister(creator, contractAddr, "[", nil)
+	require.NotNil(t, msg.ValidateBasic())
+
+	// no contract
+	_, err = handler(ctx, types.NewMsgContractRegister(creator, other, abiJSON, nil))
+	require.True(t, types.ErrNoCodeExist.Is(err))
+
+	// a stranger needs the metadata whose hash is embedded in the code
+	_, err = handler(ctx, types.NewMsgContractRegister(stranger, contractAddr, abiJSON, nil))
+	require.True(t, types.ErrUnauthorizedRegistration.Is(err))
+	_, err = handler(ctx, types.NewMsgContractRegister(stranger, contractAddr, abiJSON, metadataHash))
+	require.True(t, types.ErrUnauthorizedRegistration.Is(err))
+	_, err = handler(ctx, types.NewMsgContractRegisterWithMetadata(stranger, contractAddr, metadata+" "))
+	require.True(t, types.ErrUnauthorizedRegistration.Is(err))
+	_, err = handler(ctx, types.NewMsgContractRegisterWithMetadata(stranger, contractAddr, `{"output":{}}`))
+	require.True(t, types.ErrInvalidMetadata.Is(err))
+	msg = types.NewMsgContractRegisterWithMetadata(stranger, contractAddr, metadata)
+	msg.ABI = "[]"
+	require.True(t, types.ErrInvalidMetadata.Is(msg.ValidateBasic()))
+	res, err := handler(ctx, types.NewMsgContractRegisterWithMetadata(stranger, contractAddr, metadata))
+	require.Nil(t, err)
+	require.Equal(t, types.EventTypeRegisterContract, res.Events[0].Type)
+
+	// the registration can be overwritten by its registrant only, besides the creator
+	_, err = handler(ctx, types.NewMsgContractRegisterWithMetadata(other, contractAddr, metadata))
+	require.True(t, types.ErrUnauthorizedRegistration.Is(err))
+	_, err = handler(ctx, types.NewMsgContractRegisterWithMetadata(stranger, contractAddr, metadata))
+	require.Nil(t, err)
+
+	// the abi and the metadata hash are taken from the metadata
+	info, found := vmKeeper.GetContractInfo(ctx, contractAddr)
+	require.True(t, found)
+	require.Equal(t, stranger, info.Registrant)
+	require.Equal(t, metadataHash, []byte(info.MetadataHash))
+	require.Equal(t, metadata, info.Metadata)
+	contractABI, err := info.ParseABI()
+	require.Nil(t, err)
+	require.Contains(t, contractABI.Methods, "foo")
+
+	// the creator overwrites the registration, which can't be overwritten by the others anymore
+	_, err = handler(ctx, types.NewMsgContractRegister(creator, contractAddr, abiJSON, nil))
+	require.Nil(t, err)
+	_, err = handler(ctx, types.NewMsgContractRegisterWithMetadata(stranger, contractAddr, metadata))
+	require.True(t, types.ErrUnauthorizedRegistration.Is(err))
+
+	info, _ = vmKeeper.GetContractInfo(ctx, contractAddr)
+	require.Equal(t, creator, info.Registrant)
 }
@@ -1466,6 +1466,41 @@ func TestOpNumber(t *testing.T) {
 	require.Equal(t, blockNumber, v.Int64())
 }
 
+func TestOpBlockhash(t *testing.T) {
+	var (
+		addr        = sdk.AccAddress{0xab}
+		value       = big.NewInt(1000)
+		env         = newEVM()
+		stack       = newstack()
+		interpreter = NewEVMInterpreter(env, env.vmConfig)
+		contract    = NewContract(&dummyContractRef{address: addr}, &dummyContractRef{address: addr}, value, 0)
+	)
+
+	interpreter.intPool = poolOfIntPools.get()
+	interpreter.evm.BlockNumber = big.NewInt(1000)
+	interpreter.evm.GetHash = func(n uint64) sdk.Hash {
+		return sdk.BigToHash(new(big.Int).SetUint64(n))
+	}
+	pc := uint64(0)
+
+	testCases := []struct {
+		number   int64
+		expected int64
+	}{
+		{999, 999},
+		{744, 744},
+		{743, 0},
+		{1000, 0},
+		{1001, 0},
+	}
+
+	for _, tc := range testCases {
+		stack.push(big.NewInt(tc.number))
+		opBlockhash(&pc, interpreter, contract, nil, stack)
+		require.Equal(t, tc.expected, stack.pop().Int64(), tc.number)
+	}
+}
+
 func TestOpDifficulty(t *testing.T) {
 	var (
 		addr        = sdk.AccAddress{0xab}
@@ -19,6 +19,7 @@ type Config struct {
 	JumpTable        [256]operation // EVM instruction table, automatically populated if unset
 	OpConstGasConfig *[256]uint64
 	CommonGasConfig  *types.VMCommonGasParams
+	NativeContracts  map[string]NativeContract // Stateful contracts bridging into the native modules
 
 	EWASMInterpreter string // External EWASM interpreter options
 	EVMInterpreter   string // External EVM interpreter options
@@ -1,28 +1,49 @@
 package keeper
 
 import (
+	"bytes"
 	"fmt"
 
 	"github.com/tendermint/tendermint/libs/log"
 
 	"github.com/netcloth/netcloth-chain/app/v0/auth"
+	authexported "github.com/netcloth/netcloth-chain/app/v0/auth/exported"
 	"github.com/netcloth/netcloth-chain/app/v0/params"
+	"github.com/netcloth/netcloth-chain/app/v0/supply"
 	"github.com/netcloth/netcloth-chain/app/v0/vm/types"
 	"github.com/netcloth/netcloth-chain/codec"
 	sdk "github.com/netcloth/netcloth-chain/types"
+	sdkerrors "github.com/netcloth/netcloth-chain/types/errors"
 )
 
 type Keeper struct {
 	Cdc        *codec.Codec
+	storeKey   sdk.StoreKey
 	paramstore params.Subspace
+	ak         auth.AccountKeeper
 	StateDB    *types.CommitStateDB
+
+	// keepers of the native modules, bridged into the vm by the native contracts
+	BankKeeper    types.BankKeeper
+	StakingKeeper types.StakingKeeper
+	DistrKeeper   types.DistributionKeeper
+	IPALKeeper    types.IPALKeeper
+	CIPALKeeper   types.CIPALKeeper
 }
 
-func NewKeeper(cdc *codec.Codec, storeKey, codeKey, logKey, storageDebugKey sdk.StoreKey, paramstore params.Subspace, ak auth.AccountKeeper) Keeper {
+func NewKeeper(cdc *codec.Codec, storeKey, codeKey, logKey, storageDebugKey sdk.StoreKey, paramstore params.Subspace, ak auth.AccountKeeper,
+	bk types.BankKeeper, sk types.StakingKeeper, dk types.DistributionKeeper, ik types.IPALKeeper, ck types.CIPALKeeper) Keeper {
 	return Keeper{
-		Cdc:        cdc,
-		paramstore: paramstore.WithKeyTable(ParamKeyTable()),
-		StateDB:    types.NewCommitStateDB(ak, storeKey, codeKey, logKey, storageDebugKey),
+		Cdc:           cdc,
+		storeKey:      storeKey,
+		paramstore:    paramstore.WithKeyTable(ParamKeyTable()),
+		ak:            ak,
+		StateDB:       types.NewCommitStateDB(ak, storeKey, codeKey, logKey, storageDebugKey),
+		BankKeeper:    bk,
+		StakingKeeper: sk,
+		DistrKeeper:   dk,
+		IPALKeeper:    ik,
+		CIPALKeeper:   ck,
 	}
 }
 
@@ -41,3 +62,157 @@ func (k *Keeper) GetCode(ctx sdk.Context, addr sdk.AccAddress) []byte {
 func (k *Keeper) GetLogs(ctx sdk.Context, hash sdk.Hash) []*types.Log {
 	return k.StateDB.WithContext(ctx).GetLogs(hash)
 }
+
+func (k *Keeper) FilterLogs(ctx sdk.Context, filter types.LogFilter) []*types.Log {
+	return k.StateDB.WithContext(ctx).FilterLogs(filter)
+}
+
+// GetLogsIndexStartHeight returns the height from which the logs are indexed, if recorded
+func (k Keeper) GetLogsIndexStartHeight(ctx sdk.Context) (uint64, bool) {
+	return k.StateDB.WithContext(ctx).GetLogsIndexStartHeight()
+}
+
+// SetLogsIndexStartHeight sets the height from which the logs are indexed
+func (k Keeper) SetLogsIndexStartHeight(ctx sdk.Context, height uint64) {
+	k.StateDB.WithContext(ctx).SetLogsIndexStartHeight(height)
+}
+
+// IterateContracts iterates over all the accounts with code and performs a callback function
+func (k Keeper) IterateContracts(ctx sdk.Context, cb func(acc *auth.BaseAccount) (stop bool)) {
+	k.ak.IterateAccounts(ctx, func(acc authexported.Account) bool {
+		baseAcc, ok := acc.(*auth.BaseAccount)
+		if !ok || len(baseAcc.CodeHash) == 0 {
+			return false
+		}
+
+		return cb(baseAcc)
+	})
+}
+
+// ReplayAnte replays the state changes of the ante handler for a transaction, for re-executing
+// its messages: the sequences of the signers are incremented and the fee payer, the first
+// signer, pays the fee to the fee collector
+func (k Keeper) ReplayAnte(ctx sdk.Context, signers []sdk.AccAddress, fee sdk.Coins) error {
+	for _, signer := range signers {
+		acc := k.ak.GetAccount(ctx, signer)
+		if acc == nil {
+			return sdkerrors.Wrapf(sdkerrors.ErrUnknownAddress, "account %s does not exist", signer)
+		}
+
+		if err := acc.SetSequence(acc.GetSequence() + 1); err != nil {
+			return err
+		}
+		k.ak.SetAccount(ctx, acc)
+	}
+
+	if len(signers) == 0 || fee.IsZero() {
+		return nil
+	}
+
+	return k.BankKeeper.SendCoins(ctx, signers[0], supply.NewModuleAddress(auth.FeeCollectorName), fee)
+}
+
+// GetBlockHash returns the hash of the block at the given height, an empty hash
+// if the block is out of the window of the last NumBlockHashes blocks
+func (k Keeper) GetBlockHash(ctx sdk.Context, height uint64) sdk.Hash {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetBlockHashKey(height))
+	if bz == nil {
+		return sdk.Hash{}
+	}
+
+	return sdk.BytesToHash(bz)
+}
+
+// SetBlockHash sets the hash of the block at the given height, and prunes the
+// hash out of the window of the last NumBlockHashes blocks
+func (k Keeper) SetBlockHash(ctx sdk.Context, height uint64, hash sdk.Hash) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetBlockHashKey(height), hash.Bytes())
+
+	if height >= types.NumBlockHashes {
+		store.Delete(types.GetBlockHashKey(height - types.NumBlockHashes))
+	}
+}
+
+// GetContractCreator returns the account which created the contract by a transaction,
+// nil for a contract created by another contract or before the creators were recorded
+func (k Keeper) GetContractCreator(ctx sdk.Context, addr sdk.AccAddress) sdk.AccAddress {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetContractCreatorKey(addr))
+	if bz == nil {
+		return nil
+	}
+
+	return sdk.AccAddress(bz)
+}
+
+// SetContractCreator sets the account which created the contract
+func (k Keeper) SetContractCreator(ctx sdk.Context, addr, creator sdk.AccAddress) {
+	ctx.KVStore(k.storeKey).Set(types.GetContractCreatorKey(addr), creator.Bytes())
+}
+
+// GetContractInfo returns the registered info of a contract
+func (k Keeper) GetContractInfo(ctx sdk.Context, addr sdk.AccAddress) (info types.ContractInfo, found bool) {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetContractInfoKey(addr))
+	if bz == nil {
+		return info, false
+	}
+
+	k.Cdc.MustUnmarshalBinaryLengthPrefixed(bz, &info)
+	return info, true
+}
+
+// SetContractInfo sets the registered info of a contract
+func (k Keeper) SetContractInfo(ctx sdk.Context, info types.ContractInfo) {
+	ctx.KVStore(k.storeKey).Set(types.GetContractInfoKey(info.Address), k.Cdc.MustMarshalBinaryLengthPrefixed(info))
+}
+
+// IterateContractInfos iterates over all the registered contract infos and performs a callback function
+func (k Keeper) IterateContractInfos(ctx sdk.Context, cb func(info types.ContractInfo) (stop bool)) {
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), types.ContractInfoKeyPrefix)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var info types.ContractInfo
+		k.Cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &info)
+		if cb(info) {
+			break
+		}
+	}
+}
+
+// RegisterContract registers the ABI and metadata hash of a contract. The creator of the
+// contract may always (re-)register it. Anyone else may register a contract not registered
+// yet, or re-register their own registration, giving the solc metadata of the contract whose
+// IPFS hash is the one of the solc metadata trailer of the contract code, the ABI being taken
+// from the metadata.
+func (k Keeper) RegisterContract(ctx sdk.Context, info types.ContractInfo) error {
+	code := k.GetCode(ctx, info.Address)
+	if len(code) == 0 {
+		return sdkerrors.Wrapf(types.ErrNoCodeExist, "contract %s", info.Address)
+	}
+
+	creator := k.GetContractCreator(ctx, info.Address)
+	if creator.Empty() || !creator.Equals(info.Registrant) {
+		if len(info.Metadata) == 0 {
+			return sdkerrors.Wrap(types.ErrUnauthorizedRegistration, "not the contract creator, and no solc metadata of the contract")
+		}
+
+		// the abi and the metadata hash are derived from the metadata, not trusted
+		verified, err := types.NewContractInfoFromMetadata(info.Address, info.Registrant, info.Metadata)
+		if err != nil {
+			return err
+		}
+
+		if codeHash := types.CodeMetadataHash(code); !bytes.Equal(codeHash, verified.MetadataHash) {
+			return sdkerrors.Wrap(types.ErrUnauthorizedRegistration, "not the contract creator, and the metadata hash is not the one of the contract code")
+		}
+
+		if prev, found := k.GetContractInfo(ctx, info.Address); found && !prev.Registrant.Equals(info.Registrant) {
+			return sdkerrors.Wrapf(types.ErrUnauthorizedRegistration, "the contract is registered by %s", prev.Registrant)
+		}
+		info = verified
+	}
+
+	k.SetContractInfo(ctx, info)
+	return nil
+}
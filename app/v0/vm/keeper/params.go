@@ -34,7 +34,7 @@ func (k Keeper) SetVMOpGasParams(ctx sdk.Context, params [256]uint64) {
 
 func (k Keeper) GetVMCommonGasParams(ctx sdk.Context) (params types.VMCommonGasParams) {
 	k.paramstore.Get(ctx, types.KeyVMCommonGasParams, &params)
-	return
+	return params.WithDefaults()
 }
 
 func (k Keeper) SetVMCommonGasParams(ctx sdk.Context, params types.VMCommonGasParams) {
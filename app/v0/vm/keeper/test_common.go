@@ -48,6 +48,7 @@ var (
 		staking.BondedPoolName:    {supply.Burner, supply.Staking},
 		staking.NotBondedPoolName: {supply.Burner, supply.Staking},
 		gov.ModuleName:            {supply.Burner},
+		ipal.ModuleName:           {supply.Staking},
 	}
 )
 
@@ -110,6 +111,8 @@ func CreateTestInput(t *testing.T, isCheckTx bool, initPower int64) (sdk.Context
 
 	blacklistedAddrs := make(map[string]bool)
 	blacklistedAddrs[feeCollectorAcc.String()] = true
+	blacklistedAddrs[supply.NewModuleAddress(staking.BondedPoolName).String()] = true
+	blacklistedAddrs[supply.NewModuleAddress(staking.NotBondedPoolName).String()] = true
 
 	paramsKeeper := params.NewKeeper(cdc, keys[params.StoreKey], tkeys[params.TStoreKey])
 
@@ -134,6 +137,19 @@ func CreateTestInput(t *testing.T, isCheckTx bool, initPower int64) (sdk.Context
 
 	supplyKeeper.SetSupply(ctx, supply.NewSupply(totalSupply))
 
+	stakingKeeper := staking.NewKeeper(cdc, keys[staking.StoreKey], tkeys[staking.TStoreKey], supplyKeeper, paramsKeeper.Subspace(staking.DefaultParamspace))
+	stakingKeeper.SetParams(ctx, staking.DefaultParams())
+
+	distrKeeper := distr.NewKeeper(cdc, keys[distr.StoreKey], paramsKeeper.Subspace(distr.DefaultParamspace), stakingKeeper, supplyKeeper, auth.FeeCollectorName, blacklistedAddrs)
+	distrKeeper.SetFeePool(ctx, distr.InitialFeePool())
+	distrKeeper.SetCommunityTax(ctx, sdk.NewDecWithPrec(2, 2))
+	distrKeeper.SetBaseProposerReward(ctx, sdk.NewDecWithPrec(1, 2))
+	distrKeeper.SetBonusProposerReward(ctx, sdk.NewDecWithPrec(4, 2))
+	stakingKeeper.SetHooks(distrKeeper.Hooks())
+
+	ipalKeeper := ipal.NewKeeper(keys[ipal.StoreKey], cdc, supplyKeeper, paramsKeeper.Subspace(ipal.DefaultParamspace), auth.FeeCollectorName)
+	cipalKeeper := cipal.NewKeeper(keys[cipal.StoreKey], cdc, paramsKeeper.Subspace(cipal.DefaultParamspace), ipalKeeper)
+
 	keeper := NewKeeper(
 		cdc,
 		keys[types.StoreKey],
@@ -142,6 +158,11 @@ func CreateTestInput(t *testing.T, isCheckTx bool, initPower int64) (sdk.Context
 		keys[types.StoreDebugKey],
 		paramsKeeper.Subspace(DefaultParamspace),
 		accountKeeper,
+		bankKeeper,
+		stakingKeeper,
+		distrKeeper,
+		ipalKeeper,
+		cipalKeeper,
 	)
 	keeper.SetParams(ctx, types.DefaultParams())
 
@@ -50,7 +50,8 @@ func TestStoreCapture(t *testing.T) {
 		sdk.NewKVStoreKey(LogKey),
 		sdk.NewKVStoreKey(StoreDebugKey),
 		paramsKeeper.Subspace(bank.DefaultParamspace),
-		accountKeeper)
+		accountKeeper,
+		nil, nil, nil, nil, nil)
 
 	var (
 		env      = NewEVM(Context{}, vmKeeper.StateDB, Config{})
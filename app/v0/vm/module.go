@@ -2,8 +2,6 @@ package vm
 
 import (
 	"encoding/json"
-	"fmt"
-	"os"
 
 	"github.com/gorilla/mux"
 	"github.com/spf13/cobra"
@@ -73,15 +71,12 @@ func NewAppModule(keeper Keeper) AppModule {
 func (a AppModule) InitGenesis(ctx sdk.Context, data json.RawMessage) []abci.ValidatorUpdate {
 	var genesisState types.GenesisState
 	types.ModuleCdc.MustUnmarshalJSON(data, &genesisState)
-	a.keeper.SetParams(ctx, genesisState.Params)
-
-	return nil
+	return InitGenesis(ctx, a.keeper, genesisState)
 }
 
 func (a AppModule) ExportGenesis(ctx sdk.Context) json.RawMessage {
-	kvs := a.keeper.StateDB.WithContext(ctx).ExportState()
-	fmt.Fprintf(os.Stderr, "len(kvs)=%d", len(kvs))
-	return types.ModuleCdc.MustMarshalJSON(kvs)
+	gs := ExportGenesis(ctx, a.keeper)
+	return types.ModuleCdc.MustMarshalJSON(gs)
 }
 
 func (a AppModule) RegisterInvariants(sdk.InvariantRegistry) {
@@ -104,8 +99,8 @@ func (a AppModule) NewQuerierHandler() sdk.Querier {
 	return NewQuerier(a.keeper)
 }
 
-func (a AppModule) BeginBlock(sdk.Context, abci.RequestBeginBlock) {
-	// TODO
+func (a AppModule) BeginBlock(ctx sdk.Context, req abci.RequestBeginBlock) {
+	BeginBlocker(ctx, req, a.keeper)
 }
 
 func (a AppModule) EndBlock(ctx sdk.Context, end abci.RequestEndBlock) []abci.ValidatorUpdate {
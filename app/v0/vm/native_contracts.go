@@ -0,0 +1,338 @@
+package vm
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+
+	ipaltypes "github.com/netcloth/netcloth-chain/app/v0/ipal/types"
+	"github.com/netcloth/netcloth-chain/app/v0/vm/keeper"
+	sdk "github.com/netcloth/netcloth-chain/types"
+	sdkerrors "github.com/netcloth/netcloth-chain/types/errors"
+)
+
+// NativeContract is a stateful precompiled contract bridging into a native module,
+// its methods are called with the Solidity ABI encoding.
+type NativeContract interface {
+	RequiredGas(input []byte) uint64
+	Run(evm *EVM, contract *Contract, input []byte, readOnly bool) ([]byte, error)
+}
+
+// reserved addresses of the native contracts
+var (
+	BankContractAddress    = sdk.BytesToAddress([]byte{0x10, 0x01})
+	StakingContractAddress = sdk.BytesToAddress([]byte{0x10, 0x02})
+	IPALContractAddress    = sdk.BytesToAddress([]byte{0x10, 0x03})
+	CIPALContractAddress   = sdk.BytesToAddress([]byte{0x10, 0x04})
+)
+
+const (
+	// BankContractABI - interface of the bank native contract
+	BankContractABI = `[
+		{"type":"function","name":"balanceOf","stateMutability":"view","inputs":[{"name":"account","type":"address"},{"name":"denom","type":"string"}],"outputs":[{"name":"","type":"uint256"}]},
+		{"type":"function","name":"send","stateMutability":"nonpayable","inputs":[{"name":"to","type":"address"},{"name":"denom","type":"string"},{"name":"amount","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]}
+	]`
+
+	// StakingContractABI - interface of the staking native contract, the validator is the operator address
+	StakingContractABI = `[
+		{"type":"function","name":"delegate","stateMutability":"nonpayable","inputs":[{"name":"validator","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]},
+		{"type":"function","name":"undelegate","stateMutability":"nonpayable","inputs":[{"name":"validator","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[{"name":"completionTime","type":"uint256"}]},
+		{"type":"function","name":"withdrawRewards","stateMutability":"nonpayable","inputs":[{"name":"validator","type":"address"}],"outputs":[{"name":"amount","type":"uint256"}]}
+	]`
+
+	// IPALContractABI - interface of the ipal native contract
+	IPALContractABI = `[
+		{"type":"function","name":"nodeCount","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint256"}]},
+		{"type":"function","name":"getNode","stateMutability":"view","inputs":[{"name":"index","type":"uint256"}],"outputs":[{"name":"operator","type":"address"},{"name":"moniker","type":"string"},{"name":"bond","type":"uint256"}]},
+		{"type":"function","name":"getEndpoint","stateMutability":"view","inputs":[{"name":"operator","type":"address"},{"name":"serviceType","type":"uint256"}],"outputs":[{"name":"","type":"string"}]}
+	]`
+
+	// CIPALContractABI - interface of the cipal native contract
+	CIPALContractABI = `[
+		{"type":"function","name":"resolve","stateMutability":"view","inputs":[{"name":"user","type":"string"},{"name":"serviceType","type":"uint256"}],"outputs":[{"name":"","type":"string"}]}
+	]`
+)
+
+// NewNativeContracts creates the native contracts bridging into the native modules of the keeper
+func NewNativeContracts(k keeper.Keeper) map[string]NativeContract {
+	contracts := make(map[string]NativeContract)
+
+	if k.BankKeeper != nil {
+		contracts[BankContractAddress.String()] = newNativeContract(BankContractABI, map[string]nativeMethod{
+			"balanceOf": {NativeReadGas, true, func(ctx sdk.Context, caller sdk.AccAddress, useGas func(gas uint64) error, args []interface{}) ([]interface{}, error) {
+				coins := k.BankKeeper.GetCoins(ctx, toAccAddress(args[0]))
+				return []interface{}{coins.AmountOf(args[1].(string)).BigInt()}, nil
+			}},
+			"send": {NativeWriteGas, false, func(ctx sdk.Context, caller sdk.AccAddress, useGas func(gas uint64) error, args []interface{}) ([]interface{}, error) {
+				coin, err := toCoin(args[1].(string), args[2].(*big.Int))
+				if err != nil {
+					return nil, err
+				}
+
+				to := toAccAddress(args[0])
+				if k.BankKeeper.BlacklistedAddr(to) {
+					return nil, sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "%s is not allowed to receive transactions", to)
+				}
+
+				if err := k.BankKeeper.SendCoins(ctx, caller, to, sdk.NewCoins(coin)); err != nil {
+					return nil, err
+				}
+				return []interface{}{true}, nil
+			}},
+		})
+	}
+
+	if k.StakingKeeper != nil && k.DistrKeeper != nil {
+		contracts[StakingContractAddress.String()] = newNativeContract(StakingContractABI, map[string]nativeMethod{
+			"delegate": {NativeWriteGas, false, func(ctx sdk.Context, caller sdk.AccAddress, useGas func(gas uint64) error, args []interface{}) ([]interface{}, error) {
+				validator, found := k.StakingKeeper.GetValidator(ctx, toValAddress(args[0]))
+				if !found {
+					return nil, sdkerrors.Wrapf(ErrInvalidNativeInput, "validator %s not found", toValAddress(args[0]))
+				}
+
+				coin, err := toCoin(k.StakingKeeper.BondDenom(ctx), args[1].(*big.Int))
+				if err != nil {
+					return nil, err
+				}
+
+				// NOTE: source funds are always unbonded
+				if _, err := k.StakingKeeper.Delegate(ctx, caller, coin.Amount, sdk.Unbonded, validator, true); err != nil {
+					return nil, err
+				}
+				return []interface{}{true}, nil
+			}},
+			"undelegate": {NativeWriteGas, false, func(ctx sdk.Context, caller sdk.AccAddress, useGas func(gas uint64) error, args []interface{}) ([]interface{}, error) {
+				coin, err := toCoin(k.StakingKeeper.BondDenom(ctx), args[1].(*big.Int))
+				if err != nil {
+					return nil, err
+				}
+
+				shares, err := k.StakingKeeper.ValidateUnbondAmount(ctx, caller, toValAddress(args[0]), coin.Amount)
+				if err != nil {
+					return nil, err
+				}
+
+				completionTime, err := k.StakingKeeper.Undelegate(ctx, caller, toValAddress(args[0]), shares)
+				if err != nil {
+					return nil, err
+				}
+				return []interface{}{big.NewInt(completionTime.Unix())}, nil
+			}},
+			"withdrawRewards": {NativeWriteGas, false, func(ctx sdk.Context, caller sdk.AccAddress, useGas func(gas uint64) error, args []interface{}) ([]interface{}, error) {
+				rewards, err := k.DistrKeeper.WithdrawDelegationRewards(ctx, caller, toValAddress(args[0]))
+				if err != nil {
+					return nil, err
+				}
+				return []interface{}{rewards.AmountOf(k.StakingKeeper.BondDenom(ctx)).BigInt()}, nil
+			}},
+		})
+	}
+
+	if k.IPALKeeper != nil {
+		contracts[IPALContractAddress.String()] = newNativeContract(IPALContractABI, map[string]nativeMethod{
+			"nodeCount": {NativeReadGas, true, func(ctx sdk.Context, caller sdk.AccAddress, useGas func(gas uint64) error, args []interface{}) ([]interface{}, error) {
+				count := int64(0)
+				err := iterateIPALNodes(ctx, k, useGas, func(node ipaltypes.IPALNode) bool {
+					count++
+					return false
+				})
+				if err != nil {
+					return nil, err
+				}
+
+				return []interface{}{big.NewInt(count)}, nil
+			}},
+			"getNode": {NativeReadGas, true, func(ctx sdk.Context, caller sdk.AccAddress, useGas func(gas uint64) error, args []interface{}) ([]interface{}, error) {
+				index := args[0].(*big.Int)
+				if !index.IsInt64() {
+					return nil, sdkerrors.Wrapf(ErrInvalidNativeInput, "node index %s out of range", index)
+				}
+
+				var node ipaltypes.IPALNode
+				found, i := false, int64(0)
+				err := iterateIPALNodes(ctx, k, useGas, func(n ipaltypes.IPALNode) bool {
+					if i == index.Int64() {
+						node, found = n, true
+						return true
+					}
+					i++
+					return false
+				})
+				if err != nil {
+					return nil, err
+				}
+				if !found {
+					return nil, sdkerrors.Wrapf(ErrInvalidNativeInput, "node index %s out of range", index)
+				}
+
+				return []interface{}{common.BytesToAddress(node.OperatorAddress), node.Moniker, node.TotalBond().Amount.BigInt()}, nil
+			}},
+			"getEndpoint": {NativeReadGas, true, func(ctx sdk.Context, caller sdk.AccAddress, useGas func(gas uint64) error, args []interface{}) ([]interface{}, error) {
+				node, found := k.IPALKeeper.GetIPALNode(ctx, toAccAddress(args[0]))
+				if !found {
+					return []interface{}{""}, nil
+				}
+
+				for _, endpoint := range node.Endpoints {
+					if new(big.Int).SetUint64(endpoint.Type).Cmp(args[1].(*big.Int)) == 0 {
+						return []interface{}{endpoint.Endpoint}, nil
+					}
+				}
+				return []interface{}{""}, nil
+			}},
+		})
+	}
+
+	if k.CIPALKeeper != nil {
+		contracts[CIPALContractAddress.String()] = newNativeContract(CIPALContractABI, map[string]nativeMethod{
+			"resolve": {NativeReadGas, true, func(ctx sdk.Context, caller sdk.AccAddress, useGas func(gas uint64) error, args []interface{}) ([]interface{}, error) {
+				obj, found := k.CIPALKeeper.GetCIPALObject(ctx, args[0].(string))
+				if !found {
+					return []interface{}{""}, nil
+				}
+
+				for _, info := range obj.ServiceInfos {
+					if new(big.Int).SetUint64(info.Type).Cmp(args[1].(*big.Int)) == 0 {
+						return []interface{}{info.Address}, nil
+					}
+				}
+				return []interface{}{""}, nil
+			}},
+		})
+	}
+
+	return contracts
+}
+
+// iterateIPALNodes iterates over the nodes neither jailed nor inactive by ascending bond,
+// charging NativeReadPerItemGas for each node read
+func iterateIPALNodes(ctx sdk.Context, k keeper.Keeper, useGas func(gas uint64) error, cb func(node ipaltypes.IPALNode) (stop bool)) (err error) {
+	k.IPALKeeper.IterateIPALNodes(ctx, func(n ipaltypes.IPALNode) bool {
+		if err = useGas(NativeReadPerItemGas); err != nil {
+			return true
+		}
+
+		if n.IsJailed(ctx.BlockHeader().Time) || n.Inactive {
+			return false
+		}
+		return cb(n)
+	})
+	return
+}
+
+type nativeMethod struct {
+	gas  uint64 // base gas, the methods iterating over the state charge more while running
+	view bool   // the method doesn't change the state
+	run  func(ctx sdk.Context, caller sdk.AccAddress, useGas func(gas uint64) error, args []interface{}) ([]interface{}, error)
+}
+
+type nativeContract struct {
+	abi     abi.ABI
+	methods map[string]nativeMethod
+}
+
+func newNativeContract(definition string, methods map[string]nativeMethod) *nativeContract {
+	contractABI, err := abi.JSON(strings.NewReader(definition))
+	if err != nil {
+		panic(err)
+	}
+
+	return &nativeContract{abi: contractABI, methods: methods}
+}
+
+func (c *nativeContract) method(input []byte) (*abi.Method, nativeMethod, error) {
+	abiMethod, err := c.abi.MethodById(input)
+	if err != nil {
+		return nil, nativeMethod{}, sdkerrors.Wrap(ErrInvalidNativeInput, err.Error())
+	}
+
+	return abiMethod, c.methods[abiMethod.Name], nil
+}
+
+// RequiredGas returns the gas of the called method, 0 for an unknown method as Run fails
+func (c *nativeContract) RequiredGas(input []byte) uint64 {
+	_, m, err := c.method(input)
+	if err != nil {
+		return 0
+	}
+
+	return m.gas
+}
+
+// Run runs the called method, the state changes are journaled by the StateDB so that
+// they are reverted together with the calling contract
+func (c *nativeContract) Run(evm *EVM, contract *Contract, input []byte, readOnly bool) ([]byte, error) {
+	// delegate calls would act on behalf of the caller of the calling contract
+	if contract.CodeAddr == nil || !contract.Address().Equals(*contract.CodeAddr) || contract.Value().Sign() != 0 {
+		return nil, ErrInvalidNativeCall
+	}
+
+	abiMethod, m, err := c.method(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if readOnly && !m.view {
+		return nil, ErrWriteProtection
+	}
+
+	args, err := abiMethod.Inputs.UnpackValues(input[4:])
+	if err != nil {
+		return nil, sdkerrors.Wrap(ErrInvalidNativeInput, err.Error())
+	}
+
+	useGas := func(gas uint64) error {
+		if !contract.UseGas(gas) {
+			return ErrOutOfGas
+		}
+		return nil
+	}
+
+	var outputs []interface{}
+	run := func(ctx sdk.Context) (err error) {
+		outputs, err = m.run(ctx, contract.Caller(), useGas, args)
+		return
+	}
+
+	if m.view {
+		err = evm.StateDB.QueryNative(run)
+	} else {
+		err = evm.StateDB.RunNative(run)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return abiMethod.Outputs.Pack(outputs...)
+}
+
+// RunNativeContract runs and evaluates the output of a native contract.
+func RunNativeContract(evm *EVM, p NativeContract, input []byte, contract *Contract, readOnly bool) (ret []byte, err error) {
+	gas := p.RequiredGas(input)
+	if contract.UseGas(gas) {
+		return p.Run(evm, contract, input, readOnly)
+	}
+	return nil, ErrOutOfGas
+}
+
+func toAccAddress(arg interface{}) sdk.AccAddress {
+	return sdk.AccAddress(arg.(common.Address).Bytes())
+}
+
+func toValAddress(arg interface{}) sdk.ValAddress {
+	return sdk.ValAddress(arg.(common.Address).Bytes())
+}
+
+func toCoin(denom string, amount *big.Int) (sdk.Coin, error) {
+	if err := sdk.ValidateDenom(denom); err != nil {
+		return sdk.Coin{}, sdkerrors.Wrap(ErrInvalidNativeInput, err.Error())
+	}
+
+	if amount.Sign() <= 0 || amount.BitLen() > 255 {
+		return sdk.Coin{}, sdkerrors.Wrapf(ErrInvalidNativeInput, "invalid amount %s", amount)
+	}
+
+	return sdk.NewCoin(denom, sdk.NewIntFromBigInt(amount)), nil
+}
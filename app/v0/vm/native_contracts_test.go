@@ -0,0 +1,136 @@
+package vm
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/netcloth/netcloth-chain/app/v0/ipal"
+	ipaltypes "github.com/netcloth/netcloth-chain/app/v0/ipal/types"
+	"github.com/netcloth/netcloth-chain/app/v0/staking"
+	"github.com/netcloth/netcloth-chain/app/v0/supply"
+	keep "github.com/netcloth/netcloth-chain/app/v0/vm/keeper"
+	"github.com/netcloth/netcloth-chain/app/v0/vm/types"
+	sdk "github.com/netcloth/netcloth-chain/types"
+	sdkerrors "github.com/netcloth/netcloth-chain/types/errors"
+)
+
+func TestNativeBankContract(t *testing.T) {
+	ctx, ak, vmKeeper, _ := keep.CreateTestInput(t, false, int64(1000000))
+
+	bankABI, err := abi.JSON(strings.NewReader(BankContractABI))
+	require.Nil(t, err)
+
+	from, to := keep.Addrs[0], keep.Addrs[1]
+	amount := big.NewInt(1000)
+	fromCoins := ak.GetAccount(ctx, from).GetCoins()
+	toCoins := ak.GetAccount(ctx, to).GetCoins()
+
+	send, err := bankABI.Pack("send", common.BytesToAddress(to), sdk.DefaultBondDenom, amount)
+	require.Nil(t, err)
+	balanceOf, err := bankABI.Pack("balanceOf", common.BytesToAddress(to), sdk.DefaultBondDenom)
+	require.Nil(t, err)
+
+	newEVM := func() *EVM {
+		st := StateTransition{StateDB: vmKeeper.StateDB.WithContext(ctx)}
+		params := types.DefaultParams()
+		cfg := Config{OpConstGasConfig: &params.VMOpGasParams, CommonGasConfig: &params.VMCommonGasParams, NativeContracts: NewNativeContracts(vmKeeper)}
+		return NewEVM(Context{CanTransfer: st.CanTransfer, Transfer: st.Transfer, Origin: from, BlockNumber: big.NewInt(1), Time: big.NewInt(0)}, st.StateDB, cfg)
+	}
+
+	// reverted send
+	evm := newEVM()
+	snapshot := evm.StateDB.Snapshot()
+	ret, leftOverGas, err := evm.Call(AccountRef(from), BankContractAddress, send, 100000, big.NewInt(0))
+	require.Nil(t, err)
+	require.Equal(t, uint64(100000-NativeWriteGas), leftOverGas)
+	require.Equal(t, big.NewInt(1), new(big.Int).SetBytes(ret))
+	require.Equal(t, new(big.Int).Sub(fromCoins.AmountOf(sdk.DefaultBondDenom).BigInt(), amount), evm.StateDB.GetBalance(from))
+
+	ret, _, err = evm.StaticCall(AccountRef(from), BankContractAddress, balanceOf, 100000)
+	require.Nil(t, err)
+	require.Equal(t, new(big.Int).Add(toCoins.AmountOf(sdk.DefaultBondDenom).BigInt(), amount), new(big.Int).SetBytes(ret))
+
+	evm.StateDB.RevertToSnapshot(snapshot)
+	require.Equal(t, fromCoins.AmountOf(sdk.DefaultBondDenom).BigInt(), evm.StateDB.GetBalance(from))
+	require.Equal(t, toCoins.AmountOf(sdk.DefaultBondDenom).BigInt(), evm.StateDB.GetBalance(to))
+	evm.StateDB.Finalise(true)
+	require.Equal(t, fromCoins, ak.GetAccount(ctx, from).GetCoins())
+	require.Equal(t, toCoins, ak.GetAccount(ctx, to).GetCoins())
+
+	// committed send
+	evm = newEVM()
+	_, _, err = evm.Call(AccountRef(from), BankContractAddress, send, 100000, big.NewInt(0))
+	require.Nil(t, err)
+	evm.StateDB.Finalise(true)
+	require.Equal(t, fromCoins.Sub(sdk.NewCoins(sdk.NewCoin(sdk.DefaultBondDenom, sdk.NewIntFromBigInt(amount)))), ak.GetAccount(ctx, from).GetCoins())
+	require.Equal(t, toCoins.Add(sdk.NewCoins(sdk.NewCoin(sdk.DefaultBondDenom, sdk.NewIntFromBigInt(amount)))), ak.GetAccount(ctx, to).GetCoins())
+
+	// state changing methods are rejected in static calls, and calls with value
+	evm = newEVM()
+	_, _, err = evm.StaticCall(AccountRef(from), BankContractAddress, send, 100000)
+	require.Equal(t, ErrWriteProtection, err)
+	_, _, err = evm.Call(AccountRef(from), BankContractAddress, send, 100000, big.NewInt(1))
+	require.Equal(t, ErrInvalidNativeCall, err)
+	_, _, err = evm.Call(AccountRef(from), BankContractAddress, []byte{0x01, 0x02, 0x03, 0x04}, 100000, big.NewInt(0))
+	require.NotNil(t, err)
+
+	// the module accounts such as the bonded pool can't receive coins
+	bondedPool := supply.NewModuleAddress(staking.BondedPoolName)
+	sendToPool, err := bankABI.Pack("send", common.BytesToAddress(bondedPool), sdk.DefaultBondDenom, amount)
+	require.Nil(t, err)
+	evm = newEVM()
+	_, _, err = evm.Call(AccountRef(from), BankContractAddress, sendToPool, 100000, big.NewInt(0))
+	require.True(t, sdkerrors.ErrUnauthorized.Is(err))
+	evm.StateDB.Finalise(true)
+	require.True(t, ak.GetAccount(ctx, bondedPool) == nil || ak.GetAccount(ctx, bondedPool).GetCoins().IsZero())
+}
+
+func TestNativeIPALContractGas(t *testing.T) {
+	ctx, _, vmKeeper, _ := keep.CreateTestInput(t, false, int64(1000000))
+
+	ipalKeeper := vmKeeper.IPALKeeper.(ipal.Keeper)
+	for i := 0; i < 3; i++ {
+		ipalKeeper.CreateIPALNode(ctx, ipaltypes.NewIPALNode(keep.Addrs[i], fmt.Sprintf("node%d", i), "", "", "", nil, sdk.NewCoin(sdk.DefaultBondDenom, sdk.NewInt(int64(i+1)))))
+	}
+
+	ipalABI, err := abi.JSON(strings.NewReader(IPALContractABI))
+	require.Nil(t, err)
+	nodeCount, err := ipalABI.Pack("nodeCount")
+	require.Nil(t, err)
+	getNode, err := ipalABI.Pack("getNode", big.NewInt(1))
+	require.Nil(t, err)
+	getMissingNode, err := ipalABI.Pack("getNode", big.NewInt(3))
+	require.Nil(t, err)
+
+	st := StateTransition{StateDB: vmKeeper.StateDB.WithContext(ctx)}
+	params := types.DefaultParams()
+	cfg := Config{OpConstGasConfig: &params.VMOpGasParams, CommonGasConfig: &params.VMCommonGasParams, NativeContracts: NewNativeContracts(vmKeeper)}
+	evm := NewEVM(Context{CanTransfer: st.CanTransfer, Transfer: st.Transfer, Origin: keep.Addrs[0], BlockNumber: big.NewInt(1), Time: big.NewInt(0)}, st.StateDB, cfg)
+
+	// every node read is charged
+	ret, leftOverGas, err := evm.StaticCall(AccountRef(keep.Addrs[0]), IPALContractAddress, nodeCount, 100000)
+	require.Nil(t, err)
+	require.Equal(t, big.NewInt(3), new(big.Int).SetBytes(ret))
+	require.Equal(t, uint64(100000)-NativeReadGas-3*NativeReadPerItemGas, leftOverGas)
+
+	// the iteration stops at the requested node
+	ret, leftOverGas, err = evm.StaticCall(AccountRef(keep.Addrs[0]), IPALContractAddress, getNode, 100000)
+	require.Nil(t, err)
+	outputs, err := ipalABI.Methods["getNode"].Outputs.UnpackValues(ret)
+	require.Nil(t, err)
+	require.Equal(t, "node1", outputs[1])
+	require.Equal(t, uint64(100000)-NativeReadGas-2*NativeReadPerItemGas, leftOverGas)
+
+	_, _, err = evm.StaticCall(AccountRef(keep.Addrs[0]), IPALContractAddress, getMissingNode, 100000)
+	require.NotNil(t, err)
+
+	// running out of gas while iterating
+	_, _, err = evm.StaticCall(AccountRef(keep.Addrs[0]), IPALContractAddress, nodeCount, NativeReadGas+2*NativeReadPerItemGas)
+	require.Equal(t, ErrOutOfGas, err)
+}
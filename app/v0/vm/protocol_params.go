@@ -83,4 +83,9 @@ const (
 	Bn256PairingBaseGasIstanbul      uint64 = 45000  // Base price for an elliptic curve pairing check
 	Bn256PairingPerPointGasByzantium uint64 = 80000  // Byzantium per-point price for an elliptic curve pairing check
 	Bn256PairingPerPointGasIstanbul  uint64 = 34000  // Per-point price for an elliptic curve pairing check
+
+	NativeReadGas  uint64 = 1000  // Gas of a native contract method reading the state of a module
+	NativeWriteGas uint64 = 30000 // Gas of a native contract method changing the state of a module
+
+	NativeReadPerItemGas uint64 = 200 // Gas per item read by a native contract method iterating over the state of a module
 )
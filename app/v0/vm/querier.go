@@ -28,8 +28,14 @@ func NewQuerier(k keeper.Keeper) sdk.Querier {
 			return queryStorage(ctx, path, k)
 		case types.QueryTxLogs:
 			return queryTxLogs(ctx, path, k)
+		case types.QueryFilterLogs:
+			return queryFilterLogs(ctx, req, k)
 		case types.EstimateGas, types.QueryCall:
 			return simulateStateTransition(ctx, req, k)
+		case types.QueryTrace:
+			return queryTrace(ctx, req, k)
+		case types.QueryContractInfo:
+			return queryContractInfo(ctx, path, k)
 		default:
 			return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unknown query path: %s", path[0])
 		}
@@ -68,6 +74,24 @@ func queryCode(ctx sdk.Context, path []string, k keeper.Keeper) ([]byte, error)
 	return code, nil
 }
 
+func queryContractInfo(ctx sdk.Context, path []string, k keeper.Keeper) ([]byte, error) {
+	addr, err := sdk.AccAddressFromBech32(path[1])
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, err.Error())
+	}
+
+	info, found := k.GetContractInfo(ctx, addr)
+	if !found {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "contract %s is not registered", addr)
+	}
+
+	res, err := codec.MarshalJSONIndent(k.Cdc, info)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return res, nil
+}
+
 func queryStorage(ctx sdk.Context, path []string, keeper keeper.Keeper) ([]byte, error) {
 	addr, _ := sdk.AccAddressFromBech32(path[1])
 	key := sdk.HexToHash(path[2])
@@ -93,6 +117,38 @@ func queryTxLogs(ctx sdk.Context, path []string, keeper keeper.Keeper) ([]byte,
 	return res, nil
 }
 
+func queryFilterLogs(ctx sdk.Context, req abci.RequestQuery, keeper keeper.Keeper) ([]byte, error) {
+	var filter types.LogFilter
+	if err := keeper.Cdc.UnmarshalJSON(req.Data, &filter); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	// no logs above the current height, which also bounds the end key of the index iterators
+	if filter.ToHeight == 0 || filter.ToHeight > uint64(ctx.BlockHeight()) {
+		filter.ToHeight = uint64(ctx.BlockHeight())
+	}
+	if filter.FromHeight > filter.ToHeight {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "from height %d is greater than to height %d", filter.FromHeight, filter.ToHeight)
+	}
+	if filter.ToHeight-filter.FromHeight >= types.MaxLogsBlockRange {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "block range exceeds the limit of %d blocks", types.MaxLogsBlockRange)
+	}
+	// the logs of the blocks before the indexes can't be filtered
+	if startHeight, found := keeper.GetLogsIndexStartHeight(ctx); found && filter.FromHeight < startHeight {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "from height %d is before height %d the logs are indexed from", filter.FromHeight, startHeight)
+	}
+
+	logs := keeper.FilterLogs(ctx, filter)
+
+	bRes := types.QueryLogsResult{Logs: logs}
+	res, err := codec.MarshalJSONIndent(keeper.Cdc, bRes)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return res, nil
+}
+
 func simulateStateTransition(ctx sdk.Context, req abci.RequestQuery, k keeper.Keeper) ([]byte, error) {
 	var msg types.MsgContract
 	codec.Cdc.UnmarshalJSON(req.Data, &msg)
@@ -108,5 +164,101 @@ func simulateStateTransition(ctx sdk.Context, req abci.RequestQuery, k keeper.Ke
 		return res, nil
 	}
 
-	return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "StateTransition faileds")
+	return nil, sdkerrors.Wrap(err, "state transition failed")
+}
+
+func queryTrace(ctx sdk.Context, req abci.RequestQuery, k keeper.Keeper) (res []byte, err error) {
+	var params types.QueryTraceParams
+	if err := k.Cdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	var tracer Tracer
+	switch params.Config.Tracer {
+	case "", types.TracerStruct:
+		tracer = NewStructLogger(&LogConfig{
+			DisableMemory:  params.Config.DisableMemory,
+			DisableStack:   params.Config.DisableStack,
+			DisableStorage: params.Config.DisableStorage,
+			Limit:          params.Config.Limit,
+		})
+	case types.TracerCall:
+		tracer = NewCallTracer()
+	default:
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "unknown tracer: %s", params.Config.Tracer)
+	}
+
+	// the re-execution panics when it runs out of gas, as in the ante handler
+	defer func() {
+		if r := recover(); r != nil {
+			res, err = nil, sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "failed to trace tx %s: %v", params.TxHash, r)
+		}
+	}()
+
+	if params.MsgIndex < 0 || params.MsgIndex >= len(params.Tx.Msgs) {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "invalid message index %d of %d vm messages", params.MsgIndex, len(params.Tx.Msgs))
+	}
+
+	ctx = ctx.WithBlockHeight(params.Height).WithBlockTime(params.Time)
+
+	// NOTE: only the ante handlers and the vm messages of the block are replayed, the state
+	// changes of the other messages preceding the message in its block are missing
+	for _, tx := range params.Preceding {
+		if err := k.ReplayAnte(ctx, tx.Signers, tx.Fee); err != nil {
+			return nil, sdkerrors.Wrapf(err, "failed to replay a preceding tx of tx %s", params.TxHash)
+		}
+
+		for _, msg := range tx.Msgs {
+			traceStateTransition(ctx.WithGasMeter(sdk.NewGasMeter(DefaultVmGasLimit)), msg, k, nil, nil)
+		}
+	}
+
+	if err := k.ReplayAnte(ctx, params.Tx.Signers, params.Tx.Fee); err != nil {
+		return nil, sdkerrors.Wrapf(err, "failed to replay the ante handler of tx %s", params.TxHash)
+	}
+
+	for _, msg := range params.Tx.Msgs[:params.MsgIndex] {
+		traceStateTransition(ctx.WithGasMeter(sdk.NewGasMeter(DefaultVmGasLimit)), msg, k, params.TxHash.Bytes(), nil)
+	}
+
+	gas := params.Gas
+	if gas == 0 {
+		gas = DefaultVmGasLimit
+	}
+
+	result, vmerr := traceStateTransition(ctx.WithGasMeter(sdk.NewGasMeter(gas)), params.Tx.Msgs[params.MsgIndex], k, params.TxHash.Bytes(), tracer)
+	if result == nil {
+		return nil, vmerr
+	}
+
+	bRes := types.TraceResult{Gas: result.GasUsed, Failed: vmerr != nil, ReturnValue: hex.EncodeToString(result.Data)}
+	switch tracer := tracer.(type) {
+	case *StructLogger:
+		bRes.StructLogs = FormatLogs(tracer.StructLogs())
+	case *CallTracer:
+		bRes.Calls = tracer.CallFrame()
+	}
+
+	// NOTE: amino doesn't support the maps of the struct logs
+	res, err = json.Marshal(bRes)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return res, nil
+}
+
+// traceStateTransition executes msg on a copy of the StateDB, the state changes are
+// written to ctx
+func traceStateTransition(ctx sdk.Context, msg types.MsgContract, k keeper.Keeper, txHash []byte, tracer Tracer) (*sdk.Result, error) {
+	st := StateTransition{
+		Sender:    msg.From,
+		Recipient: msg.To,
+		Payload:   msg.Payload,
+		Amount:    msg.Amount.Amount,
+		StateDB:   types.NewStateDB(k.StateDB).WithContext(ctx).WithTxHash(txHash),
+		Tracer:    tracer,
+	}
+
+	_, result, err := st.TransitionCSDB(ctx, k)
+	return result, err
 }
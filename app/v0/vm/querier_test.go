@@ -0,0 +1,222 @@
+package vm
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	keep "github.com/netcloth/netcloth-chain/app/v0/vm/keeper"
+	"github.com/netcloth/netcloth-chain/app/v0/vm/types"
+	sdk "github.com/netcloth/netcloth-chain/types"
+	sdkerrors "github.com/netcloth/netcloth-chain/types/errors"
+)
+
+func TestQueryFilterLogs(t *testing.T) {
+	ctx, _, vmKeeper, _ := keep.CreateTestInput(t, false, int64(1000000))
+	ctx = ctx.WithBlockHeight(20)
+
+	addr1, addr2 := keep.Addrs[0], keep.Addrs[1]
+	topicA := sdk.BytesToHash([]byte("topicA"))
+	topicB := sdk.BytesToHash([]byte("topicB"))
+
+	// tx1 at height 5: addr1 [A], tx2 at height 10: addr2 [A, B], tx3 at height 15: addr1 []
+	txs := []struct {
+		hash sdk.Hash
+		log  *types.Log
+	}{
+		{sdk.BytesToHash([]byte("tx1")), &types.Log{Address: addr1, Topics: []sdk.Hash{topicA}, BlockNumber: 5}},
+		{sdk.BytesToHash([]byte("tx2")), &types.Log{Address: addr2, Topics: []sdk.Hash{topicA, topicB}, BlockNumber: 10}},
+		{sdk.BytesToHash([]byte("tx3")), &types.Log{Address: addr1, Topics: []sdk.Hash{}, BlockNumber: 15}},
+	}
+
+	csdb := vmKeeper.StateDB.WithContext(ctx)
+	for _, tx := range txs {
+		tx.log.TxHash = tx.hash
+		require.Nil(t, csdb.SetLogs(tx.hash, []*types.Log{tx.log}))
+	}
+
+	testCases := []struct {
+		name     string
+		filter   types.LogFilter
+		expected []sdk.Hash
+		expErr   bool
+	}{
+		{"all", types.NewLogFilter(1, 0, nil, nil), []sdk.Hash{txs[0].hash, txs[1].hash, txs[2].hash}, false},
+		{"height range", types.NewLogFilter(6, 15, nil, nil), []sdk.Hash{txs[1].hash, txs[2].hash}, false},
+		{"address", types.NewLogFilter(1, 0, []sdk.AccAddress{addr1}, nil), []sdk.Hash{txs[0].hash, txs[2].hash}, false},
+		{"address and height", types.NewLogFilter(1, 10, []sdk.AccAddress{addr1}, nil), []sdk.Hash{txs[0].hash}, false},
+		{"topic0", types.NewLogFilter(1, 0, nil, [][]sdk.Hash{{topicA}}), []sdk.Hash{txs[0].hash, txs[1].hash}, false},
+		{"topic1", types.NewLogFilter(1, 0, nil, [][]sdk.Hash{nil, {topicB}}), []sdk.Hash{txs[1].hash}, false},
+		{"trailing empty topics", types.NewLogFilter(1, 0, []sdk.AccAddress{addr1}, make([][]sdk.Hash, types.MaxTopics)), []sdk.Hash{txs[0].hash, txs[2].hash}, false},
+		{"address and topic", types.NewLogFilter(1, 0, []sdk.AccAddress{addr1}, [][]sdk.Hash{{topicB}}), nil, false},
+		{"invalid range", types.NewLogFilter(10, 5, nil, nil), nil, true},
+		{"to height above current height", types.NewLogFilter(6, math.MaxUint64, nil, nil), []sdk.Hash{txs[1].hash, txs[2].hash}, false},
+	}
+
+	querier := NewQuerier(vmKeeper)
+	for _, tc := range testCases {
+		req := abci.RequestQuery{
+			Path: fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryFilterLogs),
+			Data: vmKeeper.Cdc.MustMarshalJSON(tc.filter),
+		}
+
+		bz, err := querier(ctx, []string{types.QueryFilterLogs}, req)
+		if tc.expErr {
+			require.NotNil(t, err, tc.name)
+			continue
+		}
+		require.Nil(t, err, tc.name)
+
+		var res types.QueryLogsResult
+		vmKeeper.Cdc.MustUnmarshalJSON(bz, &res)
+
+		var hashes []sdk.Hash
+		for _, log := range res.Logs {
+			hashes = append(hashes, log.TxHash)
+		}
+		require.Equal(t, tc.expected, hashes, tc.name)
+	}
+
+	// the heights before the log indexes, recorded by the first begin blocker, are rejected
+	BeginBlocker(ctx.WithBlockHeight(8), abci.RequestBeginBlock{}, vmKeeper)
+	BeginBlocker(ctx.WithBlockHeight(9), abci.RequestBeginBlock{}, vmKeeper)
+	req := abci.RequestQuery{Data: vmKeeper.Cdc.MustMarshalJSON(types.NewLogFilter(7, 0, nil, nil))}
+	_, err := querier(ctx, []string{types.QueryFilterLogs}, req)
+	require.True(t, sdkerrors.ErrInvalidRequest.Is(err))
+	req = abci.RequestQuery{Data: vmKeeper.Cdc.MustMarshalJSON(types.NewLogFilter(8, 0, nil, nil))}
+	_, err = querier(ctx, []string{types.QueryFilterLogs}, req)
+	require.Nil(t, err)
+
+	// the block range is limited
+	ctx = ctx.WithBlockHeight(types.MaxLogsBlockRange + 9)
+	req = abci.RequestQuery{Data: vmKeeper.Cdc.MustMarshalJSON(types.NewLogFilter(8, 0, nil, nil))}
+	_, err = querier(ctx, []string{types.QueryFilterLogs}, req)
+	require.NotNil(t, err)
+}
+
+func TestQueryTrace(t *testing.T) {
+	ctx, _, vmKeeper, _ := keep.CreateTestInput(t, false, int64(1000000))
+	from := keep.Addrs[0]
+
+	// the init code calls the identity precompiled contract with 1 byte of input, and reverts if the call failed
+	// PUSH1 0x2a PUSH1 0 MSTORE8 PUSH1 0 PUSH1 0 PUSH1 1 PUSH1 0 PUSH1 0 PUSH1 4 PUSH2 0xffff CALL PUSH1 0x1c JUMPI
+	// PUSH1 0 DUP1 REVERT JUMPDEST STOP
+	code := sdk.FromHex("602a60005360006000600160006000600461fffff1601c57600080fd5b00")
+	msg := types.NewMsgContract(from, nil, code, sdk.NewInt64Coin(sdk.NativeTokenName, 0))
+
+	tx := types.TraceTx{Signers: []sdk.AccAddress{from}, Msgs: []types.MsgContract{msg}}
+	query := func(config types.TraceConfig) (types.TraceResult, error) {
+		params := types.QueryTraceParams{Height: 10, Tx: tx, Config: config}
+		req := abci.RequestQuery{
+			Path: fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryTrace),
+			Data: vmKeeper.Cdc.MustMarshalJSON(params),
+		}
+
+		// the queries run on a cache of the state
+		cacheCtx, _ := ctx.CacheContext()
+
+		var res types.TraceResult
+		bz, err := NewQuerier(vmKeeper)(cacheCtx, []string{types.QueryTrace}, req)
+		if err == nil {
+			require.Nil(t, json.Unmarshal(bz, &res))
+		}
+		return res, err
+	}
+
+	// struct logger
+	res, err := query(types.TraceConfig{})
+	require.Nil(t, err)
+	require.False(t, res.Failed)
+	require.Equal(t, 15, len(res.StructLogs))
+	require.Equal(t, "PUSH1", res.StructLogs[0].Op)
+	require.Equal(t, "CALL", res.StructLogs[10].Op)
+	require.Equal(t, []string{"2a00000000000000000000000000000000000000000000000000000000000000"}, res.StructLogs[3].Memory)
+	require.Equal(t, "STOP", res.StructLogs[14].Op)
+	require.Nil(t, res.Calls)
+
+	res, err = query(types.TraceConfig{DisableMemory: true, DisableStack: true, Limit: 5})
+	require.Nil(t, err)
+	require.Equal(t, 5, len(res.StructLogs))
+	require.Nil(t, res.StructLogs[3].Memory)
+	require.Nil(t, res.StructLogs[3].Stack)
+
+	// call tracer
+	res, err = query(types.TraceConfig{Tracer: types.TracerCall})
+	require.Nil(t, err)
+	require.Nil(t, res.StructLogs)
+	require.Equal(t, "CREATE", res.Calls.Type)
+	require.Equal(t, from.String(), res.Calls.From)
+	require.Equal(t, 1, len(res.Calls.Calls))
+
+	// the ante handler increments the sequence of the sender before the message is executed
+	seq := vmKeeper.StateDB.WithContext(ctx).GetNonce(from)
+	require.Equal(t, CreateAddress(from, seq+1).String(), res.Calls.To)
+
+	call := res.Calls.Calls[0]
+	require.Equal(t, "CALL", call.Type)
+	require.Equal(t, sdk.BytesToAddress([]byte{4}).String(), call.To)
+	require.Equal(t, "2a", call.Input)
+	require.Equal(t, "2a", call.Output)
+	require.Equal(t, uint64(0xffff), call.Gas)
+	require.Equal(t, IdentityBaseGas+IdentityPerWordGas, call.GasUsed)
+	require.Equal(t, "", call.Error)
+
+	_, err = query(types.TraceConfig{Tracer: "unknown"})
+	require.NotNil(t, err)
+
+	// the preceding txs of the sender in the block, and their fees, are replayed
+	fee := sdk.NewCoins(sdk.NewInt64Coin(sdk.NativeTokenName, 100))
+	balance := vmKeeper.BankKeeper.GetCoins(ctx, from)
+	params := types.QueryTraceParams{
+		Height:    10,
+		Tx:        tx,
+		Preceding: []types.TraceTx{{Signers: []sdk.AccAddress{from}, Fee: fee}},
+		Config:    types.TraceConfig{Tracer: types.TracerCall},
+	}
+	cacheCtx, _ := ctx.CacheContext()
+	bz, err := queryTrace(cacheCtx, abci.RequestQuery{Data: vmKeeper.Cdc.MustMarshalJSON(params)}, vmKeeper)
+	require.Nil(t, err)
+	require.Nil(t, json.Unmarshal(bz, &res))
+	require.Equal(t, CreateAddress(from, seq+2).String(), res.Calls.To)
+	require.Equal(t, balance.Sub(fee), vmKeeper.BankKeeper.GetCoins(cacheCtx, from))
+
+	// the requested message of the tx is traced after the ones preceding it, the second
+	// creation of the tx collides with the first one, the sequence being the same
+	params = types.QueryTraceParams{Height: 10, Tx: tx, MsgIndex: 1, Config: types.TraceConfig{Tracer: types.TracerCall}}
+	params.Tx.Msgs = []types.MsgContract{msg, msg}
+	cacheCtx, _ = ctx.CacheContext()
+	bz, err = queryTrace(cacheCtx, abci.RequestQuery{Data: vmKeeper.Cdc.MustMarshalJSON(params)}, vmKeeper)
+	require.Nil(t, err)
+	require.Nil(t, json.Unmarshal(bz, &res))
+	require.True(t, res.Failed)
+
+	params.MsgIndex = 2
+	_, err = queryTrace(cacheCtx, abci.RequestQuery{Data: vmKeeper.Cdc.MustMarshalJSON(params)}, vmKeeper)
+	require.True(t, sdkerrors.ErrInvalidRequest.Is(err))
+}
+
+func TestSimulateRevertReason(t *testing.T) {
+	ctx, _, vmKeeper, _ := keep.CreateTestInput(t, false, int64(1000000))
+
+	// the init code reverts with Error("insufficient allowance"), copied from the end of the code
+	// PUSH1 100 PUSH1 12 PUSH1 0 CODECOPY PUSH1 100 PUSH1 0 REVERT
+	code := sdk.FromHex("6064600c6000396064" + "6000fd" + "08c379a0" +
+		"0000000000000000000000000000000000000000000000000000000000000020" +
+		"0000000000000000000000000000000000000000000000000000000000000016" +
+		"696e73756666696369656e7420616c6c6f77616e636500000000000000000000")
+	msg := types.NewMsgContract(keep.Addrs[0], nil, code, sdk.NewInt64Coin(sdk.NativeTokenName, 0))
+
+	req := abci.RequestQuery{
+		Path: fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.EstimateGas),
+		Data: vmKeeper.Cdc.MustMarshalJSON(msg),
+	}
+
+	_, err := NewQuerier(vmKeeper)(ctx, []string{types.EstimateGas}, req)
+	require.NotNil(t, err)
+	require.True(t, types.ErrExecutionReverted.Is(err))
+	require.Contains(t, err.Error(), "reverted: insufficient allowance")
+}
@@ -4,11 +4,12 @@ import (
 	"fmt"
 	"math/big"
 
-	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/crypto/tmhash"
 
 	"github.com/netcloth/netcloth-chain/app/v0/vm/types"
+	"github.com/netcloth/netcloth-chain/hexutil"
 	sdk "github.com/netcloth/netcloth-chain/types"
+	sdkerrors "github.com/netcloth/netcloth-chain/types/errors"
 )
 
 // StateTransition defines data to transitionDB in vm
@@ -18,6 +19,7 @@ type StateTransition struct {
 	Amount    sdk.Int
 	Payload   []byte
 	StateDB   *types.CommitStateDB
+	Tracer    Tracer // traces the execution if set
 }
 
 func (st StateTransition) CanTransfer(acc sdk.AccAddress, amount *big.Int) bool {
@@ -29,12 +31,11 @@ func (st StateTransition) Transfer(from, to sdk.AccAddress, amount *big.Int) {
 	st.StateDB.AddBalance(to, amount)
 }
 
-func (st StateTransition) GetHashFn(header abci.Header) func() sdk.Hash {
-	return func() sdk.Hash {
-		var res = sdk.Hash{}
-		blockID := header.GetLastBlockId()
-		res.SetBytes(blockID.GetHash())
-		return res
+// GetHashFn returns the hash of the block at the given height, from the hashes of the
+// recent blocks recorded by the BeginBlocker
+func (st StateTransition) GetHashFn(ctx sdk.Context, k Keeper) func(uint64) sdk.Hash {
+	return func(height uint64) sdk.Hash {
+		return k.GetBlockHash(ctx.WithGasMeter(sdk.NewInfiniteGasMeter()), height)
 	}
 }
 
@@ -44,7 +45,7 @@ func (st StateTransition) TransitionCSDB(ctx sdk.Context, k Keeper) (*big.Int, *
 	evmCtx := Context{
 		CanTransfer: st.CanTransfer,
 		Transfer:    st.Transfer,
-		GetHash:     st.GetHashFn(ctx.BlockHeader()),
+		GetHash:     st.GetHashFn(ctx, k),
 		Origin:      st.Sender,
 		CoinBase:    ctx.BlockHeader().ProposerAddress,
 		Time:        sdk.NewInt(ctx.BlockHeader().Time.Unix()).BigInt(),
@@ -63,7 +64,10 @@ func (st StateTransition) TransitionCSDB(ctx sdk.Context, k Keeper) (*big.Int, *
 	vmParams := k.GetParams(ctx) // will consume gas
 	st.StateDB.UpdateAccounts()  // wile consume gas
 
-	cfg := Config{OpConstGasConfig: &vmParams.VMOpGasParams, CommonGasConfig: &vmParams.VMCommonGasParams}
+	cfg := Config{OpConstGasConfig: &vmParams.VMOpGasParams, CommonGasConfig: &vmParams.VMCommonGasParams, NativeContracts: NewNativeContracts(k)}
+	if st.Tracer != nil {
+		cfg.Debug, cfg.Tracer = true, st.Tracer
+	}
 	evm := NewEVM(evmCtx, st.StateDB.WithContext(ctx.WithGasMeter(gasMeterForEvm)), cfg)
 
 	var (
@@ -78,15 +82,15 @@ func (st StateTransition) TransitionCSDB(ctx sdk.Context, k Keeper) (*big.Int, *
 		ctx.Logger().Info(fmt.Sprintf("create contract, consumed gas = %v, leftOverGas = %v, vm err = %v ", gasLimitForVm-leftOverGas, leftOverGas, vmerr))
 	} else {
 		ret, leftOverGas, vmerr = evm.Call(st.Sender, st.Recipient, st.Payload, gasLimitForVm, st.Amount.BigInt())
-		if vmerr == ErrExecutionReverted {
-			reason := "null"
-			if len(ret) > 4 {
-				reason = string(ret[4:])
-			}
+		ctx.Logger().Info(fmt.Sprintf("call contract, ret = %x, consumed gas = %v, leftOverGas = %v, vm err = %v", ret, gasLimitForVm-leftOverGas, leftOverGas, vmerr))
+	}
+
+	// the revert reason is returned with the error, the raw data as the result data
+	if vmerr == ErrExecutionReverted {
+		if reason := types.DecodeRevertReason(ret); reason != "" {
 			ctx.Logger().Info(fmt.Sprintf("VM revert error, reason provided by the contract: %s", reason))
+			vmerr = sdkerrors.Wrapf(ErrExecutionReverted, "reverted: %s", reason)
 		}
-
-		ctx.Logger().Info(fmt.Sprintf("call contract, ret = %x, consumed gas = %v, leftOverGas = %v, vm err = %v", ret, gasLimitForVm-leftOverGas, leftOverGas, vmerr))
 	}
 
 	vmGasUsed := gasLimitForVm - leftOverGas
@@ -95,8 +99,15 @@ func (st StateTransition) TransitionCSDB(ctx sdk.Context, k Keeper) (*big.Int, *
 		return nil, &sdk.Result{Data: ret, GasUsed: curGasMeter.GasConsumed() + vmGasUsed}, vmerr
 	}
 
+	// the logs are flushed to the log store by Finalise
+	logs := st.StateDB.TxLogs()
 	st.StateDB.Finalise(true)
 
+	// the creator may register the ABI of the contract
+	if st.Recipient.Empty() {
+		k.SetContractCreator(ctx, addr, st.Sender)
+	}
+
 	// comsume vm gas
 	ctx.WithGasMeter(curGasMeter).GasMeter().ConsumeGas(vmGasUsed, "VM execution consumption")
 
@@ -106,10 +117,29 @@ func (st StateTransition) TransitionCSDB(ctx sdk.Context, k Keeper) (*big.Int, *
 			sdk.NewAttribute(types.AttributeKeyAddress, addr.String()),
 		),
 	})
+	ctx.EventManager().EmitEvents(NewContractLogEvents(logs))
 
 	return nil, &sdk.Result{Data: ret, GasUsed: ctx.GasMeter().GasConsumed()}, nil
 }
 
+// NewContractLogEvents converts the contract logs to events, so that they can be
+// followed by the tendermint tx indexer and subscriptions
+func NewContractLogEvents(logs []*types.Log) sdk.Events {
+	events := make(sdk.Events, 0, len(logs))
+	for _, log := range logs {
+		attrs := make([]sdk.Attribute, 0, len(log.Topics)+2)
+		attrs = append(attrs, sdk.NewAttribute(types.AttributeKeyAddress, log.Address.String()))
+		for i, topic := range log.Topics {
+			attrs = append(attrs, sdk.NewAttribute(fmt.Sprintf("%s%d", types.AttributeKeyTopic, i), hexutil.Encode(topic.Bytes())))
+		}
+		attrs = append(attrs, sdk.NewAttribute(types.AttributeKeyData, hexutil.Encode(log.Data)))
+
+		events = append(events, sdk.NewEvent(types.EventTypeContractLog, attrs...))
+	}
+
+	return events
+}
+
 func DoStateTransition(ctx sdk.Context, msg types.MsgContract, k Keeper, readonly bool) (*big.Int, *sdk.Result, error) {
 	st := StateTransition{
 		Sender:    msg.From,
@@ -26,6 +26,11 @@ type (
 		prev    sdk.Int
 	}
 
+	coinsChange struct {
+		account *sdk.AccAddress
+		prev    sdk.Coins
+	}
+
 	nonceChange struct {
 		account *sdk.AccAddress
 		prev    uint64
@@ -57,6 +62,12 @@ type (
 	touchChange struct {
 		account *sdk.AccAddress
 	}
+
+	// changes to the native modules
+	nativeChange struct {
+		prevCtx   sdk.Context
+		liveAddrs map[string]struct{}
+	}
 )
 
 // createObjectChange
@@ -109,6 +120,15 @@ func (ch balanceChange) dirtied() *sdk.AccAddress {
 	return ch.account
 }
 
+// coinsChange
+func (ch coinsChange) revert(s *CommitStateDB) {
+	s.getStateObject(*ch.account).setCoins(ch.prev)
+}
+
+func (ch coinsChange) dirtied() *sdk.AccAddress {
+	return ch.account
+}
+
 // nonceChange
 func (ch nonceChange) revert(s *CommitStateDB) {
 	s.getStateObject(*ch.account).setNonce(ch.prev)
@@ -169,3 +189,21 @@ func (ch addPreimageChange) revert(s *CommitStateDB) {
 func (ch addPreimageChange) dirtied() *sdk.AccAddress {
 	return nil
 }
+
+// nativeChange
+func (ch nativeChange) revert(s *CommitStateDB) {
+	s.ctx = ch.prevCtx
+	s.nativeWrites = s.nativeWrites[:len(s.nativeWrites)-1]
+
+	// drop the accounts loaded from the discarded branch, they are reloaded on demand
+	for addr := range s.stateObjects {
+		if _, ok := ch.liveAddrs[addr]; !ok {
+			delete(s.stateObjects, addr)
+			delete(s.stateObjectsDirty, addr)
+		}
+	}
+}
+
+func (ch nativeChange) dirtied() *sdk.AccAddress {
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 // RegisterCodec - register the sdk message type
 func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(MsgContract{}, "nch/MsgContract", nil)
+	cdc.RegisterConcrete(MsgContractRegister{}, "nch/MsgContractRegister", nil)
 }
 
 // ModuleCdc - generic sealed codec to be used throughout this module
@@ -0,0 +1,161 @@
+package types
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+
+	"github.com/netcloth/netcloth-chain/hexutil"
+	sdk "github.com/netcloth/netcloth-chain/types"
+	sdkerrors "github.com/netcloth/netcloth-chain/types/errors"
+)
+
+// ContractInfo - the ABI and compiler metadata hash registered for a contract, and the solc
+// metadata they are taken from if registered by another account than the creator
+type ContractInfo struct {
+	Address      sdk.AccAddress `json:"address" yaml:"address"`
+	Registrant   sdk.AccAddress `json:"registrant" yaml:"registrant"`
+	ABI          string         `json:"abi" yaml:"abi"`
+	MetadataHash hexutil.Bytes  `json:"metadata_hash,omitempty" yaml:"metadata_hash"`
+	Metadata     string         `json:"metadata,omitempty" yaml:"metadata"`
+}
+
+// NewContractInfo creates a new ContractInfo instance
+func NewContractInfo(addr, registrant sdk.AccAddress, abiJSON string, metadataHash []byte) ContractInfo {
+	return ContractInfo{
+		Address:      addr,
+		Registrant:   registrant,
+		ABI:          abiJSON,
+		MetadataHash: metadataHash,
+	}
+}
+
+// NewContractInfoFromMetadata creates a new ContractInfo instance from the solc metadata of
+// the contract, the ABI being its output ABI and the metadata hash its IPFS hash
+func NewContractInfoFromMetadata(addr, registrant sdk.AccAddress, metadata string) (ContractInfo, error) {
+	if len(metadata) == 0 || len(metadata) > MaxMetadataLength {
+		return ContractInfo{}, sdkerrors.Wrapf(ErrInvalidMetadata, "length must be between 1 and %d", MaxMetadataLength)
+	}
+
+	var m struct {
+		Output struct {
+			ABI json.RawMessage `json:"abi"`
+		} `json:"output"`
+	}
+	if err := json.Unmarshal([]byte(metadata), &m); err != nil {
+		return ContractInfo{}, sdkerrors.Wrap(ErrInvalidMetadata, err.Error())
+	}
+	if len(m.Output.ABI) == 0 {
+		return ContractInfo{}, sdkerrors.Wrap(ErrInvalidMetadata, "missing output abi")
+	}
+
+	info := NewContractInfo(addr, registrant, string(m.Output.ABI), MetadataIPFSHash([]byte(metadata)))
+	info.Metadata = metadata
+	return info, nil
+}
+
+// Validate performs a basic validation of the contract info fields
+func (ci ContractInfo) Validate() error {
+	if ci.Address.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing contract address")
+	}
+	if ci.Registrant.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing registrant address")
+	}
+	if l := len(ci.MetadataHash); l != 0 && l != SwarmMetadataHashLength && l != IPFSMetadataHashLength {
+		return sdkerrors.Wrapf(ErrInvalidMetadataHash, "length %d, expected %d or %d", l, SwarmMetadataHashLength, IPFSMetadataHashLength)
+	}
+	if len(ci.Metadata) > MaxMetadataLength {
+		return sdkerrors.Wrapf(ErrInvalidMetadata, "length must be at most %d", MaxMetadataLength)
+	}
+
+	_, err := ci.ParseABI()
+	return err
+}
+
+// ParseABI parses the registered ABI
+func (ci ContractInfo) ParseABI() (abi.ABI, error) {
+	if len(ci.ABI) == 0 || len(ci.ABI) > MaxABILength {
+		return abi.ABI{}, sdkerrors.Wrapf(ErrInvalidABI, "length must be between 1 and %d", MaxABILength)
+	}
+
+	contractABI, err := abi.JSON(strings.NewReader(ci.ABI))
+	if err != nil {
+		return abi.ABI{}, sdkerrors.Wrap(ErrInvalidABI, err.Error())
+	}
+
+	return contractABI, nil
+}
+
+func (ci ContractInfo) String() string {
+	return fmt.Sprintf(`Contract Info:
+  Address:       %s
+  Registrant:    %s
+  Metadata Hash: %s
+  ABI:           %s`, ci.Address, ci.Registrant, ci.MetadataHash, ci.ABI)
+}
+
+// cbor encoded keys and byte string headers of the metadata hashes in the solc metadata trailer
+var metadataHashPrefixes = [][]byte{
+	append([]byte("\x64ipfs"), 0x58, IPFSMetadataHashLength),
+	append([]byte("\x65bzzr0"), 0x58, SwarmMetadataHashLength),
+	append([]byte("\x65bzzr1"), 0x58, SwarmMetadataHashLength),
+}
+
+// CodeMetadataHash returns the metadata hash of the cbor encoded metadata trailer appended to
+// the runtime code by solc, the trailer length being in the last 2 bytes. It returns nil if
+// the code has no such trailer.
+func CodeMetadataHash(code []byte) []byte {
+	if len(code) < 2 {
+		return nil
+	}
+
+	end := len(code) - 2
+	length := int(binary.BigEndian.Uint16(code[end:]))
+	if length > end {
+		return nil
+	}
+
+	trailer := code[end-length : end]
+	for _, prefix := range metadataHashPrefixes {
+		i := bytes.Index(trailer, prefix)
+		if i < 0 {
+			continue
+		}
+
+		start := i + len(prefix)
+		hashLength := int(prefix[len(prefix)-1])
+		if start+hashLength <= len(trailer) {
+			return trailer[start : start+hashLength]
+		}
+	}
+
+	return nil
+}
+
+// MetadataIPFSHash returns the IPFS multihash of the solc metadata, as appended to the code by
+// solc: the sha256 hash of the protobuf encoded IPFS node of a single chunk unixfs file
+func MetadataIPFSHash(metadata []byte) []byte {
+	varint := func(n int) []byte {
+		buf := make([]byte, binary.MaxVarintLen64)
+		return buf[:binary.PutUvarint(buf, uint64(n))]
+	}
+
+	// unixfs Data{Type: File, Data: metadata, filesize: len(metadata)}
+	unixfs := []byte{0x08, 0x02}
+	if len(metadata) != 0 {
+		unixfs = append(append(append(unixfs, 0x12), varint(len(metadata))...), metadata...)
+	}
+	unixfs = append(append(unixfs, 0x18), varint(len(metadata))...)
+
+	// PBNode{Data: unixfs}
+	node := append(append([]byte{0x0a}, varint(len(unixfs))...), unixfs...)
+
+	hash := sha256.Sum256(node)
+	return append([]byte{0x12, 0x20}, hash[:]...)
+}
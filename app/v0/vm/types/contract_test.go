@@ -0,0 +1,26 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+func TestMetadataIPFSHash(t *testing.T) {
+	testCases := []struct {
+		name     string
+		data     string
+		expected string
+	}{
+		// QmbFMke1KXqnYyBBWxB74N4c5SBnJMVAiMNRcGu6x1AwQH
+		{"empty", "", "1220bfccda787baba32b59c78450ac3d20b633360b43992c77289f9ed46d843561e6"},
+		// QmT78zSuBmuS4z925WZfrqQ1qHaJ56DQaTfyMUF7F8ff5o
+		{"hello world", "hello world\n", "122046d44814b9c5af141c3aaab7c05dc5e844ead5f91f12858b021eba45768b4c0e"},
+	}
+
+	for _, tc := range testCases {
+		require.Equal(t, sdk.FromHex(tc.expected), MetadataIPFSHash([]byte(tc.data)), tc.name)
+	}
+}
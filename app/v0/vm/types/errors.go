@@ -22,4 +22,10 @@ var (
 	ErrInvalidJump              = sdkerrors.New(ModuleName, 15, "evm: invalid jump destination")
 	ErrGasUintOverflow          = sdkerrors.New(ModuleName, 16, "gas uint64 overflow")
 	ErrWrongCtx                 = sdkerrors.New(ModuleName, 17, "must be simulate mode when gas limit is 0")
+	ErrInvalidNativeInput       = sdkerrors.New(ModuleName, 18, "native contract: invalid input")
+	ErrInvalidNativeCall        = sdkerrors.New(ModuleName, 19, "native contract: must be called directly without value")
+	ErrInvalidABI               = sdkerrors.New(ModuleName, 20, "invalid contract abi")
+	ErrInvalidMetadataHash      = sdkerrors.New(ModuleName, 21, "invalid contract metadata hash")
+	ErrUnauthorizedRegistration = sdkerrors.New(ModuleName, 22, "not authorized to register the contract")
+	ErrInvalidMetadata          = sdkerrors.New(ModuleName, 23, "invalid contract metadata")
 )
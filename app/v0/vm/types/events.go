@@ -1,8 +1,13 @@
 package types
 
 const (
-	EventTypeNewContract = "new_contract"
+	EventTypeNewContract      = "new_contract"
+	EventTypeContractLog      = "contract_log"
+	EventTypeRegisterContract = "register_contract"
 
 	AttributeKeyAddress    = "address"
+	AttributeKeyTopic      = "topic" // suffixed by the topic position, e.g. topic0
+	AttributeKeyData       = "data"
+	AttributeKeyRegistrant = "registrant"
 	AttributeValueCategory = "vm"
 )
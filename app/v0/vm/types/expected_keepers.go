@@ -1,7 +1,12 @@
 package types
 
 import (
+	"time"
+
 	"github.com/netcloth/netcloth-chain/app/v0/auth/exported"
+	cipaltypes "github.com/netcloth/netcloth-chain/app/v0/cipal/types"
+	ipaltypes "github.com/netcloth/netcloth-chain/app/v0/ipal/types"
+	stakingtypes "github.com/netcloth/netcloth-chain/app/v0/staking/types"
 	sdk "github.com/netcloth/netcloth-chain/types"
 )
 
@@ -13,5 +18,28 @@ type AccountKeeper interface {
 }
 
 type BankKeeper interface {
+	GetCoins(ctx sdk.Context, addr sdk.AccAddress) sdk.Coins
 	SendCoins(ctx sdk.Context, fromAddr sdk.AccAddress, toAddr sdk.AccAddress, amt sdk.Coins) error
+	BlacklistedAddr(addr sdk.AccAddress) bool
+}
+
+type StakingKeeper interface {
+	BondDenom(ctx sdk.Context) string
+	GetValidator(ctx sdk.Context, addr sdk.ValAddress) (validator stakingtypes.Validator, found bool)
+	Delegate(ctx sdk.Context, delAddr sdk.AccAddress, bondAmt sdk.Int, tokenSrc sdk.BondStatus, validator stakingtypes.Validator, subtractAccount bool) (newShares sdk.Dec, err error)
+	ValidateUnbondAmount(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress, amt sdk.Int) (shares sdk.Dec, err error)
+	Undelegate(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress, sharesAmount sdk.Dec) (time.Time, error)
+}
+
+type DistributionKeeper interface {
+	WithdrawDelegationRewards(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) (sdk.Coins, error)
+}
+
+type IPALKeeper interface {
+	GetIPALNode(ctx sdk.Context, operator sdk.AccAddress) (obj ipaltypes.IPALNode, found bool)
+	IterateIPALNodes(ctx sdk.Context, cb func(n ipaltypes.IPALNode) (stop bool))
+}
+
+type CIPALKeeper interface {
+	GetCIPALObject(ctx sdk.Context, userAddress string) (obj cipaltypes.CIPALObject, found bool)
 }
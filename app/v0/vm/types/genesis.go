@@ -1,8 +1,48 @@
 package types
 
-type GenesisState struct {
-	Params Params `json:"params" yaml:"params"`
-}
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/tendermint/tendermint/crypto"
+
+	"github.com/netcloth/netcloth-chain/hexutil"
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+type (
+	// GenesisState - vm genesis state, includes all the deployed contracts and their logs
+	GenesisState struct {
+		Params        Params            `json:"params" yaml:"params"`
+		Accounts      []GenesisAccount  `json:"accounts" yaml:"accounts"`
+		TxsLogs       []TransactionLogs `json:"txs_logs" yaml:"txs_logs"`
+		LogIndex      uint64            `json:"log_index" yaml:"log_index"`
+		ContractInfos []ContractInfo    `json:"contract_infos" yaml:"contract_infos"`
+	}
+
+	// GenesisAccount - contract account exported at genesis, the balance and sequence of the
+	// account are kept by the genaccounts module
+	GenesisAccount struct {
+		Address  sdk.AccAddress   `json:"address" yaml:"address"`
+		CodeHash hexutil.Bytes    `json:"code_hash" yaml:"code_hash"`
+		Code     sdk.Code         `json:"code" yaml:"code"`
+		Storage  []GenesisStorage `json:"storage" yaml:"storage"`
+		Creator  sdk.AccAddress   `json:"creator,omitempty" yaml:"creator,omitempty"`
+	}
+
+	// GenesisStorage - a single storage slot of a contract account, keyed by its composite key in the storage store
+	GenesisStorage struct {
+		Key   sdk.Hash `json:"key" yaml:"key"`
+		Value sdk.Hash `json:"value" yaml:"value"`
+	}
+
+	// TransactionLogs - logs produced by the transaction with the given hash
+	TransactionLogs struct {
+		Hash sdk.Hash `json:"hash" yaml:"hash"`
+		Logs []*Log   `json:"logs" yaml:"logs"`
+	}
+)
 
 func DefaultGenesisState() GenesisState {
 	return GenesisState{
@@ -10,8 +50,47 @@ func DefaultGenesisState() GenesisState {
 	}
 }
 
-func NewGenesisState(params Params) GenesisState {
-	return GenesisState{Params: params}
+func NewGenesisState(params Params, accounts []GenesisAccount, txsLogs []TransactionLogs, logIndex uint64, contractInfos []ContractInfo) GenesisState {
+	return GenesisState{
+		Params:        params,
+		Accounts:      accounts,
+		TxsLogs:       txsLogs,
+		LogIndex:      logIndex,
+		ContractInfos: contractInfos,
+	}
+}
+
+// NewGenesisStorage creates a new GenesisStorage instance
+func NewGenesisStorage(key, value sdk.Hash) GenesisStorage {
+	return GenesisStorage{
+		Key:   key,
+		Value: value,
+	}
+}
+
+// Validate performs a basic validation of a GenesisAccount
+func (ga GenesisAccount) Validate() error {
+	if ga.Address.Empty() {
+		return errors.New("contract address cannot be empty")
+	}
+
+	if len(ga.Code) == 0 {
+		return fmt.Errorf("contract %s has no code", ga.Address)
+	}
+
+	if !bytes.Equal(crypto.Sha256(ga.Code), ga.CodeHash) {
+		return fmt.Errorf("contract %s code hash mismatch, expected %s, got %s", ga.Address, hexutil.Encode(crypto.Sha256(ga.Code)), ga.CodeHash)
+	}
+
+	seenStorage := make(map[sdk.Hash]bool)
+	for _, s := range ga.Storage {
+		if seenStorage[s.Key] {
+			return fmt.Errorf("contract %s has duplicated storage key %s", ga.Address, s.Key)
+		}
+		seenStorage[s.Key] = true
+	}
+
+	return nil
 }
 
 func ValidateGenesis(data GenesisState) error {
@@ -26,5 +105,47 @@ func ValidateGenesis(data GenesisState) error {
 	}
 
 	vmCommonGasParams := data.Params.VMCommonGasParams
-	return validateVMCommonGasParams(vmCommonGasParams)
+	if err := validateVMCommonGasParams(vmCommonGasParams); err != nil {
+		return err
+	}
+
+	seenAccounts := make(map[string]bool)
+	for _, acc := range data.Accounts {
+		if seenAccounts[acc.Address.String()] {
+			return fmt.Errorf("duplicated contract account %s", acc.Address)
+		}
+
+		if err := acc.Validate(); err != nil {
+			return err
+		}
+
+		seenAccounts[acc.Address.String()] = true
+	}
+
+	seenTxs := make(map[sdk.Hash]bool)
+	for _, txLogs := range data.TxsLogs {
+		if seenTxs[txLogs.Hash] {
+			return fmt.Errorf("duplicated logs for tx %s", txLogs.Hash)
+		}
+		seenTxs[txLogs.Hash] = true
+	}
+
+	seenContractInfos := make(map[string]bool)
+	for _, info := range data.ContractInfos {
+		if seenContractInfos[info.Address.String()] {
+			return fmt.Errorf("duplicated contract info %s", info.Address)
+		}
+
+		if !seenAccounts[info.Address.String()] {
+			return fmt.Errorf("contract info %s has no contract account", info.Address)
+		}
+
+		if err := info.Validate(); err != nil {
+			return err
+		}
+
+		seenContractInfos[info.Address.String()] = true
+	}
+
+	return nil
 }
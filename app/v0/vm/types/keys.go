@@ -1,7 +1,10 @@
 package types
 
 import (
+	"encoding/binary"
+
 	"github.com/netcloth/netcloth-chain/app/protocol"
+	sdk "github.com/netcloth/netcloth-chain/types"
 )
 
 const (
@@ -14,6 +17,109 @@ const (
 	RouterKey     = ModuleName
 )
 
+const (
+	// MaxTopics - max number of topics of a log, see LOG0 ... LOG4
+	MaxTopics = 4
+
+	// NumBlockHashes - number of the most recent block hashes available to BLOCKHASH
+	NumBlockHashes = 256
+
+	// MaxABILength - max length of a registered contract ABI
+	MaxABILength = 64 * 1024
+
+	// SwarmMetadataHashLength - length of a swarm metadata hash appended to the code by solc
+	SwarmMetadataHashLength = 32
+
+	// IPFSMetadataHashLength - length of an IPFS multihash metadata hash appended to the code by solc
+	IPFSMetadataHashLength = 34
+
+	// MaxMetadataLength - max length of a registered solc metadata, the size of a single IPFS chunk
+	MaxMetadataLength = 256 * 1024
+)
+
 var (
 	LogIndexKey = []byte("logIndexKey")
+
+	// height from which the logs are indexed by height, contract address and topics, in the log store
+	LogsIndexStartHeightKey = []byte("logsIndexStartHeight")
+
+	// block hashes in the storage store, whose other keys are 32 bytes composite keys or debug keys
+	BlockHashKeyPrefix = []byte("blockHash:")
+
+	// contract creators and registered contract infos in the storage store
+	ContractCreatorKeyPrefix = []byte("contractCreator:")
+	ContractInfoKeyPrefix    = []byte("contractInfo:")
+
+	// log indexes in the log store, the logs themselves are stored by tx hash
+	LogByHeightIndexPrefix  = []byte{0x01} // prefix for each key to a tx with logs, by height
+	LogByAddressIndexPrefix = []byte{0x02} // prefix for each key to a tx with logs, by contract address and height
+	LogByTopicIndexPrefix   = []byte{0x03} // prefix for each key to a tx with logs, by topic position, topic and height
 )
+
+// GetBlockHashKey - key for the hash of the block at the given height
+func GetBlockHashKey(height uint64) []byte {
+	return append(BlockHashKeyPrefix, sdk.Uint64ToBigEndian(height)...)
+}
+
+// GetContractCreatorKey - key for the creator of the given contract
+func GetContractCreatorKey(addr sdk.AccAddress) []byte {
+	return append(ContractCreatorKeyPrefix, addr.Bytes()...)
+}
+
+// GetContractInfoKey - key for the registered info of the given contract
+func GetContractInfoKey(addr sdk.AccAddress) []byte {
+	return append(ContractInfoKeyPrefix, addr.Bytes()...)
+}
+
+// GetLogByHeightIndexKey - key for the tx hash of a tx with logs at the given height
+// VALUE: none (key rearrangement used)
+func GetLogByHeightIndexKey(height uint64, txHash sdk.Hash) []byte {
+	return append(GetLogByHeightIndexHeightKey(height), txHash.Bytes()...)
+}
+
+// GetLogByHeightIndexHeightKey - prefix of the txs with logs at the given height
+func GetLogByHeightIndexHeightKey(height uint64) []byte {
+	return append(LogByHeightIndexPrefix, sdk.Uint64ToBigEndian(height)...)
+}
+
+// GetLogByAddressIndexKey - key for the tx hash of a tx with logs of the given contract at the given height
+// VALUE: none (key rearrangement used)
+func GetLogByAddressIndexKey(addr sdk.AccAddress, height uint64, txHash sdk.Hash) []byte {
+	return append(GetLogByAddressIndexHeightKey(addr, height), txHash.Bytes()...)
+}
+
+// GetLogByAddressIndexHeightKey - prefix of the txs with logs of the given contract at the given height
+func GetLogByAddressIndexHeightKey(addr sdk.AccAddress, height uint64) []byte {
+	return append(GetLogByAddressIndexAddressKey(addr), sdk.Uint64ToBigEndian(height)...)
+}
+
+// GetLogByAddressIndexAddressKey - prefix of the txs with logs of the given contract
+func GetLogByAddressIndexAddressKey(addr sdk.AccAddress) []byte {
+	return append(LogByAddressIndexPrefix, addr.Bytes()...)
+}
+
+// GetLogByTopicIndexKey - key for the tx hash of a tx with logs having the topic at the given position and height
+// VALUE: none (key rearrangement used)
+func GetLogByTopicIndexKey(position int, topic sdk.Hash, height uint64, txHash sdk.Hash) []byte {
+	return append(GetLogByTopicIndexHeightKey(position, topic, height), txHash.Bytes()...)
+}
+
+// GetLogByTopicIndexHeightKey - prefix of the txs with logs having the topic at the given position and height
+func GetLogByTopicIndexHeightKey(position int, topic sdk.Hash, height uint64) []byte {
+	return append(GetLogByTopicIndexTopicKey(position, topic), sdk.Uint64ToBigEndian(height)...)
+}
+
+// GetLogByTopicIndexTopicKey - prefix of the txs with logs having the topic at the given position
+func GetLogByTopicIndexTopicKey(position int, topic sdk.Hash) []byte {
+	return append(append(LogByTopicIndexPrefix, byte(position)), topic.Bytes()...)
+}
+
+// GetLogIndexTxHash returns the tx hash of a log index key
+func GetLogIndexTxHash(key []byte) sdk.Hash {
+	return sdk.BytesToHash(key[len(key)-sdk.HashLength:])
+}
+
+// GetLogIndexHeight returns the height of a log index key
+func GetLogIndexHeight(key []byte) uint64 {
+	return binary.BigEndian.Uint64(key[len(key)-sdk.HashLength-8 : len(key)-sdk.HashLength])
+}
@@ -24,3 +24,91 @@ type Log struct {
 
 	Removed bool `json:"removed" yaml:"removed"`
 }
+
+// MaxLogsBlockRange - max number of blocks scanned by a single log filter query
+const MaxLogsBlockRange = 1000
+
+// LogFilter - filter of the logs between FromHeight and ToHeight (both included), a log
+// matches if it's emitted by any of the Addresses, and each of its topics matches any of
+// the Topics at the same position. Empty Addresses or Topics position matches all.
+type LogFilter struct {
+	FromHeight uint64           `json:"from_height" yaml:"from_height"`
+	ToHeight   uint64           `json:"to_height" yaml:"to_height"`
+	Addresses  []sdk.AccAddress `json:"addresses" yaml:"addresses"`
+	Topics     [][]sdk.Hash     `json:"topics" yaml:"topics"`
+}
+
+// NewLogFilter creates a new LogFilter instance, trailing empty topic positions are dropped
+// so that logs with fewer topics still match
+func NewLogFilter(fromHeight, toHeight uint64, addresses []sdk.AccAddress, topics [][]sdk.Hash) LogFilter {
+	for len(topics) > 0 && len(topics[len(topics)-1]) == 0 {
+		topics = topics[:len(topics)-1]
+	}
+
+	return LogFilter{
+		FromHeight: fromHeight,
+		ToHeight:   toHeight,
+		Addresses:  addresses,
+		Topics:     topics,
+	}
+}
+
+// Match reports whether the log matches the filter
+func (f LogFilter) Match(log *Log) bool {
+	if log.BlockNumber < f.FromHeight || log.BlockNumber > f.ToHeight {
+		return false
+	}
+
+	if len(f.Addresses) > 0 {
+		found := false
+		for _, addr := range f.Addresses {
+			if addr.Equals(log.Address) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	if len(f.Topics) > len(log.Topics) {
+		return false
+	}
+
+	for i, sub := range f.Topics {
+		if len(sub) == 0 {
+			continue
+		}
+
+		found := false
+		for _, topic := range sub {
+			if topic == log.Topics[i] {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// firstTopicPosition returns the first topic position with topics to match, -1 if there is none
+func (f LogFilter) firstTopicPosition() int {
+	for i, sub := range f.Topics {
+		if i >= MaxTopics {
+			break
+		}
+
+		if len(sub) > 0 {
+			return i
+		}
+	}
+
+	return -1
+}
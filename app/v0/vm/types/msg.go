@@ -7,11 +7,13 @@ import (
 )
 
 const (
-	TypeMsgContract = "contract"
+	TypeMsgContract         = "contract"
+	TypeMsgContractRegister = "contract_register"
 )
 
 var (
 	_ sdk.Msg = &MsgContract{}
+	_ sdk.Msg = &MsgContractRegister{}
 )
 
 type MsgContract struct {
@@ -74,3 +76,71 @@ func NewMsgContractQuery(from, to sdk.AccAddress, payload []byte, amount sdk.Coi
 		Amount:  amount,
 	}
 }
+
+// MsgContractRegister - registers the ABI and the compiler metadata hash of a contract. It's
+// allowed to the creator of the contract, or to anyone giving the solc metadata of the contract,
+// whose IPFS hash is embedded in the code of the contract by the Solidity compiler, the ABI
+// being taken from the metadata.
+type MsgContractRegister struct {
+	From         sdk.AccAddress `json:"from" yaml:"from"`
+	Contract     sdk.AccAddress `json:"contract" yaml:"contract"`
+	ABI          string         `json:"abi" yaml:"abi"`
+	MetadataHash hexutil.Bytes  `json:"metadata_hash" yaml:"metadata_hash"`
+	Metadata     string         `json:"metadata,omitempty" yaml:"metadata"`
+}
+
+func NewMsgContractRegister(from, contract sdk.AccAddress, abiJSON string, metadataHash []byte) MsgContractRegister {
+	return MsgContractRegister{
+		From:         from,
+		Contract:     contract,
+		ABI:          abiJSON,
+		MetadataHash: metadataHash,
+	}
+}
+
+// NewMsgContractRegisterWithMetadata creates a MsgContractRegister giving the solc metadata of the contract
+func NewMsgContractRegisterWithMetadata(from, contract sdk.AccAddress, metadata string) MsgContractRegister {
+	return MsgContractRegister{
+		From:     from,
+		Contract: contract,
+		Metadata: metadata,
+	}
+}
+
+// ContractInfo returns the contract info to register, taken from the metadata if given
+func (msg MsgContractRegister) ContractInfo() (ContractInfo, error) {
+	if len(msg.Metadata) == 0 {
+		return NewContractInfo(msg.Contract, msg.From, msg.ABI, msg.MetadataHash), nil
+	}
+
+	if len(msg.ABI) != 0 || len(msg.MetadataHash) != 0 {
+		return ContractInfo{}, sdkerrors.Wrap(ErrInvalidMetadata, "the abi and the metadata hash are taken from the metadata")
+	}
+	return NewContractInfoFromMetadata(msg.Contract, msg.From, msg.Metadata)
+}
+
+func (msg MsgContractRegister) Route() string {
+	return RouterKey
+}
+
+func (msg MsgContractRegister) Type() string {
+	return TypeMsgContractRegister
+}
+
+func (msg MsgContractRegister) ValidateBasic() error {
+	info, err := msg.ContractInfo()
+	if err != nil {
+		return err
+	}
+
+	return info.Validate()
+}
+
+func (msg MsgContractRegister) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+func (msg MsgContractRegister) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.From}
+}
@@ -12,6 +12,12 @@ const (
 
 	DefaultContractCreationGas = 53000
 	DefaultCreateDataGas       = 200
+
+	DefaultBn256AddGas             = 150   // Gas needed for an elliptic curve addition
+	DefaultBn256ScalarMulGas       = 6000  // Gas needed for an elliptic curve scalar multiplication
+	DefaultBn256PairingBaseGas     = 45000 // Base price for an elliptic curve pairing check
+	DefaultBn256PairingPerPointGas = 34000 // Per-point price for an elliptic curve pairing check
+	DefaultBlake2FPerRoundGas      = 1     // Per-round price for a blake2 F compression
 )
 
 var (
@@ -30,12 +36,48 @@ var (
 		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 32000, 700, 700, 0, 700, 32000, 0, 0, 0, 0, 700, 0, 0, 0, 0, 0, //224-255
 	}
 
-	DefaultVMCommonGasParams = VMCommonGasParams{ContractCreationGas: DefaultContractCreationGas, CreateDataGas: DefaultCreateDataGas} //protocol_params.go::CreateDataGas
+	DefaultVMCommonGasParams = VMCommonGasParams{
+		ContractCreationGas:     DefaultContractCreationGas,
+		CreateDataGas:           DefaultCreateDataGas, //protocol_params.go::CreateDataGas
+		Bn256AddGas:             DefaultBn256AddGas,
+		Bn256ScalarMulGas:       DefaultBn256ScalarMulGas,
+		Bn256PairingBaseGas:     DefaultBn256PairingBaseGas,
+		Bn256PairingPerPointGas: DefaultBn256PairingPerPointGas,
+		Blake2FPerRoundGas:      DefaultBlake2FPerRoundGas,
+	}
 )
 
 type VMCommonGasParams struct {
 	ContractCreationGas uint64 `json:"contract_creation_gas" yaml:"contract_creation_gas"`
 	CreateDataGas       uint64 `json:"create_data_gas" yaml:"create_data_gas"`
+
+	// gas of the precompiled contracts
+	Bn256AddGas             uint64 `json:"bn256_add_gas" yaml:"bn256_add_gas"`
+	Bn256ScalarMulGas       uint64 `json:"bn256_scalar_mul_gas" yaml:"bn256_scalar_mul_gas"`
+	Bn256PairingBaseGas     uint64 `json:"bn256_pairing_base_gas" yaml:"bn256_pairing_base_gas"`
+	Bn256PairingPerPointGas uint64 `json:"bn256_pairing_per_point_gas" yaml:"bn256_pairing_per_point_gas"`
+	Blake2FPerRoundGas      uint64 `json:"blake2f_per_round_gas" yaml:"blake2f_per_round_gas"`
+}
+
+// WithDefaults returns the params with the default gas of the precompiled contracts in place of
+// the zero values, which are decoded from the params stored before these contracts were enabled
+func (v VMCommonGasParams) WithDefaults() VMCommonGasParams {
+	if v.Bn256AddGas == 0 {
+		v.Bn256AddGas = DefaultBn256AddGas
+	}
+	if v.Bn256ScalarMulGas == 0 {
+		v.Bn256ScalarMulGas = DefaultBn256ScalarMulGas
+	}
+	if v.Bn256PairingBaseGas == 0 {
+		v.Bn256PairingBaseGas = DefaultBn256PairingBaseGas
+	}
+	if v.Bn256PairingPerPointGas == 0 {
+		v.Bn256PairingPerPointGas = DefaultBn256PairingPerPointGas
+	}
+	if v.Blake2FPerRoundGas == 0 {
+		v.Blake2FPerRoundGas = DefaultBlake2FPerRoundGas
+	}
+	return v
 }
 
 type Params struct {
@@ -107,5 +149,25 @@ func validateVMCommonGasParams(i interface{}) error {
 		return fmt.Errorf("create_data_gas must be positive: %d", v.CreateDataGas)
 	}
 
+	if v.Bn256AddGas == 0 {
+		return fmt.Errorf("bn256_add_gas must be positive: %d", v.Bn256AddGas)
+	}
+
+	if v.Bn256ScalarMulGas == 0 {
+		return fmt.Errorf("bn256_scalar_mul_gas must be positive: %d", v.Bn256ScalarMulGas)
+	}
+
+	if v.Bn256PairingBaseGas == 0 {
+		return fmt.Errorf("bn256_pairing_base_gas must be positive: %d", v.Bn256PairingBaseGas)
+	}
+
+	if v.Bn256PairingPerPointGas == 0 {
+		return fmt.Errorf("bn256_pairing_per_point_gas must be positive: %d", v.Bn256PairingPerPointGas)
+	}
+
+	if v.Blake2FPerRoundGas == 0 {
+		return fmt.Errorf("blake2f_per_round_gas must be positive: %d", v.Blake2FPerRoundGas)
+	}
+
 	return nil
 }
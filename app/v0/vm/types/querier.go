@@ -3,18 +3,22 @@ package types
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
 	sdk "github.com/netcloth/netcloth-chain/types"
 )
 
 const (
-	QueryParameters = "params"
-	QueryState      = "state"
-	QueryCode       = "code"
-	QueryStorage    = "storage"
-	QueryTxLogs     = "logs"
-	EstimateGas     = "estimate_gas"
-	QueryCall       = "call"
+	QueryParameters   = "params"
+	QueryState        = "state"
+	QueryCode         = "code"
+	QueryStorage      = "storage"
+	QueryTxLogs       = "logs"
+	QueryFilterLogs   = "filter_logs"
+	QueryTrace        = "trace"
+	QueryContractInfo = "contract_info"
+	EstimateGas       = "estimate_gas"
+	QueryCall         = "call"
 )
 
 // QueryLogsResult - for query logs
@@ -64,3 +68,84 @@ type QueryStateParams struct {
 	ShowCode     bool `json:"show_code" yaml:"show_code"`
 	ContractOnly bool `json:"contract_only" yaml:"contract_only"`
 }
+
+// TraceConfig - options of the tracer used to re-execute a transaction
+type TraceConfig struct {
+	Tracer         string `json:"tracer" yaml:"tracer"` // TracerStruct or TracerCall, the struct logger if empty
+	DisableMemory  bool   `json:"disable_memory" yaml:"disable_memory"`
+	DisableStack   bool   `json:"disable_stack" yaml:"disable_stack"`
+	DisableStorage bool   `json:"disable_storage" yaml:"disable_storage"`
+	Limit          int    `json:"limit" yaml:"limit"` // maximum number of struct logs, zero means unlimited
+}
+
+// supported tracers
+const (
+	TracerStruct = "struct"
+	TracerCall   = "call"
+)
+
+// TraceTx - a transaction of a block, for replaying the state changes of its ante
+// handler and its vm messages
+type TraceTx struct {
+	Signers []sdk.AccAddress `json:"signers" yaml:"signers"` // the first signer is the fee payer
+	Fee     sdk.Coins        `json:"fee" yaml:"fee"`
+	Msgs    []MsgContract    `json:"msgs" yaml:"msgs"` // the vm messages of the transaction
+}
+
+// QueryTraceParams - for tracing a vm message of a transaction, which is re-executed on the
+// state of the previous block after the preceding transactions of its block are replayed
+type QueryTraceParams struct {
+	Height    int64       `json:"height" yaml:"height"`
+	Time      time.Time   `json:"time" yaml:"time"`
+	TxHash    sdk.Hash    `json:"tx_hash" yaml:"tx_hash"`
+	Gas       uint64      `json:"gas" yaml:"gas"` // gas limit of the transaction
+	Tx        TraceTx     `json:"tx" yaml:"tx"`
+	MsgIndex  int         `json:"msg_index" yaml:"msg_index"` // index of the traced message in Tx.Msgs
+	Preceding []TraceTx   `json:"preceding" yaml:"preceding"`
+	Config    TraceConfig `json:"config" yaml:"config"`
+}
+
+// StructLogRes - a formatted StructLog
+type StructLogRes struct {
+	Pc      uint64            `json:"pc"`
+	Op      string            `json:"op"`
+	Gas     uint64            `json:"gas"`
+	GasCost uint64            `json:"gasCost"`
+	Depth   int               `json:"depth"`
+	Error   string            `json:"error,omitempty"`
+	Stack   []string          `json:"stack,omitempty"`
+	Memory  []string          `json:"memory,omitempty"`
+	Storage map[string]string `json:"storage,omitempty"`
+}
+
+// CallFrame - a call, or contract creation, of the call tree of a transaction
+type CallFrame struct {
+	Type    string       `json:"type"`
+	From    string       `json:"from"`
+	To      string       `json:"to,omitempty"`
+	Value   string       `json:"value,omitempty"`
+	Gas     uint64       `json:"gas"`
+	GasUsed uint64       `json:"gasUsed"`
+	Input   string       `json:"input"`
+	Output  string       `json:"output,omitempty"`
+	Error   string       `json:"error,omitempty"`
+	Calls   []*CallFrame `json:"calls,omitempty"`
+}
+
+// TraceResult - the trace of a transaction, StructLogs is set by the struct logger
+// and Calls by the call tracer
+type TraceResult struct {
+	Gas         uint64         `json:"gas"`
+	Failed      bool           `json:"failed"`
+	ReturnValue string         `json:"returnValue"`
+	StructLogs  []StructLogRes `json:"structLogs,omitempty"`
+	Calls       *CallFrame     `json:"calls,omitempty"`
+}
+
+func (r TraceResult) String() string {
+	j, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Sprintf("Gas = %d\nFailed = %t\nReturnValue = %s", r.Gas, r.Failed, r.ReturnValue)
+	}
+	return string(j)
+}
@@ -0,0 +1,68 @@
+package types
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+var (
+	// selectors of the Solidity builtin errors
+	errorSelector = []byte{0x08, 0xc3, 0x79, 0xa0} // Error(string)
+	panicSelector = []byte{0x4e, 0x48, 0x7b, 0x71} // Panic(uint256)
+
+	// panic codes of the Solidity compiler
+	panicReasons = map[uint64]string{
+		0x00: "generic compiler inserted panic",
+		0x01: "assertion failed",
+		0x11: "arithmetic underflow or overflow",
+		0x12: "division or modulo by zero",
+		0x21: "enum overflow",
+		0x22: "invalid encoded storage byte array accessed",
+		0x31: "pop on empty array",
+		0x32: "array index out of bounds",
+		0x41: "out of memory",
+		0x51: "call to zero-initialized variable of internal function type",
+	}
+)
+
+// DecodeRevertReason decodes the data returned by a reverted execution: the message of
+// Error(string), the code of Panic(uint256), or the raw data of a custom error. It
+// returns an empty string if no data is returned.
+func DecodeRevertReason(data []byte) string {
+	if len(data) == 0 {
+		return ""
+	}
+
+	if len(data) >= 4 {
+		switch {
+		case bytes.Equal(data[:4], errorSelector):
+			if values, err := unpack("string", data[4:]); err == nil {
+				return values[0].(string)
+			}
+		case bytes.Equal(data[:4], panicSelector):
+			if values, err := unpack("uint256", data[4:]); err == nil {
+				code := values[0].(*big.Int)
+				if code.IsUint64() {
+					if reason, ok := panicReasons[code.Uint64()]; ok {
+						return fmt.Sprintf("panic: %s (0x%x)", reason, code)
+					}
+				}
+				return fmt.Sprintf("panic: unknown code 0x%x", code)
+			}
+		}
+	}
+
+	return fmt.Sprintf("custom error: %x", data)
+}
+
+func unpack(typ string, data []byte) ([]interface{}, error) {
+	t, err := abi.NewType(typ, "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return abi.Arguments{{Type: t}}.UnpackValues(data)
+}
@@ -0,0 +1,35 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+func TestDecodeRevertReason(t *testing.T) {
+	testCases := []struct {
+		name     string
+		data     string
+		expected string
+	}{
+		{"no data", "", ""},
+		{"error string", "08c379a0" +
+			"0000000000000000000000000000000000000000000000000000000000000020" +
+			"0000000000000000000000000000000000000000000000000000000000000016" +
+			"696e73756666696369656e7420616c6c6f77616e636500000000000000000000", "insufficient allowance"},
+		{"panic", "4e487b71" +
+			"0000000000000000000000000000000000000000000000000000000000000011", "panic: arithmetic underflow or overflow (0x11)"},
+		{"unknown panic", "4e487b71" +
+			"00000000000000000000000000000000000000000000000000000000000000ff", "panic: unknown code 0xff"},
+		{"custom error", "fb8f41b2" +
+			"0000000000000000000000000000000000000000000000000000000000000001", "custom error: fb8f41b20000000000000000000000000000000000000000000000000000000000000001"},
+		{"malformed error string", "08c379a00000", "custom error: 08c379a00000"},
+		{"short data", "0102", "custom error: 0102"},
+	}
+
+	for _, tc := range testCases {
+		require.Equal(t, tc.expected, DecodeRevertReason(sdk.FromHex(tc.data)), tc.name)
+	}
+}
@@ -181,6 +181,20 @@ func (so *stateObject) setBalance(amount sdk.Int) {
 	so.account.SetBalance(amount)
 }
 
+// SetCoins sets the state object's coins of all denominations.
+func (so *stateObject) SetCoins(coins sdk.Coins) {
+	so.stateDB.journal.append(coinsChange{
+		account: &so.address,
+		prev:    so.account.GetCoins(),
+	})
+
+	so.setCoins(coins)
+}
+
+func (so *stateObject) setCoins(coins sdk.Coins) {
+	so.account.Coins = coins
+}
+
 // SetNonce sets the state object's nonce (sequence number).
 func (so *stateObject) SetNonce(nonce uint64) {
 	so.stateDB.journal.append(nonceChange{
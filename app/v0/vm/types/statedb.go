@@ -1,6 +1,7 @@
 package types
 
 import (
+	"encoding/binary"
 	"encoding/json"
 	"fmt"
 	"math/big"
@@ -49,6 +50,11 @@ type CommitStateDB struct {
 	txIndex      int
 	logs         map[sdk.Hash][]*Log
 
+	// writes of the native module state changed by the native contracts, the
+	// context of each one is a branch of the previous one, see RunNative
+	nativeWrites []func()
+	nativeBase   sdk.Context
+
 	// TODO: Determine if we actually need this as we do not need preimages in
 	// the SDK, but it seems to be used elsewhere in Geth.
 	preimages map[sdk.Hash][]byte
@@ -99,6 +105,7 @@ func NewStateDB(db *CommitStateDB) *CommitStateDB {
 		ak:                db.ak,
 		storageKey:        db.storageKey,
 		codeKey:           db.codeKey,
+		logKey:            db.logKey,
 		storageDebugKey:   db.storageDebugKey,
 		stateObjects:      make(map[string]*stateObject),
 		stateObjectsDirty: make(map[string]struct{}),
@@ -109,9 +116,11 @@ func NewStateDB(db *CommitStateDB) *CommitStateDB {
 	}
 }
 
-// WithContext returns a Database with an updated sdk context
+// WithContext returns a Database with an updated sdk context, the native module
+// state changes not yet written by Finalise are discarded
 func (csdb *CommitStateDB) WithContext(ctx sdk.Context) *CommitStateDB {
 	csdb.ctx = ctx
+	csdb.nativeWrites = nil
 	return csdb
 }
 
@@ -326,6 +335,11 @@ func (csdb *CommitStateDB) GetLogs(hash sdk.Hash) (logs []*Log) {
 	return
 }
 
+// TxLogs returns the logs emitted so far by the current transaction.
+func (csdb *CommitStateDB) TxLogs() []*Log {
+	return csdb.logs[csdb.thash]
+}
+
 // Logs returns all the current logs in the state.
 func (csdb *CommitStateDB) Logs() []*Log { // todo: is should get all logs from store?
 	var logs []*Log
@@ -406,7 +420,6 @@ func (csdb *CommitStateDB) Commit(deleteEmptyObjects bool) (root sdk.Hash, err e
 
 func (csdb *CommitStateDB) commitLogs() {
 	ctx := csdb.ctx
-	store := ctx.KVStore(csdb.logKey)
 
 	var hs []string
 	for h := range csdb.logs {
@@ -416,14 +429,12 @@ func (csdb *CommitStateDB) commitLogs() {
 
 	for _, h := range hs {
 		hash := sdk.HexToHash(h)
-		d, err := json.Marshal(csdb.logs[hash])
-		if err != nil {
+		if err := csdb.SetLogs(hash, csdb.logs[hash]); err != nil {
 			ctx.Logger().Error(err.Error())
 			continue
 		}
 
-		ctx.Logger().Debug("save log----", hash.String(), ":", string(d))
-		store.Set(hash.Bytes(), d)
+		ctx.Logger().Debug("save log----", hash.String())
 	}
 }
 
@@ -490,10 +501,73 @@ func (csdb *CommitStateDB) Finalise(deleteEmptyObjects bool) {
 	csdb.commitLogs()
 	csdb.ClearLogs()
 
+	// write the native module state changes, from the innermost branch to the base context
+	if len(csdb.nativeWrites) > 0 {
+		for i := len(csdb.nativeWrites) - 1; i >= 0; i-- {
+			csdb.nativeWrites[i]()
+		}
+		csdb.nativeWrites = nil
+		csdb.ctx = csdb.nativeBase
+	}
+
 	// invalidate journal because reverting across transactions is not allowed
 	csdb.clearJournalAndRefund()
 }
 
+// QueryNative runs fn against a discarded branch of the current state, in which
+// the live accounts are visible to the native modules.
+func (csdb *CommitStateDB) QueryNative(fn func(ctx sdk.Context) error) error {
+	branchCtx, _ := csdb.ctx.CacheContext()
+	for _, so := range csdb.stateObjects {
+		if !so.deleted {
+			csdb.ak.SetAccount(branchCtx, so.account)
+		}
+	}
+
+	return fn(branchCtx)
+}
+
+// RunNative runs fn against a branch of the current state, so that the state
+// changes of the native modules are reverted with the journal. The live accounts
+// are made visible to the native modules, and their coins changed by fn are
+// synced back to the state objects. On success the branch becomes the current
+// context, it's written at Finalise.
+func (csdb *CommitStateDB) RunNative(fn func(ctx sdk.Context) error) error {
+	branchCtx, write := csdb.ctx.CacheContext()
+
+	liveAddrs := make(map[string]struct{}, len(csdb.stateObjects))
+	for addr, so := range csdb.stateObjects {
+		liveAddrs[addr] = struct{}{}
+		if !so.deleted {
+			csdb.ak.SetAccount(branchCtx, so.account)
+		}
+	}
+
+	if err := fn(branchCtx); err != nil {
+		return err
+	}
+
+	if len(csdb.nativeWrites) == 0 {
+		csdb.nativeBase = csdb.ctx
+	}
+	csdb.journal.append(nativeChange{prevCtx: csdb.ctx, liveAddrs: liveAddrs})
+	csdb.ctx = branchCtx
+	csdb.nativeWrites = append(csdb.nativeWrites, write)
+
+	for _, so := range csdb.stateObjects {
+		if so.deleted {
+			continue
+		}
+
+		acc := csdb.ak.GetAccount(branchCtx, so.address)
+		if acc != nil && acc.GetCoins().String() != so.account.GetCoins().String() {
+			so.SetCoins(acc.GetCoins())
+		}
+	}
+
+	return nil
+}
+
 // IntermediateRoot returns the current root hash of the state. It is called in
 // between transactions to get the root hash that goes into transaction
 // receipts.
@@ -741,32 +815,189 @@ func (csdb *CommitStateDB) Copy() *CommitStateDB {
 }
 
 // ForEachStorage iterates over each storage items, all invokes the provided
-// callback on each key, value pair .
+// callback on each key, value pair. The iteration stops once the callback
+// returns false.
+//
+// NOTE: the keys passed to the callback are the composite keys of the storage
+// store (see GetStorageByAddressKey), as the original keys are not persisted.
 func (csdb *CommitStateDB) ForEachStorage(addr sdk.AccAddress, cb func(key, value sdk.Hash) bool) error {
 	so := csdb.getStateObject(addr)
 	if so == nil {
 		return nil
 	}
 
-	store := csdb.ctx.KVStore(csdb.storageKey)
-	iter := sdk.KVStorePrefixIterator(store, so.Address().Bytes())
+	prefix := append(append([]byte{}, DEBUG_KEY_PREFIX...), so.address.Bytes()...)
+	store := csdb.ctx.KVStore(csdb.storageDebugKey)
+	iter := sdk.KVStorePrefixIterator(store, prefix)
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		key := sdk.BytesToHash(iter.Key()[len(prefix):])
+		value := sdk.BytesToHash(iter.Value())
+
+		if dirtyValue, dirty := so.dirtyStorage[key]; dirty {
+			value = dirtyValue
+		}
+
+		if !cb(key, value) {
+			break
+		}
+	}
+
+	return nil
+}
+
+// SetCommittedState writes a storage item of an account, keyed by its
+// composite key, directly to the storage store. It is used to restore the
+// storage exported by ForEachStorage.
+func (csdb *CommitStateDB) SetCommittedState(addr sdk.AccAddress, key, value sdk.Hash) {
+	var kv DebugAccKV
+	k, v := kv.Reset(addr, key, value).DebugAccKVToKV()
+
+	csdb.ctx.KVStore(csdb.storageKey).Set(key.Bytes(), value.Bytes())
+	csdb.ctx.KVStore(csdb.storageDebugKey).Set(k, v.Bytes())
+}
+
+// ForEachTxLogs iterates over the logs of all the transactions in the log
+// store. The iteration stops once the callback returns false.
+func (csdb *CommitStateDB) ForEachTxLogs(cb func(hash sdk.Hash, logs []*Log) bool) {
+	store := csdb.ctx.KVStore(csdb.logKey)
+	iter := store.Iterator(nil, nil)
+	defer iter.Close()
 
 	for ; iter.Valid(); iter.Next() {
-		key := sdk.BytesToHash(iter.Key())
-		value := iter.Value()
+		// skip the log index counter and the log indexes
+		if len(iter.Key()) != sdk.HashLength {
+			continue
+		}
 
-		if value, dirty := so.dirtyStorage[key]; dirty {
-			cb(key, value)
+		var logs []*Log
+		if err := json.Unmarshal(iter.Value(), &logs); err != nil {
+			csdb.ctx.Logger().Error(err.Error())
 			continue
 		}
 
-		cb(key, sdk.BytesToHash(value))
+		if !cb(sdk.BytesToHash(iter.Key()), logs) {
+			break
+		}
+	}
+}
+
+// SetLogs sets the logs for a transaction in the log store, and indexes the
+// transaction by the height, contract address and topics of the logs.
+func (csdb *CommitStateDB) SetLogs(hash sdk.Hash, logs []*Log) error {
+	d, err := json.Marshal(logs)
+	if err != nil {
+		return err
+	}
+
+	store := csdb.ctx.KVStore(csdb.logKey)
+	store.Set(hash.Bytes(), d)
+
+	for _, log := range logs {
+		// index, store empty bytes
+		store.Set(GetLogByHeightIndexKey(log.BlockNumber, hash), []byte{})
+		store.Set(GetLogByAddressIndexKey(log.Address, log.BlockNumber, hash), []byte{})
+		for i, topic := range log.Topics {
+			if i >= MaxTopics {
+				break
+			}
+			store.Set(GetLogByTopicIndexKey(i, topic, log.BlockNumber, hash), []byte{})
+		}
 	}
 
-	iter.Close()
 	return nil
 }
 
+// FilterLogs returns the logs between the given heights matching the
+// addresses and topics of the filter, using the most selective log index.
+func (csdb *CommitStateDB) FilterLogs(filter LogFilter) (logs []*Log) {
+	store := csdb.ctx.KVStore(csdb.logKey)
+
+	var iters []sdk.Iterator
+	switch {
+	case len(filter.Addresses) > 0:
+		for _, addr := range filter.Addresses {
+			iters = append(iters, store.Iterator(
+				GetLogByAddressIndexHeightKey(addr, filter.FromHeight),
+				GetLogByAddressIndexHeightKey(addr, filter.ToHeight+1),
+			))
+		}
+
+	case filter.firstTopicPosition() >= 0:
+		position := filter.firstTopicPosition()
+		for _, topic := range filter.Topics[position] {
+			iters = append(iters, store.Iterator(
+				GetLogByTopicIndexHeightKey(position, topic, filter.FromHeight),
+				GetLogByTopicIndexHeightKey(position, topic, filter.ToHeight+1),
+			))
+		}
+
+	default:
+		iters = append(iters, store.Iterator(
+			GetLogByHeightIndexHeightKey(filter.FromHeight),
+			GetLogByHeightIndexHeightKey(filter.ToHeight+1),
+		))
+	}
+
+	// collect the txs ordered by height, and drop the duplicates found by several iterators
+	type indexedTx struct {
+		height uint64
+		hash   sdk.Hash
+	}
+	var txs []indexedTx
+	seen := make(map[sdk.Hash]bool)
+	for _, iter := range iters {
+		for ; iter.Valid(); iter.Next() {
+			hash := GetLogIndexTxHash(iter.Key())
+			if !seen[hash] {
+				seen[hash] = true
+				txs = append(txs, indexedTx{height: GetLogIndexHeight(iter.Key()), hash: hash})
+			}
+		}
+		iter.Close()
+	}
+	sort.SliceStable(txs, func(i, j int) bool {
+		return txs[i].height < txs[j].height
+	})
+
+	for _, tx := range txs {
+		for _, log := range csdb.GetLogs(tx.hash) {
+			if filter.Match(log) {
+				logs = append(logs, log)
+			}
+		}
+	}
+
+	return logs
+}
+
+// GetLogIndex returns the index of the last log in the log store.
+func (csdb *CommitStateDB) GetLogIndex() uint64 {
+	d := csdb.ctx.KVStore(csdb.logKey).Get(LogIndexKey)
+	return new(big.Int).SetBytes(d).Uint64()
+}
+
+// SetLogIndex sets the index of the last log in the log store.
+func (csdb *CommitStateDB) SetLogIndex(index uint64) {
+	csdb.ctx.KVStore(csdb.logKey).Set(LogIndexKey, new(big.Int).SetUint64(index).Bytes())
+}
+
+// GetLogsIndexStartHeight returns the height from which the logs are indexed by height, contract
+// address and topics, the logs of the previous blocks being stored before the indexes.
+func (csdb *CommitStateDB) GetLogsIndexStartHeight() (height uint64, found bool) {
+	d := csdb.ctx.KVStore(csdb.logKey).Get(LogsIndexStartHeightKey)
+	if d == nil {
+		return 0, false
+	}
+	return binary.BigEndian.Uint64(d), true
+}
+
+// SetLogsIndexStartHeight sets the height from which the logs are indexed.
+func (csdb *CommitStateDB) SetLogsIndexStartHeight(height uint64) {
+	csdb.ctx.KVStore(csdb.logKey).Set(LogsIndexStartHeightKey, sdk.Uint64ToBigEndian(height))
+}
+
 // GetOrNewStateObject retrieves a state object or create a new state object if
 // nil.
 func (csdb *CommitStateDB) GetOrNewStateObject(addr sdk.AccAddress) StateObject {
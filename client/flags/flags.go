@@ -50,6 +50,7 @@ const (
 	FlagGenerateOnly       = "generate-only"
 	FlagIndentResponse     = "indent"
 	FlagListenAddr         = "laddr"
+	FlagJSONRPCListenAddr  = "jsonrpc-laddr"
 	FlagMaxOpenConnections = "max-open"
 	FlagRPCReadTimeout     = "read-timeout"
 	FlagRPCWriteTimeout    = "write-timeout"
@@ -121,6 +122,7 @@ func RegisterRestServerFlags(cmd *cobra.Command) *cobra.Command {
 	cmd.Flags().Uint(FlagMaxOpenConnections, 1000, "The number of maximum open connections")
 	cmd.Flags().Uint(FlagRPCReadTimeout, 10, "The RPC read timeout (in seconds)")
 	cmd.Flags().Uint(FlagRPCWriteTimeout, 10, "The RPC write timeout (in seconds)")
+	cmd.Flags().String(FlagJSONRPCListenAddr, "", "The address for the Ethereum compatible JSON-RPC server to listen on (e.g. tcp://localhost:8545), disabled if empty")
 
 	return cmd
 }
@@ -30,6 +30,9 @@ type RestServer struct {
 	CliCtx  context.CLIContext
 	KeyBase keybase.Keybase
 
+	// JSONRPC is served on its own listener when --jsonrpc-laddr is set
+	JSONRPC http.Handler
+
 	log      log.Logger
 	listener net.Listener
 }
@@ -73,6 +76,22 @@ func (rs *RestServer) Start(listenAddr string, maxOpen int, readTimeout, writeTi
 	return rpcserver.StartHTTPServer(rs.listener, rs.Mux, rs.log, cfg)
 }
 
+// StartJSONRPC starts the JSON-RPC server
+func (rs *RestServer) StartJSONRPC(listenAddr string, maxOpen int, readTimeout, writeTimeout uint) error {
+	cfg := rpcserver.DefaultConfig()
+	cfg.MaxOpenConnections = maxOpen
+	cfg.ReadTimeout = time.Duration(readTimeout) * time.Second
+	cfg.WriteTimeout = time.Duration(writeTimeout) * time.Second
+
+	listener, err := rpcserver.Listen(listenAddr, cfg)
+	if err != nil {
+		return err
+	}
+	rs.log.Info(fmt.Sprintf("Starting JSON-RPC service on %s...", listenAddr))
+
+	return rpcserver.StartHTTPServer(listener, rs.JSONRPC, rs.log, cfg)
+}
+
 // ServeCommand will start the application REST service as a blocking process. It
 // takes a codec to create a RestServer object and a function to register all
 // necessary routes.
@@ -86,6 +105,19 @@ func ServeCommand(cdc *codec.Codec, registerRoutesFn func(*RestServer)) *cobra.C
 			registerRoutesFn(rs)
 			rs.registerSwaggerUI()
 
+			if laddr := viper.GetString(flags.FlagJSONRPCListenAddr); laddr != "" && rs.JSONRPC != nil {
+				go func() {
+					if err := rs.StartJSONRPC(
+						laddr,
+						viper.GetInt(flags.FlagMaxOpenConnections),
+						uint(viper.GetInt(flags.FlagRPCReadTimeout)),
+						uint(viper.GetInt(flags.FlagRPCWriteTimeout)),
+					); err != nil {
+						rs.log.Error("error starting JSON-RPC server", "err", err)
+					}
+				}()
+			}
+
 			// Start the rest server and return error if one exists
 			err = rs.Start(
 				viper.GetString(flags.FlagListenAddr),
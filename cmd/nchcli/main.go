@@ -20,6 +20,7 @@ import (
 	cipalcli "github.com/netcloth/netcloth-chain/app/v0/cipal/client/cli"
 	ipalcli "github.com/netcloth/netcloth-chain/app/v0/ipal/client/cli"
 	vmcli "github.com/netcloth/netcloth-chain/app/v0/vm/client/cli"
+	vmjsonrpc "github.com/netcloth/netcloth-chain/app/v0/vm/client/jsonrpc"
 	"github.com/netcloth/netcloth-chain/client"
 	"github.com/netcloth/netcloth-chain/client/keys"
 	"github.com/netcloth/netcloth-chain/client/lcd"
@@ -76,6 +77,7 @@ func registerRoutes(rs *lcd.RestServer) {
 	client.RegisterRoutes(rs.CliCtx, rs.Mux)
 	authrest.RegisterTxRoutes(rs.CliCtx, rs.Mux)
 	v0.ModuleBasics.RegisterRESTRoutes(rs.CliCtx, rs.Mux)
+	rs.JSONRPC = vmjsonrpc.NewServer(rs.CliCtx)
 }
 
 func queryCmd(cdc *amino.Codec) *cobra.Command {
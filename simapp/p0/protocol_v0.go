@@ -18,6 +18,7 @@ import (
 	"github.com/netcloth/netcloth-chain/app/v0/gov"
 	"github.com/netcloth/netcloth-chain/app/v0/guardian"
 	"github.com/netcloth/netcloth-chain/app/v0/ipal"
+	ipalclient "github.com/netcloth/netcloth-chain/app/v0/ipal/client"
 	"github.com/netcloth/netcloth-chain/app/v0/mint"
 	"github.com/netcloth/netcloth-chain/app/v0/params"
 	paramsclient "github.com/netcloth/netcloth-chain/app/v0/params/client"
@@ -25,6 +26,7 @@ import (
 	"github.com/netcloth/netcloth-chain/app/v0/staking"
 	"github.com/netcloth/netcloth-chain/app/v0/supply"
 	"github.com/netcloth/netcloth-chain/app/v0/upgrade"
+	upgradeclient "github.com/netcloth/netcloth-chain/app/v0/upgrade/client"
 	"github.com/netcloth/netcloth-chain/app/v0/upgrade/types"
 	"github.com/netcloth/netcloth-chain/app/v0/vm"
 	"github.com/netcloth/netcloth-chain/codec"
@@ -45,7 +47,7 @@ var ModuleBasics = module.NewBasicManager(
 	staking.AppModuleBasic{},
 	mint.AppModuleBasic{},
 	distr.AppModuleBasic{},
-	gov.NewAppModuleBasic(paramsclient.ProposalHandler, distrclient.ProposalHandler),
+	gov.NewAppModuleBasic(paramsclient.ProposalHandler, distrclient.ProposalHandler, ipalclient.ProposalHandler, upgradeclient.ProposalHandler),
 	params.AppModuleBasic{},
 	crisis.AppModuleBasic{},
 	slashing.AppModuleBasic{},
@@ -237,16 +239,18 @@ func (p *ProtocolV0) configKeepers() {
 		p.Cdc, protocol.Keys[slashing.StoreKey], &stakingKeeper, slashingSubspace)
 	p.crisisKeeper = crisis.NewKeeper(crisisSubspace, p.invCheckPeriod, p.SupplyKeeper, auth.FeeCollectorName)
 
-	p.cipalKeeper = cipal.NewKeeper(
-		protocol.Keys[cipal.StoreKey],
-		p.Cdc,
-		cipalSubspace)
-
 	p.ipalKeeper = ipal.NewKeeper(
 		protocol.Keys[ipal.StoreKey],
 		p.Cdc,
 		p.SupplyKeeper,
-		ipalSubspace)
+		ipalSubspace,
+		auth.FeeCollectorName)
+
+	p.cipalKeeper = cipal.NewKeeper(
+		protocol.Keys[cipal.StoreKey],
+		p.Cdc,
+		cipalSubspace,
+		p.ipalKeeper)
 
 	p.vmKeeper = vm.NewKeeper(
 		p.Cdc,
@@ -255,7 +259,12 @@ func (p *ProtocolV0) configKeepers() {
 		protocol.Keys[protocol.VMLogStoreKey],
 		protocol.Keys[protocol.VMStoreKey],
 		vmSubspace,
-		p.AccountKeeper)
+		p.AccountKeeper,
+		p.BankKeeper,
+		&stakingKeeper,
+		p.distrKeeper,
+		p.ipalKeeper,
+		p.cipalKeeper)
 
 	p.guardianKeeper = guardian.NewKeeper(p.Cdc, protocol.Keys[protocol.GuardianStoreKey])
 
@@ -264,14 +273,6 @@ func (p *ProtocolV0) configKeepers() {
 		&stakingKeeper, p.guardianKeeper, p.protocolKeeper,
 	)
 
-	govRouter := gov.NewRouter()
-	govRouter.
-		AddRoute(gov.RouterKey, gov.NewGovProposalHandler(p.GovKeeper)).
-		AddRoute(params.RouterKey, params.NewParamChangeProposalHandler(p.paramsKeeper)).
-		AddRoute(distr.RouterKey, distr.NewCommunityPoolSpendProposalHandler(p.distrKeeper))
-
-	p.GovKeeper.SetRouter(govRouter)
-
 	p.StakingKeeper = *stakingKeeper.SetHooks(
 		staking.NewMultiStakingHooks(p.distrKeeper.Hooks(), p.slashingKeeper.Hooks()),
 	)
@@ -280,7 +281,18 @@ func (p *ProtocolV0) configKeepers() {
 		p.Cdc,
 		protocol.Keys[protocol.UpgradeStoreKey],
 		p.protocolKeeper,
-		p.StakingKeeper)
+		p.StakingKeeper,
+		p.guardianKeeper)
+
+	govRouter := gov.NewRouter()
+	govRouter.
+		AddRoute(gov.RouterKey, gov.NewGovProposalHandler(p.GovKeeper)).
+		AddRoute(params.RouterKey, params.NewParamChangeProposalHandler(p.paramsKeeper)).
+		AddRoute(distr.RouterKey, distr.NewCommunityPoolSpendProposalHandler(p.distrKeeper)).
+		AddRoute(ipal.RouterKey, ipal.NewIPALSlashProposalHandler(p.ipalKeeper)).
+		AddRoute(upgrade.RouterKey, upgrade.NewUpgradeProposalHandler(p.upgradeKeeper))
+
+	p.GovKeeper.SetRouter(govRouter)
 }
 
 func (p *ProtocolV0) configModuleManager() {
@@ -303,9 +315,9 @@ func (p *ProtocolV0) configModuleManager() {
 		guardian.NewAppModule(p.guardianKeeper),
 	)
 
-	moduleManager.SetOrderBeginBlockers(mint.ModuleName, distr.ModuleName, slashing.ModuleName)
+	moduleManager.SetOrderBeginBlockers(mint.ModuleName, distr.ModuleName, slashing.ModuleName, vm.ModuleName, cipal.ModuleName, ipal.ModuleName)
 
-	moduleManager.SetOrderEndBlockers(types.ModuleName, crisis.ModuleName, gov.ModuleName, staking.ModuleName, ipal.ModuleName, vm.ModuleName) // TODO upgrade should be the first or the last?
+	moduleManager.SetOrderEndBlockers(types.ModuleName, crisis.ModuleName, gov.ModuleName, staking.ModuleName, ipal.ModuleName, vm.ModuleName, guardian.ModuleName) // TODO upgrade should be the first or the last?
 
 	// NOTE: The genutils module must occur after staking so that pools are
 	// properly initialized with tokens from genesis accounts.
@@ -37,8 +37,10 @@ func executeGetIPALNodes(t *testing.T, cmdStr string) (nodes itypes.IPALNodes) {
 
 	cdc := MakeCodec()
 
-	err := cdc.UnmarshalJSON([]byte(out), &nodes)
+	var result itypes.IPALNodeListResult
+	err := cdc.UnmarshalJSON([]byte(out), &result)
 	require.NoError(t, err, "acc %v, err %v", string(out), err)
+	nodes = result.Nodes
 
 	return
 }
@@ -59,7 +61,7 @@ func Test_ipal(t *testing.T) {
 	require.Equal(t, initAccount.Coins.AmountOf(types.NativeTokenName), types.NewInt(DefaultGenAccountAmount-1000000000000))
 
 	//failed for bond insufficient
-	r := executeWrite(t, ipalClaimCmd("sky", "sky", "sky", "3|http://47.104.248.183", "100pnch", nchcliHome, port), DefaultKeyPass)
+	r := executeWrite(t, ipalClaimCmd("sky", "sky", "sky", "2|http://47.104.248.183", "100pnch", nchcliHome, port), DefaultKeyPass)
 	require.True(t, r)
 	tests.WaitForNextNBlocksTM(1, port)
 	nodes := executeGetIPALNodes(t, ipalQueryCmd(nchcliHome, port))
@@ -81,13 +83,13 @@ func Test_ipal(t *testing.T) {
 
 	//update bond amount
 	bondAmount = types.NewCoin(types.NativeTokenName, types.NewInt(2000000000000000))
-	r = executeWrite(t, ipalClaimCmd("sky", "sky", "sky", "3|http://47.104.248.183", bondAmount.String(), nchcliHome, port), DefaultKeyPass)
+	r = executeWrite(t, ipalClaimCmd("sky", "sky", "sky", "2|http://47.104.248.183", bondAmount.String(), nchcliHome, port), DefaultKeyPass)
 	require.True(t, r)
 	tests.WaitForNextNBlocksTM(1, port)
 	nodes = executeGetIPALNodes(t, ipalQueryCmd(nchcliHome, port))
 	require.Equal(t, len(nodes), 1)
 	require.Equal(t, nodes[0].Bond, bondAmount)
-	require.Equal(t, nodes[0].Endpoints[0].Type, uint64(3))
+	require.Equal(t, nodes[0].Endpoints[0].Type, uint64(2))
 	require.Equal(t, nodes[0].Moniker, "sky")
 	require.Equal(t, nodes[0].Endpoints[0].Endpoint, "http://47.104.248.183")
 	require.Equal(t, nodes[0].Details, "sky")
@@ -95,7 +97,7 @@ func Test_ipal(t *testing.T) {
 
 	//test for unbond
 	bondAmount = types.NewCoin(types.NativeTokenName, types.NewInt(1000000000000000))
-	r = executeWrite(t, ipalClaimCmd("sky", "sky", "sky", "1|http://47.104.248.183,3|http://47.104.248.183", bondAmount.String(), nchcliHome, port), DefaultKeyPass)
+	r = executeWrite(t, ipalClaimCmd("sky", "sky", "sky", "1|http://47.104.248.183,2|http://47.104.248.183", bondAmount.String(), nchcliHome, port), DefaultKeyPass)
 	require.True(t, r)
 	tests.WaitForNextNBlocksTM(1, port)
 	nodes = executeGetIPALNodes(t, ipalQueryCmd(nchcliHome, port))
@@ -110,7 +112,7 @@ func Test_ipal(t *testing.T) {
 
 	//test for unbond
 	bondAmount = types.NewCoin(types.NativeTokenName, types.NewInt(20000))
-	r = executeWrite(t, ipalClaimCmd("sky", "sky", "sky", "1|http://47.104.248.183,3|http://47.104.248.183", bondAmount.String(), nchcliHome, port), DefaultKeyPass)
+	r = executeWrite(t, ipalClaimCmd("sky", "sky", "sky", "1|http://47.104.248.183,2|http://47.104.248.183", bondAmount.String(), nchcliHome, port), DefaultKeyPass)
 	require.True(t, r)
 	tests.WaitForNextNBlocksTM(1, port)
 	nodes = executeGetIPALNodes(t, ipalQueryCmd(nchcliHome, port))
@@ -2,6 +2,7 @@ package types
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/netcloth/netcloth-chain/codec"
 )
@@ -19,10 +20,16 @@ var (
 )
 
 type ProtocolDefinition struct {
-	Version   uint64 `json:"version"`
-	Software  string `json:"software"`
-	Height    uint64 `json:"height"`
-	Threshold Dec    `json:"threshold"`
+	Version    uint64    `json:"version"`
+	Software   string    `json:"software"`
+	Height     uint64    `json:"height"`
+	Threshold  Dec       `json:"threshold"`
+	SwitchTime time.Time `json:"switch_time"` // switches at the first block after the time instead of the height if set
+}
+
+// SwitchByTime returns whether the protocol switches at the switch time instead of the height
+func (pd ProtocolDefinition) SwitchByTime() bool {
+	return !pd.SwitchTime.IsZero()
 }
 
 type UpgradeConfig struct {
@@ -31,6 +38,11 @@ type UpgradeConfig struct {
 }
 
 func (uc UpgradeConfig) String() string {
+	if uc.Protocol.SwitchByTime() {
+		return fmt.Sprintf("proposalID: %v, version: %v, software: %s, switch time: %s, threshold: %s",
+			uc.ProposalID, uc.Protocol.Version, uc.Protocol.Software, uc.Protocol.SwitchTime.UTC().Format(time.RFC3339), uc.Protocol.Threshold.String(),
+		)
+	}
 	return fmt.Sprintf("proposalID: %v, version: %v, software: %s, height: %v, threshold: %s",
 		uc.ProposalID, uc.Protocol.Version, uc.Protocol.Software, uc.Protocol.Height, uc.Protocol.Threshold.String(),
 	)
@@ -38,10 +50,19 @@ func (uc UpgradeConfig) String() string {
 
 func NewProtocolDefinition(version uint64, software string, height uint64, threshold Dec) ProtocolDefinition {
 	return ProtocolDefinition{
-		version,
-		software,
-		height,
-		threshold,
+		Version:   version,
+		Software:  software,
+		Height:    height,
+		Threshold: threshold,
+	}
+}
+
+func NewTimedProtocolDefinition(version uint64, software string, switchTime time.Time, threshold Dec) ProtocolDefinition {
+	return ProtocolDefinition{
+		Version:    version,
+		Software:   software,
+		Threshold:  threshold,
+		SwitchTime: switchTime,
 	}
 }
 